@@ -34,7 +34,7 @@ function testVoucherNotFound() {
   check(response, {
     'testVoucherNotFound is status 200': (r) => r.status === 200,
     'testVoucherNotFound response body contains expected content': (r) =>
-      assertStringContains(r.body, 'voucher not found'),
+      assertStringContains(r.body, '"code":"NOT_FOUND"'),
   })
 }
 
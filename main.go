@@ -15,16 +15,16 @@ import (
 	"time"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/bootstrap"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/devnet"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/export"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
 	"github.com/carlmjohnson/versioninfo"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/joho/godotenv"
 	"github.com/lmittmann/tint"
 	"github.com/mattn/go-isatty"
-	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var (
@@ -37,8 +37,6 @@ GraphQL running at http://localhost:HTTP_PORT/graphql
 Press Ctrl+C to stop the node
 `
 
-var tempFromBlockL1 uint64
-
 var cmd = &cobra.Command{
 	Use:     "hlgraphql [flags] [-- application [args]...]",
 	Short:   "hlgraphql is a development node for Cartesi Rollups",
@@ -46,6 +44,45 @@ var cmd = &cobra.Command{
 	Version: versioninfo.Short(),
 }
 
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var ConfigPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration, with the secrets redacted",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		LoadEnv()
+		opts, err := loadConfig(cmd)
+		if err != nil {
+			exitf("%v", err)
+		}
+		out, err := bootstrap.PrintConfig(opts)
+		cobra.CheckErr(err)
+		fmt.Print(string(out))
+	},
+}
+
+var exportOpts struct {
+	app    string
+	entity string
+	format string
+	from   uint64
+	to     uint64
+	output string
+}
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the inputs, vouchers, notices or reports of an application",
+	Example: `hlgraphql export --app 0x75135d8ADb7180640d29d822D9AD59E83E8695b2 --entity vouchers --format parquet -o vouchers.parquet
+hlgraphql export --app 0x75135d8ADb7180640d29d822D9AD59E83E8695b2 --from-index 100 --to-index 199 > inputs.csv`,
+	Args: cobra.NoArgs,
+	Run:  runExport,
+}
+
 var CompletionCmd = &cobra.Command{
 	Use:                   "completion",
 	Short:                 "Generate shell completion scripts",
@@ -67,9 +104,8 @@ var CompletionCmd = &cobra.Command{
 }
 
 var (
-	debug bool
-	color bool
-	opts  = bootstrap.NewBootstrapOpts()
+	configFile string
+	opts       = bootstrap.NewBootstrapOpts()
 )
 
 func ArrBytesAttr(key string, v []byte) slog.Attr {
@@ -90,113 +126,148 @@ func CheckIfValidSize(size uint64) error {
 }
 
 func init() {
+	cmd.PersistentFlags().StringVar(&configFile, "config", "",
+		"YAML or TOML config file, its keys are the flag names. Flags and env vars take precedence over it")
+
 	// contracts-*
-	cmd.Flags().StringVar(&opts.ApplicationAddress, "contracts-application-address",
+	cmd.PersistentFlags().StringVar(&opts.ApplicationAddress, "contracts-application-address",
 		opts.ApplicationAddress, "Application contract address")
-	cmd.Flags().StringVar(&opts.InputBoxAddress, "contracts-input-box-address",
+	cmd.PersistentFlags().StringVar(&opts.ApplicationAddresses, "contracts-application-addresses",
+		opts.ApplicationAddresses, "Comma separated application contract addresses indexed from the L1 with the application one")
+	cmd.PersistentFlags().StringVar(&opts.InputBoxAddress, "contracts-input-box-address",
 		opts.InputBoxAddress, "InputBox contract address")
-	cmd.Flags().Uint64Var(&opts.InputBoxBlock, "contracts-input-box-block",
-		opts.InputBoxBlock, "InputBox deployment block number")
+	cmd.PersistentFlags().Uint64("contracts-input-box-block", 0,
+		"InputBox deployment block number, required with --rpc-url")
+	cmd.PersistentFlags().StringVar(&opts.ConsensusAddress, "contracts-consensus-address",
+		opts.ConsensusAddress, "If set, indexes the claims of this consensus contract from the rpc-url")
 
 	// enable-*
-	cmd.Flags().BoolVarP(&debug, "enable-debug", "d", false, "If set, enable debug output")
-	cmd.Flags().BoolVar(&color, "enable-color", true, "If set, enables logs color")
-	cmd.Flags().BoolVar(&opts.EnableEcho, "enable-echo", opts.EnableEcho,
+	cmd.PersistentFlags().BoolVarP(&opts.Debug, "enable-debug", "d", opts.Debug, "If set, enable debug output")
+	cmd.PersistentFlags().BoolVar(&opts.Color, "enable-color", opts.Color, "If set, enables logs color")
+	cmd.PersistentFlags().BoolVar(&opts.EnableEcho, "enable-echo", opts.EnableEcho,
 		"If set, hlgraphql starts a built-in echo application")
 
-	cmd.Flags().DurationVar(&opts.TimeoutWorker, "timeout-worker", opts.TimeoutWorker, "Timeout for workers. Example: hlgraphql --timeout-worker 30s")
-	cmd.Flags().DurationVar(&opts.TimeoutInspect, "sm-deadline-inspect-state", opts.TimeoutInspect, "Timeout for inspect requests. Example: hlgraphql --sm-deadline-inspect-state 30s")
+	cmd.PersistentFlags().DurationVar(&opts.TimeoutWorker, "timeout-worker", opts.TimeoutWorker, "Timeout for workers. Example: hlgraphql --timeout-worker 30s")
+	cmd.PersistentFlags().DurationVar(&opts.TimeoutInspect, "sm-deadline-inspect-state", opts.TimeoutInspect, "Timeout for inspect requests. Example: hlgraphql --sm-deadline-inspect-state 30s")
 
 	// disable-*
+	cmd.PersistentFlags().BoolVar(&opts.DisableInspect, "disable-inspect", opts.DisableInspect,
+		"If set, disables the inspect endpoint")
+
+	cmd.PersistentFlags().StringVar(&opts.InspectUrl, "inspect-url", opts.InspectUrl,
+		"Node inspect URL the inspect requests are forwarded to. Example: http://localhost:10012/inspect")
+
+	cmd.PersistentFlags().BoolVar(&opts.EnableAddInput, "enable-add-input", opts.EnableAddInput,
+		"If set, enables the addInput mutation. The inputs are sent with the key in SENDER_PRIVATE_KEY")
+	cmd.PersistentFlags().DurationVar(&opts.AddInputTimeout, "add-input-timeout", opts.AddInputTimeout,
+		"How long the addInput mutation waits for the input index")
+
+	cmd.PersistentFlags().BoolVar(&opts.EnableRelay, "enable-relay", opts.EnableRelay,
+		"If set, enables the /submit endpoint that relays EIP-712 signed inputs with the key in SENDER_PRIVATE_KEY")
+	cmd.PersistentFlags().DurationVar(&opts.RelayInterval, "relay-interval", opts.RelayInterval,
+		"How often the relayed inputs are sent to the InputBox")
+
+	cmd.PersistentFlags().StringVar(&opts.AbiDir, "abi-dir", opts.AbiDir,
+		"Directory with the <address>.json ABIs used to decode the payloads of vouchers, notices and reports")
+	cmd.PersistentFlags().StringVar(&opts.AdminToken, "admin-token", opts.AdminToken,
+		"If set, enables the admin mutations for the requests with this bearer token")
+	cmd.PersistentFlags().DurationVar(&opts.StatsCacheTTL, "stats-cache-ttl", opts.StatsCacheTTL,
+		"How long the result of the stats query is reused, it is computed on every request when zero")
+	cmd.PersistentFlags().StringVar(&opts.OtlpEndpoint, "otlp-endpoint", opts.OtlpEndpoint,
+		"OTLP/HTTP collector URL, like http://localhost:4318, the spans are exported to. Tracing is off when empty")
+	cmd.PersistentFlags().StringVar(&opts.WebhooksConfig, "webhooks-config", opts.WebhooksConfig,
+		"JSON file with the webhook endpoints notified of the voucher proofs, executions and input exceptions")
 
 	// http-*
-	cmd.Flags().StringVar(&opts.HttpAddress, "http-address", opts.HttpAddress,
+	cmd.PersistentFlags().StringVar(&opts.HttpAddress, "http-address", opts.HttpAddress,
 		"HTTP address used by hlgraphql to serve its APIs")
-	cmd.Flags().IntVar(&opts.HttpPort, "http-port", opts.HttpPort,
+	cmd.PersistentFlags().IntVar(&opts.HttpPort, "http-port", opts.HttpPort,
 		"HTTP port used by hlgraphql to serve its external APIs")
-	cmd.Flags().IntVar(&opts.HttpRollupsPort, "http-rollups-port", opts.HttpRollupsPort,
+	cmd.PersistentFlags().IntVar(&opts.HttpRollupsPort, "http-rollups-port", opts.HttpRollupsPort,
 		"HTTP port used by hlgraphql to serve its internal APIs")
 
 	// rpc-url
-	cmd.Flags().StringVar(&opts.RpcUrl, "rpc-url", opts.RpcUrl,
+	cmd.PersistentFlags().StringVar(&opts.RpcUrl, "rpc-url", opts.RpcUrl,
 		"If set, hlgraphql connects to this url instead of setting up Anvil")
 
 	// database file
-	cmd.Flags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
+	cmd.PersistentFlags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
 		"The sqlite file to load the state")
 
-	cmd.Flags().Uint64Var(&opts.FromBlock, "from-block", opts.FromBlock,
+	cmd.PersistentFlags().Uint64Var(&opts.FromBlock, "from-block", opts.FromBlock,
 		"The beginning of the queried range for events")
 
-	cmd.Flags().Uint64VarP(&tempFromBlockL1, "from-l1-block", "", 0, "The beginning of the queried range for events")
+	cmd.PersistentFlags().Uint64("from-l1-block", 0, "The beginning of the queried range for events")
 
-	cmd.Flags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
+	cmd.PersistentFlags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
 		"DB to use. PostgreSQL or SQLite")
 
-	cmd.Flags().StringVar(&opts.NodeVersion, "node-version", opts.NodeVersion,
+	cmd.PersistentFlags().StringVar(&opts.NodeVersion, "node-version", opts.NodeVersion,
 		"Node version to emulate")
 
-	cmd.Flags().BoolVar(&opts.LoadTestMode, "load-test-mode", opts.LoadTestMode,
+	cmd.PersistentFlags().BoolVar(&opts.LoadTestMode, "load-test-mode", opts.LoadTestMode,
 		"If set, enables load test mode")
 
-	cmd.Flags().BoolVar(&opts.GraphileDisableSync, "graphile-disable-sync", opts.GraphileDisableSync,
+	cmd.PersistentFlags().BoolVar(&opts.GraphileDisableSync, "graphile-disable-sync", opts.GraphileDisableSync,
 		"If set, disable graphile synchronization")
 
-	cmd.Flags().StringVar(&opts.GraphileUrl, "graphile-url", opts.GraphileUrl, "URL used to connect to Graphile")
-
-	cmd.Flags().StringVar(&opts.DbRawUrl, "db-raw-url", opts.DbRawUrl, "The raw database url")
-	cmd.Flags().BoolVar(&opts.RawEnabled, "raw-enabled", opts.RawEnabled, "If set, enables raw database")
-
-	cmd.Flags().IntVar(&opts.EpochBlocks, "epoch-blocks", opts.EpochBlocks,
+	cmd.PersistentFlags().StringVar(&opts.GraphileUrl, "graphile-url", opts.GraphileUrl, "URL used to connect to Graphile")
+
+	cmd.PersistentFlags().StringVar(&opts.DbRawUrl, "db-raw-url", opts.DbRawUrl, "The raw database url")
+	cmd.PersistentFlags().StringVar(&opts.DbUrl, "db-url", opts.DbUrl, "The Postgres database url of the API")
+	cmd.PersistentFlags().StringVar(&opts.DbReadUrl, "db-read-url", opts.DbReadUrl,
+		"If set, the queries read from this Postgres replica of the db-url")
+	cmd.PersistentFlags().BoolVar(&opts.RawEnabled, "raw-enabled", opts.RawEnabled, "If set, enables raw database")
+	cmd.PersistentFlags().StringVar(&opts.RawFixture, "raw-fixture", opts.RawFixture,
+		"JSON or YAML file used to simulate the raw database")
+	cmd.PersistentFlags().BoolVar(&opts.L1Indexer, "l1-indexer", opts.L1Indexer,
+		"If set, indexes the inputs and executions from the rpc-url instead of the raw database")
+	cmd.PersistentFlags().Uint64Var(&opts.L1Confirmations, "l1-confirmations", opts.L1Confirmations,
+		"Number of blocks the L1 and claim indexers stay behind the latest block")
+	cmd.PersistentFlags().StringVar(&opts.OutputExecutedSource, "output-executed-source", opts.OutputExecutedSource,
+		"Where the voucher executions come from: raw, l1 (OutputExecuted events from the rpc-url) or both")
+
+	cmd.PersistentFlags().IntVar(&opts.EpochBlocks, "epoch-blocks", opts.EpochBlocks,
 		"Number of blocks in each epoch")
+
+	// db-*
+	cmd.PersistentFlags().IntVar(&opts.DbMaxOpenConns, "db-max-open-conns", opts.DbMaxOpenConns,
+		"Maximum number of open connections to each Postgres database")
+	cmd.PersistentFlags().IntVar(&opts.DbMaxIdleConns, "db-max-idle-conns", opts.DbMaxIdleConns,
+		"Maximum number of idle connections to each Postgres database")
+	cmd.PersistentFlags().DurationVar(&opts.DbConnMaxLifetime, "db-conn-max-lifetime", opts.DbConnMaxLifetime,
+		"Maximum time a database connection is reused")
+	cmd.PersistentFlags().DurationVar(&opts.DbConnMaxIdleTime, "db-conn-max-idle-time", opts.DbConnMaxIdleTime,
+		"Maximum time a database connection stays idle")
+
+	// export
+	ExportCmd.Flags().StringVar(&exportOpts.app, "app", "", "Address of the application")
+	ExportCmd.Flags().StringVar(&exportOpts.entity, "entity", export.EntityInputs,
+		fmt.Sprintf("What to export, one of %s", strings.Join(export.Entities, ", ")))
+	ExportCmd.Flags().StringVar(&exportOpts.format, "format", export.FormatCSV,
+		fmt.Sprintf("Format of the file, one of %s", strings.Join(export.Formats, ", ")))
+	ExportCmd.Flags().Uint64Var(&exportOpts.from, "from-index", 0,
+		"First index to export, the output index of vouchers and notices")
+	ExportCmd.Flags().Uint64Var(&exportOpts.to, "to-index", 0,
+		"Last index to export, inclusive. Exports up to the last entry when not set")
+	ExportCmd.Flags().StringVarP(&exportOpts.output, "output", "o", "", "File to write, stdout when not set")
+	cobra.CheckErr(ExportCmd.MarkFlagRequired("app"))
 }
 
 func deprecatedWarningCmd(cmd *cobra.Command, flag string, replacement string) {
-	if cmd.Flags().Changed(flag) {
+	if cmd.PersistentFlags().Changed(flag) {
 		slog.Warn(fmt.Sprintf("The '%s' flag is deprecated. %s", flag, replacement))
 	}
 }
 
-func deprecatedFlags(cmd *cobra.Command) {
-	v := viper.New()
-	err := v.BindPFlags(cmd.Flags())
-	cobra.CheckErr(err)
-
-	deprecatedWarningCmd(cmd, "graphile-disable-sync", "")
-	checkAndSetFlag(cmd, "db-raw-url", func(val string) { opts.DbRawUrl = val }, "POSTGRES_NODE_DB_URL")
-
-	checkAndSetFlag(cmd, "contracts-application-address", func(val string) { opts.ApplicationAddress = val }, "APPLICATION_ADDRESS")
-	checkAndSetFlag(cmd, "contracts-input-box-address", func(val string) { opts.InputBoxAddress = val }, "INPUT_BOX_ADDRESS")
-	checkAndSetFlag(cmd, "contracts-input-box-block", func(val string) { opts.InputBoxBlock = cast.ToUint64(val) }, "INPUT_BOX_BLOCK")
-	checkAndSetFlag(cmd, "enable-debug", func(val string) { debug = cast.ToBool(val) }, "GRAPHQL_DEBUG")
-	checkAndSetFlag(cmd, "enable-color", func(val string) { color = cast.ToBool(val) }, "COLOR")
-	checkAndSetFlag(cmd, "enable-echo", func(val string) { opts.EnableEcho = cast.ToBool(val) }, "ENABLE_ECHO")
-	checkAndSetFlag(cmd, "timeout-worker", func(val string) { opts.TimeoutWorker, _ = time.ParseDuration(val) }, "TIMEOUT_WORKER")
-	checkAndSetFlag(cmd, "sm-deadline-inspect-state", func(val string) { opts.TimeoutInspect, _ = time.ParseDuration(val) }, "SM_DEADLINE_INSPECT_STATE")
-	checkAndSetFlag(cmd, "http-address", func(val string) { opts.HttpAddress = val }, "HTTP_ADDRESS")
-	checkAndSetFlag(cmd, "http-port", func(val string) { opts.HttpPort = cast.ToInt(val) }, "HTTP_PORT")
-	checkAndSetFlag(cmd, "http-rollups-port", func(val string) { opts.HttpRollupsPort = cast.ToInt(val) }, "HTTP_ROLLUPS_PORT")
-	checkAndSetFlag(cmd, "rpc-url", func(val string) { opts.RpcUrl = val }, "RPC_URL")
-	checkAndSetFlag(cmd, "sqlite-file", func(val string) { opts.SqliteFile = val }, "SQLITE_FILE")
-	checkAndSetFlag(cmd, "from-block", func(val string) { opts.FromBlock = cast.ToUint64(val) }, "FROM_BLOCK")
-	checkAndSetFlag(cmd, "from-l1-block", func(val string) { tempFromBlockL1 = cast.ToUint64(val) }, "FROM_BLOCK_L1")
-	checkAndSetFlag(cmd, "db-implementation", func(val string) { opts.DbImplementation = val }, "DB_IMPLEMENTATION")
-	checkAndSetFlag(cmd, "node-version", func(val string) { opts.NodeVersion = val }, "NODE_VERSION")
-	checkAndSetFlag(cmd, "load-test-mode", func(val string) { opts.LoadTestMode = cast.ToBool(val) }, "LOAD_TEST_MODE")
-	checkAndSetFlag(cmd, "epoch-blocks", func(val string) { opts.EpochBlocks = cast.ToInt(val) }, "EPOCH_BLOCKS")
-	checkAndSetFlag(cmd, "raw-enabled", func(val string) { opts.RawEnabled = cast.ToBool(val) }, "RAW_ENABLED")
-}
-
-/**
- * Check if the flag is set and set the value from the environment variable
- */
-func checkAndSetFlag(cmd *cobra.Command, flagName string, setOptEnv func(string), flagEnv string) {
-	val, isEnvVarPresent := os.LookupEnv(flagEnv)
-	if isEnvVarPresent {
-		setOptEnv(val)
+// loadConfig merges the --config file (or GRAPHQL_CONFIG), the env vars
+// and the flags of the command line
+func loadConfig(cmd *cobra.Command) (bootstrap.BootstrapOpts, error) {
+	file := configFile
+	if !cmd.Flags().Changed("config") {
+		file = os.Getenv("GRAPHQL_CONFIG")
 	}
-	deprecatedMsg := fmt.Sprint("Please use ", flagEnv, " instead.")
-	deprecatedWarningCmd(cmd, flagName, deprecatedMsg)
+	return bootstrap.LoadConfig(cmd.Flags(), file)
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -204,31 +275,29 @@ func run(cmd *cobra.Command, args []string) {
 	ctx := cmd.Context()
 	startTime := time.Now()
 
-	// setup log
-	logOpts := new(tint.Options)
-	if debug {
-		logOpts.Level = slog.LevelDebug
+	loaded, err := loadConfig(cmd)
+	configureLog(loaded, os.Stdout)
+	if err != nil {
+		exitf("%v", err)
 	}
-	logOpts.AddSource = debug
-	logOpts.NoColor = !color || !isatty.IsTerminal(os.Stdout.Fd())
-	logOpts.TimeFormat = "[15:04:05.000]"
-	handler := tint.NewHandler(os.Stdout, logOpts)
-	logger := slog.New(handler)
-	slog.SetDefault(logger)
+	opts = loaded
+	deprecatedWarningCmd(cmd, "graphile-disable-sync", "")
 
 	// check args
-	checkEthAddress(cmd, "address-input-box")
-	checkEthAddress(cmd, "address-application")
-	if !cmd.Flags().Changed("sequencer") && cmd.Flags().Changed("rpc-url") && !cmd.Flags().Changed("contracts-input-box-block") {
-		exitf("must set --contracts-input-box-block when setting --rpc-url")
-	}
 	if opts.EnableEcho && len(args) > 0 {
 		exitf("can't use built-in echo with custom application")
 	}
-	if cmd.Flags().Changed("from-l1-block") {
-		opts.FromBlockL1 = &tempFromBlockL1
+	for flag, enabled := range map[string]bool{
+		"enable-add-input": opts.EnableAddInput,
+		"enable-relay":     opts.EnableRelay,
+	} {
+		if !enabled {
+			continue
+		}
+		if _, ok := os.LookupEnv(inputsender.PrivateKeyEnv); !ok {
+			exitf("must set %s when setting --%s", inputsender.PrivateKeyEnv, flag)
+		}
 	}
-	deprecatedFlags(cmd)
 
 	// handle signals with notify context
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
@@ -260,10 +329,67 @@ func run(cmd *cobra.Command, args []string) {
 		case <-ctx.Done():
 		}
 	}()
-	var err error = bootstrap.NewSupervisorGraphQL(opts).Start(ctx, ready)
+	err = bootstrap.NewSupervisorGraphQL(opts).Start(ctx, ready)
 	cobra.CheckErr(err)
 }
 
+func runExport(cmd *cobra.Command, args []string) {
+	LoadEnv()
+	loaded, err := loadConfig(cmd)
+	// stdout may be the exported file
+	configureLog(loaded, os.Stderr)
+	if err != nil {
+		exitf("%v", err)
+	}
+	if !common.IsHexAddress(exportOpts.app) {
+		exitf("invalid --app address: %s", exportOpts.app)
+	}
+	// without the file SQLite would export a new empty database
+	if loaded.DbImplementation != "postgres" {
+		if loaded.SqliteFile == "" {
+			exitf("--sqlite-file is required to export from SQLite")
+		}
+		if _, err := os.Stat(loaded.SqliteFile); err != nil {
+			exitf("invalid --sqlite-file: %v", err)
+		}
+	}
+	options := export.Options{
+		AppContract: common.HexToAddress(exportOpts.app),
+		Entity:      exportOpts.entity,
+		Format:      exportOpts.format,
+		FromIndex:   exportOpts.from,
+	}
+	if cmd.Flags().Changed("to-index") {
+		options.ToIndex = &exportOpts.to
+	}
+	if err := options.Validate(); err != nil {
+		exitf("%v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	out := os.Stdout
+	if exportOpts.output != "" && exportOpts.output != "-" {
+		out, err = os.Create(exportOpts.output)
+		cobra.CheckErr(err)
+	}
+	db := bootstrap.CreateDBInstance(loaded)
+	defer db.Close()
+	// the tables are read as they are, the export does not run DDL
+	repos := export.NewReadRepositories(db, bootstrap.CreateReadDBInstance(loaded))
+	startTime := time.Now()
+	err = export.Export(ctx, repos, options, out)
+	if err == nil && out != os.Stdout {
+		err = out.Close()
+	}
+	if err != nil {
+		slog.Error("export failed", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("export finished", "entity", options.Entity, "after", time.Since(startTime))
+}
+
 //go:embed .env
 var envBuilded string
 
@@ -292,7 +418,23 @@ func LoadEnv() {
 	slog.Debug("env: loaded")
 }
 
+func configureLog(opts bootstrap.BootstrapOpts, out *os.File) {
+	logOpts := new(tint.Options)
+	if opts.Debug {
+		logOpts.Level = slog.LevelDebug
+	}
+	logOpts.AddSource = opts.Debug
+	logOpts.NoColor = !opts.Color || !isatty.IsTerminal(out.Fd())
+	logOpts.TimeFormat = "[15:04:05.000]"
+	handler := commons.NewTraceHandler(tint.NewHandler(out, logOpts))
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+}
+
 func main() {
+	ConfigCmd.AddCommand(ConfigPrintCmd)
+	cmd.AddCommand(ConfigCmd)
+	cmd.AddCommand(ExportCmd)
 	cmd.AddCommand(CompletionCmd)
 	cobra.CheckErr(cmd.Execute())
 }
@@ -302,17 +444,3 @@ func exitf(format string, args ...any) {
 	slog.Error("configuration error", "error", err)
 	os.Exit(1)
 }
-
-func checkEthAddress(cmd *cobra.Command, varName string) {
-	if cmd.Flags().Changed(varName) {
-		value, err := cmd.Flags().GetString(varName)
-		cobra.CheckErr(err)
-		bytes, err := hexutil.Decode(value)
-		if err != nil {
-			exitf("invalid address for --%v: %v", varName, err)
-		}
-		if len(bytes) != common.AddressLength {
-			exitf("invalid address for --%v: wrong length", varName)
-		}
-	}
-}
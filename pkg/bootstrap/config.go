@@ -0,0 +1,356 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+// configField is a BootstrapOpts field that can be set
+// from the config file, the environment or a flag
+type configField struct {
+	index  int
+	key    string
+	env    string
+	secret string
+}
+
+func configFields() []configField {
+	t := reflect.TypeOf(BootstrapOpts{})
+	fields := []configField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+		fields = append(fields, configField{
+			index:  i,
+			key:    key,
+			env:    field.Tag.Get("env"),
+			secret: field.Tag.Get("secret"),
+		})
+	}
+	return fields
+}
+
+// LoadConfig merges the defaults, the config file, the environment and the
+// flags set on the command line, in this order of precedence, and validates
+// the result. The file may be YAML or TOML, its keys are the flag names.
+func LoadConfig(flags *pflag.FlagSet, configFile string) (BootstrapOpts, error) {
+	opts := NewBootstrapOpts()
+	fields := configFields()
+
+	if configFile != "" {
+		settings, err := readConfigFile(configFile, fields)
+		if err != nil {
+			return opts, err
+		}
+		if err := decodeConfig(settings, &opts, false); err != nil {
+			return opts, fmt.Errorf("config file %s: %w", configFile, err)
+		}
+	}
+
+	for _, field := range fields {
+		if field.env == "" {
+			continue
+		}
+		value, ok := os.LookupEnv(field.env)
+		if !ok {
+			continue
+		}
+		err := decodeConfig(map[string]any{field.key: value}, &opts, true)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s: %w", field.env, err)
+		}
+	}
+
+	if flags != nil {
+		for _, field := range fields {
+			flag := flags.Lookup(field.key)
+			if flag == nil || !flag.Changed {
+				continue
+			}
+			err := decodeConfig(map[string]any{field.key: flag.Value.String()}, &opts, true)
+			if err != nil {
+				return opts, fmt.Errorf("invalid --%s: %w", field.key, err)
+			}
+		}
+	}
+
+	return opts, opts.Validate()
+}
+
+func readConfigFile(configFile string, fields []configField) (map[string]any, error) {
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read the config file %s: %w", configFile, err)
+	}
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field.key] = true
+	}
+	settings := map[string]any{}
+	unknown := []error{}
+	// the keys are read directly, viper would split them on dots
+	for key := range v.AllSettings() {
+		if !known[key] {
+			unknown = append(unknown, fmt.Errorf("config file %s: unknown key %q", configFile, key))
+			continue
+		}
+		settings[key] = v.Get(key)
+	}
+	if len(unknown) > 0 {
+		return nil, errors.Join(unknown...)
+	}
+	return settings, nil
+}
+
+// decodeConfig sets the fields of the opts in the settings. The env vars and
+// the flags are strings, so they are weakly typed, the file values are not.
+func decodeConfig(settings map[string]any, opts *BootstrapOpts, weak bool) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       durationHook,
+		ErrorUnused:      true,
+		WeaklyTypedInput: weak,
+		Result:           opts,
+	})
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(settings)
+	var decodeErr *mapstructure.Error
+	if errors.As(err, &decodeErr) {
+		errs := make([]error, len(decodeErr.Errors))
+		for i, msg := range decodeErr.Errors {
+			errs[i] = errors.New(msg)
+		}
+		return errors.Join(errs...)
+	}
+	return err
+}
+
+// durationHook accepts Go durations, like 30s, and integers as seconds
+func durationHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if to != reflect.TypeOf(time.Duration(0)) {
+		return data, nil
+	}
+	switch value := data.(type) {
+	case string:
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.Duration(seconds) * time.Second, nil
+		}
+		return time.ParseDuration(value)
+	case int:
+		return time.Duration(value) * time.Second, nil
+	case int64:
+		return time.Duration(value) * time.Second, nil
+	}
+	return data, nil
+}
+
+// Validate checks the options together, returning all the problems at once
+func (opts BootstrapOpts) Validate() error {
+	errs := []error{}
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for key, address := range map[string]string{
+		"contracts-input-box-address":   opts.InputBoxAddress,
+		"contracts-application-address": opts.ApplicationAddress,
+		"contracts-consensus-address":   opts.ConsensusAddress,
+	} {
+		if address == "" {
+			continue
+		}
+		if err := checkAddress(address); err != nil {
+			fail("invalid address for %s: %v", key, err)
+		}
+	}
+	if opts.ApplicationAddresses != "" {
+		for _, address := range strings.Split(opts.ApplicationAddresses, ",") {
+			if err := checkAddress(strings.TrimSpace(address)); err != nil {
+				fail("invalid address for contracts-application-addresses: %s: %v", address, err)
+			}
+		}
+	}
+	for key, port := range map[string]int{
+		"http-port":         opts.HttpPort,
+		"http-rollups-port": opts.HttpRollupsPort,
+	} {
+		if port < 1 || port > 65535 {
+			fail("invalid %s: %d is not between 1 and 65535", key, port)
+		}
+	}
+	if opts.DbImplementation != "postgres" && opts.DbImplementation != "sqlite" {
+		fail("invalid db-implementation: %q, must be postgres or sqlite", opts.DbImplementation)
+	}
+
+	switch opts.OutputExecutedSource {
+	case OutputExecutedSourceRaw:
+	case OutputExecutedSourceL1, OutputExecutedSourceBoth:
+		if opts.RpcUrl == "" {
+			fail("must set rpc-url when setting output-executed-source=%s", opts.OutputExecutedSource)
+		}
+		if !opts.L1Indexer && len(opts.Applications()) == 0 {
+			fail("must set contracts-application-address when setting output-executed-source=%s", opts.OutputExecutedSource)
+		}
+	default:
+		fail("invalid output-executed-source: %q, must be raw, l1 or both", opts.OutputExecutedSource)
+	}
+	for key, enabled := range map[string]bool{
+		"l1-indexer":                  opts.L1Indexer,
+		"contracts-consensus-address": opts.ConsensusAddress != "",
+		"enable-add-input":            opts.EnableAddInput,
+		"enable-relay":                opts.EnableRelay,
+	} {
+		if enabled && opts.RpcUrl == "" {
+			fail("must set rpc-url when setting %s", key)
+		}
+	}
+	if opts.RpcUrl != "" && opts.InputBoxBlock == nil {
+		fail("must set contracts-input-box-block when setting rpc-url")
+	}
+	if opts.EnableRelay && opts.RelayInterval <= 0 {
+		fail("relay-interval must be positive when setting enable-relay")
+	}
+
+	for key, timeout := range map[string]time.Duration{
+		"sm-deadline-inspect-state": opts.TimeoutInspect,
+		"timeout-worker":            opts.TimeoutWorker,
+		"add-input-timeout":         opts.AddInputTimeout,
+	} {
+		if timeout <= 0 {
+			fail("%s must be positive", key)
+		}
+	}
+	if opts.StatsCacheTTL < 0 {
+		fail("stats-cache-ttl must not be negative")
+	}
+	if opts.EpochBlocks < 0 {
+		fail("epoch-blocks must not be negative")
+	}
+	for key, value := range map[string]int64{
+		"db-max-open-conns":     int64(opts.DbMaxOpenConns),
+		"db-max-idle-conns":     int64(opts.DbMaxIdleConns),
+		"db-conn-max-lifetime":  int64(opts.DbConnMaxLifetime),
+		"db-conn-max-idle-time": int64(opts.DbConnMaxIdleTime),
+	} {
+		if value < 0 {
+			fail("%s must not be negative", key)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkAddress(address string) error {
+	bytes, err := hexutil.Decode(address)
+	if err != nil {
+		return err
+	}
+	if len(bytes) != common.AddressLength {
+		return fmt.Errorf("wrong length")
+	}
+	return nil
+}
+
+// PrintConfig renders the options as a YAML config file, in the order
+// of the fields, with the secrets and the URL passwords redacted
+func PrintConfig(opts BootstrapOpts) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	value := reflect.ValueOf(opts)
+	for _, field := range configFields() {
+		fieldValue := value.Field(field.index)
+		var out any
+		switch v := fieldValue.Interface().(type) {
+		case time.Duration:
+			out = v.String()
+		case *uint64:
+			if v == nil {
+				continue
+			}
+			out = *v
+		case string:
+			out = redact(v, field.secret)
+		default:
+			out = v
+		}
+		var node yaml.Node
+		if err := node.Encode(out); err != nil {
+			return nil, err
+		}
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: field.key},
+			&node,
+		)
+	}
+	return yaml.Marshal(root)
+}
+
+func redact(value string, secret string) string {
+	if value == "" {
+		return value
+	}
+	switch secret {
+	case "true":
+		return redacted
+	case "url":
+		return redactUrl(value)
+	}
+	return value
+}
+
+// secretParam matches the password, secret and token keys
+// of the URL queries and the key=value DSNs
+var secretParam = regexp.MustCompile(`(?i)password|secret|token|^pass$|^pwd$`)
+
+// dsnParam is a key=value pair of a DSN, the value may be quoted
+var dsnParam = regexp.MustCompile(`(\w+)\s*=\s*('(?:[^'\\]|\\.)*'|\S*)`)
+
+// redactUrl redacts the password of the URL user and the secret
+// query parameters, or the secret keys of a key=value DSN. Other
+// values are fully redacted.
+func redactUrl(value string) string {
+	u, err := url.Parse(value)
+	if err == nil && u.Scheme != "" && u.Opaque == "" {
+		query := u.Query()
+		found := false
+		for key := range query {
+			if secretParam.MatchString(key) {
+				query.Set(key, "xxxxx")
+				found = true
+			}
+		}
+		if found {
+			u.RawQuery = query.Encode()
+		}
+		return u.Redacted()
+	}
+	if !dsnParam.MatchString(value) {
+		return redacted
+	}
+	return dsnParam.ReplaceAllStringFunc(value, func(param string) string {
+		key := dsnParam.FindStringSubmatch(param)[1]
+		if secretParam.MatchString(key) {
+			return key + "=xxxxx"
+		}
+		return param
+	})
+}
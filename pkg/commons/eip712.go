@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math/big"
+	"reflect"
 	"unicode"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -26,16 +28,89 @@ const (
 	COIN_TYPE_INDEX = 60
 )
 
-func NewCartesiDomain(chainId *math.HexOrDecimal256) apitypes.TypedDataDomain {
-	verifyingContract := common.HexToAddress("0x0")
+const (
+	CARTESI_DOMAIN_NAME    = "Cartesi"
+	CARTESI_DOMAIN_VERSION = "0.1.0"
+	CARTESI_PRIMARY_TYPE   = "CartesiMessage"
+)
+
+// NewCartesiDomain is the domain of the messages sent through the InputBox
+// deployed at verifyingContract
+func NewCartesiDomain(chainId *math.HexOrDecimal256, verifyingContract common.Address) apitypes.TypedDataDomain {
 	return apitypes.TypedDataDomain{
-		Name:              "Cartesi",
-		Version:           "0.1.0",
+		Name:              CARTESI_DOMAIN_NAME,
+		Version:           CARTESI_DOMAIN_VERSION,
 		ChainId:           chainId,
-		VerifyingContract: verifyingContract.String(),
+		VerifyingContract: verifyingContract.Hex(),
+	}
+}
+
+func newCartesiTypes() apitypes.Types {
+	return apitypes.Types{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		},
+		CARTESI_PRIMARY_TYPE: {
+			{Name: "app", Type: "address"},
+			{Name: "nonce", Type: "uint64"},
+			{Name: "max_gas_price", Type: "uint128"},
+			{Name: "data", Type: "bytes"},
+		},
+	}
+}
+
+// NewCartesiTypedData builds the app message a user signs to have
+// an input sent on their behalf to the InputBox at verifyingContract
+func NewCartesiTypedData(
+	chainId *math.HexOrDecimal256,
+	verifyingContract common.Address,
+	app common.Address,
+	nonce uint64,
+	maxGasPrice uint64,
+	data []byte,
+) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       newCartesiTypes(),
+		PrimaryType: CARTESI_PRIMARY_TYPE,
+		Domain:      NewCartesiDomain(chainId, verifyingContract),
+		Message: apitypes.TypedDataMessage{
+			"app":           app.Hex(),
+			"nonce":         fmt.Sprint(nonce),
+			"max_gas_price": fmt.Sprint(maxGasPrice),
+			"data":          hexutil.Encode(data),
+		},
 	}
 }
 
+// CheckCartesiTypedData rejects the messages signed for another type, chain
+// or InputBox, so their signatures cannot be replayed here
+func CheckCartesiTypedData(typedData apitypes.TypedData, chainId *big.Int, verifyingContract common.Address) error {
+	if typedData.PrimaryType != CARTESI_PRIMARY_TYPE {
+		return fmt.Errorf("invalid primary type %q", typedData.PrimaryType)
+	}
+	if !reflect.DeepEqual(typedData.Types, newCartesiTypes()) {
+		return fmt.Errorf("invalid types")
+	}
+	domain := typedData.Domain
+	if domain.Name != CARTESI_DOMAIN_NAME || domain.Version != CARTESI_DOMAIN_VERSION {
+		return fmt.Errorf("invalid domain %s %s", domain.Name, domain.Version)
+	}
+	if domain.ChainId == nil || (*big.Int)(domain.ChainId).Cmp(chainId) != 0 {
+		return fmt.Errorf("invalid domain chain id, expected %s", chainId)
+	}
+	if !common.IsHexAddress(domain.VerifyingContract) ||
+		common.HexToAddress(domain.VerifyingContract) != verifyingContract {
+		return fmt.Errorf("invalid domain verifying contract, expected %s", verifyingContract.Hex())
+	}
+	if domain.Salt != "" {
+		return fmt.Errorf("invalid domain salt")
+	}
+	return nil
+}
+
 // Implement the hashing function based on EIP-712 requirements
 func HashEIP712Message(data apitypes.TypedData) ([]byte, error) {
 	hash, _, err := apitypes.TypedDataAndHash(data)
@@ -54,6 +129,33 @@ func SignMessage(hash []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
 	return signature, nil
 }
 
+// EncodeSigAndData signs the typed data and encodes it the way
+// ExtractSigAndData reads it, as a wallet would
+func EncodeSigAndData(typedData apitypes.TypedData, privateKey *ecdsa.PrivateKey) (string, error) {
+	hash, err := HashEIP712Message(typedData)
+	if err != nil {
+		return "", err
+	}
+	signature, err := SignMessage(hash, privateKey)
+	if err != nil {
+		return "", err
+	}
+	// wallets use 27 and 28 as recovery id
+	signature[64] += 27
+	typedDataBytes, err := json.Marshal(typedData)
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal(SigAndData{
+		Signature: hexutil.Encode(signature),
+		TypedData: base64.StdEncoding.EncodeToString(typedDataBytes),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
 func trimNonPrintablePrefix(s string) string {
 	for i, r := range s {
 		if unicode.IsPrint(r) && r == '{' {
@@ -1,13 +1,20 @@
 package commons
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/stretchr/testify/suite"
 )
 
-var Token = common.HexToAddress("0xc6e7DF5E7b4f2A278906862b61205850344D4e7d")
+var (
+	Token    = common.HexToAddress("0xc6e7DF5E7b4f2A278906862b61205850344D4e7d")
+	InputBox = common.HexToAddress("0x59b22D57D4f067708AB0c00552767405926dc768")
+)
 
 type EIP712Suite struct {
 	suite.Suite
@@ -24,3 +31,43 @@ func TestDecoderSuite(t *testing.T) {
 func (s *EIP712Suite) TestHandleOutput() {
 	s.Equal(1, 1)
 }
+
+func (s *EIP712Suite) TestEncodeAndExtractSigAndData() {
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	typedData := NewCartesiTypedData(
+		math.NewHexOrDecimal256(HARDHAT), InputBox, Token, 1, 10, []byte{0xde, 0xad}, // nolint
+	)
+	raw, err := EncodeSigAndData(typedData, privateKey)
+	s.Require().NoError(err)
+
+	signer, extracted, _, err := ExtractSigAndData(raw)
+	s.Require().NoError(err)
+	s.Equal(crypto.PubkeyToAddress(privateKey.PublicKey), signer)
+	s.Equal(Token.Hex(), extracted.Message["app"])
+	s.Equal("0xdead", extracted.Message["data"])
+}
+
+func (s *EIP712Suite) TestCheckCartesiTypedData() {
+	chainId := big.NewInt(HARDHAT)
+	newTypedData := func() apitypes.TypedData {
+		return NewCartesiTypedData(
+			math.NewHexOrDecimal256(HARDHAT), InputBox, Token, 1, 10, []byte{0xde, 0xad}, // nolint
+		)
+	}
+	s.NoError(CheckCartesiTypedData(newTypedData(), chainId, InputBox))
+
+	otherChain := newTypedData()
+	otherChain.Domain.ChainId = math.NewHexOrDecimal256(1)
+	otherContract := newTypedData()
+	otherContract.Domain.VerifyingContract = Token.Hex()
+	otherName := newTypedData()
+	otherName.Domain.Name = "Other"
+	otherType := newTypedData()
+	otherType.PrimaryType = "EIP712Domain"
+	otherTypes := newTypedData()
+	otherTypes.Types[CARTESI_PRIMARY_TYPE] = otherTypes.Types[CARTESI_PRIMARY_TYPE][:3]
+	for _, typedData := range []apitypes.TypedData{otherChain, otherContract, otherName, otherType, otherTypes} {
+		s.Error(CheckCartesiTypedData(typedData, chainId, InputBox))
+	}
+}
@@ -1,10 +1,12 @@
 package commons
 
 import (
+	"context"
 	"log/slog"
 	"os"
 
 	"github.com/lmittmann/tint"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func ConfigureLog(level slog.Leveler) {
@@ -13,7 +15,37 @@ func ConfigureLog(level slog.Leveler) {
 	logOpts.AddSource = true
 	logOpts.NoColor = false
 	logOpts.TimeFormat = "[15:04:05.000]"
-	handler := tint.NewHandler(os.Stdout, logOpts)
+	handler := NewTraceHandler(tint.NewHandler(os.Stdout, logOpts))
 	logger := slog.New(handler)
 	slog.SetDefault(logger)
 }
+
+// NewTraceHandler wraps the handler to add the trace id of the context
+func NewTraceHandler(handler slog.Handler) slog.Handler {
+	return traceHandler{handler}
+}
+
+// traceHandler adds the trace id of the context to the records,
+// for the logs written with slog.*Context inside a span
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, record slog.Record) error {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("traceId", spanContext.TraceID().String()),
+			slog.String("spanId", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
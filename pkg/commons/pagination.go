@@ -99,11 +99,11 @@ func EncodeCursor(offset int) string {
 func DecodeCursor(base64Cursor string, total int) (int, error) {
 	cursorBytes, err := base64.StdEncoding.DecodeString(base64Cursor)
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
 	}
 	offset, err := strconv.Atoi(string(cursorBytes))
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
 	}
 	if offset < 0 || offset >= total {
 		return 0, ErrInvalidCursor
@@ -16,23 +16,32 @@ import (
 // what is the best DI/IoC framework for go?
 
 type Container struct {
-	db                     *sqlx.DB
-	outputDecoder          *decoder.OutputDecoder
-	convenienceService     *services.ConvenienceService
-	outputRepository       *repository.OutputRepository
-	repository             *repository.VoucherRepository
-	syncRepository         *repository.SynchronizerRepository
-	graphQLSynchronizer    *synchronizer.Synchronizer
-	voucherFetcher         *synchronizer.VoucherFetcher
-	noticeRepository       *repository.NoticeRepository
-	graphileFetcher        *synchronizer.GraphileFetcher
-	graphileSynchronizer   *synchronizer.GraphileSynchronizer
-	graphileClient         graphile.GraphileClient
-	inputRepository        *repository.InputRepository
-	reportRepository       *repository.ReportRepository
-	AutoCount              bool
-	rawInputRefRepository  *repository.RawInputRefRepository
-	rawOutputRefRepository *repository.RawOutputRefRepository
+	db                   *sqlx.DB
+	outputDecoder        *decoder.OutputDecoder
+	convenienceService   *services.ConvenienceService
+	outputRepository     *repository.OutputRepository
+	repository           *repository.VoucherRepository
+	syncRepository       *repository.SynchronizerRepository
+	graphQLSynchronizer  *synchronizer.Synchronizer
+	voucherFetcher       *synchronizer.VoucherFetcher
+	noticeRepository     *repository.NoticeRepository
+	graphileFetcher      *synchronizer.GraphileFetcher
+	graphileSynchronizer *synchronizer.GraphileSynchronizer
+	graphileClient       graphile.GraphileClient
+	inputRepository      *repository.InputRepository
+	reportRepository     *repository.ReportRepository
+	AutoCount            bool
+	// ReadDb is an optional read replica used by the query repositories
+	ReadDb                     *sqlx.DB
+	rawInputRefRepository      *repository.RawInputRefRepository
+	rawOutputRefRepository     *repository.RawOutputRefRepository
+	l1CheckpointRepository     *repository.L1CheckpointRepository
+	pendingExecutionRepository *repository.PendingExecutionRepository
+	claimRepository            *repository.ClaimRepository
+	abiRepository              *repository.AbiRepository
+	abiRegistry                *decoder.AbiRegistry
+	searchRepository           *repository.SearchRepository
+	webhookRepository          *repository.WebhookRepository
 }
 
 func NewContainer(db sqlx.DB, autoCount bool) *Container {
@@ -55,7 +64,8 @@ func (c *Container) GetOutputRepository() *repository.OutputRepository {
 		return c.outputRepository
 	}
 	c.outputRepository = &repository.OutputRepository{
-		Db: *c.db,
+		Db:     *c.db,
+		ReadDb: c.ReadDb,
 	}
 	return c.outputRepository
 }
@@ -68,6 +78,7 @@ func (c *Container) GetVoucherRepository() *repository.VoucherRepository {
 		Db:               *c.db,
 		OutputRepository: *c.GetOutputRepository(),
 		AutoCount:        c.AutoCount,
+		ReadDb:           c.ReadDb,
 	}
 	err := c.repository.CreateTables()
 	if err != nil {
@@ -98,6 +109,7 @@ func (c *Container) GetNoticeRepository() *repository.NoticeRepository {
 		Db:               *c.db,
 		OutputRepository: *c.GetOutputRepository(),
 		AutoCount:        c.AutoCount,
+		ReadDb:           c.ReadDb,
 	}
 	err := c.noticeRepository.CreateTables()
 	if err != nil {
@@ -137,12 +149,78 @@ func (c *Container) GetRawOutputRefRepository() *repository.RawOutputRefReposito
 	return c.rawOutputRefRepository
 }
 
+func (c *Container) GetL1CheckpointRepository() *repository.L1CheckpointRepository {
+	if c.l1CheckpointRepository != nil {
+		return c.l1CheckpointRepository
+	}
+	c.l1CheckpointRepository = &repository.L1CheckpointRepository{
+		Db: *c.db,
+	}
+	err := c.l1CheckpointRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	return c.l1CheckpointRepository
+}
+
+func (c *Container) GetPendingExecutionRepository() *repository.PendingExecutionRepository {
+	if c.pendingExecutionRepository != nil {
+		return c.pendingExecutionRepository
+	}
+	c.pendingExecutionRepository = &repository.PendingExecutionRepository{
+		Db: *c.db,
+	}
+	err := c.pendingExecutionRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	return c.pendingExecutionRepository
+}
+
+func (c *Container) GetClaimRepository() *repository.ClaimRepository {
+	if c.claimRepository != nil {
+		return c.claimRepository
+	}
+	c.claimRepository = &repository.ClaimRepository{
+		Db:     *c.db,
+		ReadDb: c.ReadDb,
+	}
+	err := c.claimRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	return c.claimRepository
+}
+
+func (c *Container) GetAbiRepository() *repository.AbiRepository {
+	if c.abiRepository != nil {
+		return c.abiRepository
+	}
+	c.abiRepository = &repository.AbiRepository{
+		Db: *c.db,
+	}
+	err := c.abiRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	return c.abiRepository
+}
+
+func (c *Container) GetAbiRegistry() *decoder.AbiRegistry {
+	if c.abiRegistry != nil {
+		return c.abiRegistry
+	}
+	c.abiRegistry = decoder.NewAbiRegistry(c.GetAbiRepository())
+	return c.abiRegistry
+}
+
 func (c *Container) GetInputRepository() *repository.InputRepository {
 	if c.inputRepository != nil {
 		return c.inputRepository
 	}
 	c.inputRepository = &repository.InputRepository{
-		Db: *c.db,
+		Db:     *c.db,
+		ReadDb: c.ReadDb,
 	}
 	err := c.inputRepository.CreateTables()
 	if err != nil {
@@ -157,6 +235,7 @@ func (c *Container) GetReportRepository() *repository.ReportRepository {
 	}
 	c.reportRepository = &repository.ReportRepository{
 		Db:        c.db,
+		ReadDb:    c.ReadDb,
 		AutoCount: c.AutoCount,
 	}
 	err := c.reportRepository.CreateTables()
@@ -166,6 +245,36 @@ func (c *Container) GetReportRepository() *repository.ReportRepository {
 	return c.reportRepository
 }
 
+func (c *Container) GetSearchRepository() *repository.SearchRepository {
+	if c.searchRepository != nil {
+		return c.searchRepository
+	}
+	c.searchRepository = &repository.SearchRepository{
+		Db:     c.db,
+		ReadDb: c.ReadDb,
+	}
+	err := c.searchRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	return c.searchRepository
+}
+
+func (c *Container) GetWebhookRepository() *repository.WebhookRepository {
+	if c.webhookRepository != nil {
+		return c.webhookRepository
+	}
+	c.webhookRepository = &repository.WebhookRepository{
+		Db:     c.db,
+		ReadDb: c.ReadDb,
+	}
+	err := c.webhookRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	return c.webhookRepository
+}
+
 func (c *Container) GetConvenienceService() *services.ConvenienceService {
 	if c.convenienceService != nil {
 		return c.convenienceService
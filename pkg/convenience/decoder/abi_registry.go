@@ -0,0 +1,288 @@
+package decoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrInvalidAbi = errors.New("invalid ABI")
+
+const (
+	DECODED_FUNCTION = "function"
+	DECODED_EVENT    = "event"
+)
+
+// MISS_TTL is how long an address without ABI is not looked up again
+const MISS_TTL = 30 * time.Second
+
+// AbiRegistry decodes the payloads with the ABIs of the applications and of
+// the voucher destinations, loaded from a directory or registered at runtime
+type AbiRegistry struct {
+	repository *repository.AbiRepository
+	mutex      sync.RWMutex
+	abis       map[common.Address]*abi.ABI
+	// misses has when each address without ABI was looked up
+	misses  map[common.Address]time.Time
+	missTTL time.Duration
+}
+
+// DecodedPayload is the function or event whose selector starts the payload
+type DecodedPayload struct {
+	// DECODED_FUNCTION or DECODED_EVENT
+	Kind      string
+	Name      string
+	Signature string
+	// JSON object with the named arguments in the order of the ABI
+	Args string
+}
+
+func NewAbiRegistry(repository *repository.AbiRepository) *AbiRegistry {
+	return &AbiRegistry{
+		repository: repository,
+		abis:       map[common.Address]*abi.ABI{},
+		misses:     map[common.Address]time.Time{},
+		missTTL:    MISS_TTL,
+	}
+}
+
+// LoadDir registers the <address>.json files of the directory. Each file has
+// the ABI itself or a compiler artifact with an abi field.
+func (r *AbiRegistry) LoadDir(ctx context.Context, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		address := strings.TrimSuffix(entry.Name(), ".json")
+		if !common.IsHexAddress(address) {
+			slog.Warn("ignoring ABI file not named after an address", "file", entry.Name())
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		err = r.Register(ctx, common.HexToAddress(address), string(content))
+		if err != nil {
+			return fmt.Errorf("ABI file %s: %w", entry.Name(), err)
+		}
+		slog.Debug("ABI loaded", "address", address)
+	}
+	return nil
+}
+
+// Register validates and stores the ABI of the address, replacing the previous one
+func (r *AbiRegistry) Register(ctx context.Context, address common.Address, content string) error {
+	abiJSON, parsed, err := parseAbi(content)
+	if err != nil {
+		return err
+	}
+	err = r.repository.Save(ctx, address, abiJSON)
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.abis[address] = parsed
+	delete(r.misses, address)
+	return nil
+}
+
+// Get returns nil when the address has no ABI. The misses are read again
+// from the database after MISS_TTL, since the ABI may have been registered
+// by another instance.
+func (r *AbiRegistry) Get(ctx context.Context, address common.Address) (*abi.ABI, error) {
+	r.mutex.RLock()
+	parsed, ok := r.abis[address]
+	missedAt, missed := r.misses[address]
+	r.mutex.RUnlock()
+	if ok {
+		return parsed, nil
+	}
+	if missed && time.Since(missedAt) < r.missTTL {
+		return nil, nil
+	}
+	abiJSON, err := r.repository.FindByAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if abiJSON == nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.misses[address] = time.Now()
+		return nil, nil
+	}
+	_, parsed, err = parseAbi(*abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.abis[address] = parsed
+	delete(r.misses, address)
+	return parsed, nil
+}
+
+// Decode looks for the selector of the data in the ABIs of the addresses,
+// in order. It returns nil when no function or event matches.
+func (r *AbiRegistry) Decode(
+	ctx context.Context, data []byte, addresses ...common.Address,
+) (*DecodedPayload, error) {
+	if len(data) < 4 { // nolint
+		return nil, nil
+	}
+	for _, address := range addresses {
+		parsed, err := r.Get(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		if parsed == nil {
+			continue
+		}
+		if decoded := decodeWithAbi(parsed, data); decoded != nil {
+			return decoded, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseAbi returns the ABI array and its parsed form
+func parseAbi(content string) (string, *abi.ABI, error) {
+	abiJSON := strings.TrimSpace(content)
+	if strings.HasPrefix(abiJSON, "{") {
+		var artifact struct {
+			Abi json.RawMessage `json:"abi"`
+		}
+		err := json.Unmarshal([]byte(abiJSON), &artifact)
+		if err != nil || len(artifact.Abi) == 0 {
+			return "", nil, fmt.Errorf("%w: object without an abi field", ErrInvalidAbi)
+		}
+		abiJSON = string(artifact.Abi)
+	}
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidAbi, err.Error())
+	}
+	return abiJSON, &parsed, nil
+}
+
+// decodeWithAbi matches the selector with the functions and with the first
+// bytes of the event topics, as the applications emit the events as outputs
+func decodeWithAbi(parsed *abi.ABI, data []byte) *DecodedPayload {
+	selector, args := data[:4], data[4:]
+	if method, err := parsed.MethodById(selector); err == nil {
+		decoded, err := decodeArgs(DECODED_FUNCTION, method.RawName, method.Sig, method.Inputs, args)
+		if err == nil {
+			return decoded
+		}
+	}
+	for _, event := range parsed.Events {
+		if event.Anonymous || !bytes.Equal(event.ID[:4], selector) {
+			continue
+		}
+		inputs := make(abi.Arguments, len(event.Inputs))
+		for i, input := range event.Inputs {
+			input.Indexed = false
+			inputs[i] = input
+		}
+		decoded, err := decodeArgs(DECODED_EVENT, event.RawName, event.Sig, inputs, args)
+		if err == nil {
+			return decoded
+		}
+	}
+	return nil
+}
+
+func decodeArgs(kind string, name string, signature string, inputs abi.Arguments, data []byte) (*DecodedPayload, error) {
+	values, err := inputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	var args bytes.Buffer
+	args.WriteByte('{')
+	for i, input := range inputs {
+		argName := input.Name
+		if argName == "" {
+			argName = fmt.Sprintf("arg%d", i)
+		}
+		key, err := json.Marshal(argName)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(jsonValue(values[i]))
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			args.WriteByte(',')
+		}
+		args.Write(key)
+		args.WriteByte(':')
+		args.Write(value)
+	}
+	args.WriteByte('}')
+	return &DecodedPayload{
+		Kind:      kind,
+		Name:      name,
+		Signature: signature,
+		Args:      args.String(),
+	}, nil
+}
+
+// jsonValue keeps the numbers as decimal strings and the bytes as hex,
+// like the rest of the API
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return hexutil.Encode(data)
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return items
+	case reflect.Struct:
+		fields := map[string]any{}
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name := field.Tag.Get("json")
+			if name == "" {
+				name = field.Name
+			}
+			fields[name] = jsonValue(rv.Field(i).Interface())
+		}
+		return fields
+	}
+	return value
+}
@@ -0,0 +1,147 @@
+package decoder
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+)
+
+const tokenAbi = `[
+	{"type":"function","name":"transfer","inputs":[
+		{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],
+		"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"event","name":"Minted","anonymous":false,"inputs":[
+		{"name":"owner","type":"address","indexed":true},{"name":"ids","type":"uint256[]","indexed":false},
+		{"name":"","type":"bytes32","indexed":false}]}
+]`
+
+var (
+	Owner = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	App   = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+)
+
+type AbiRegistrySuite struct {
+	suite.Suite
+	db        *sqlx.DB
+	registry  *AbiRegistry
+	tokenAbi  abi.ABI
+	dbFactory *commons.DbFactory
+}
+
+func (s *AbiRegistrySuite) SetupTest() {
+	s.dbFactory = commons.NewDbFactory()
+	s.db = s.dbFactory.CreateDb("abi_registry.sqlite3")
+	abiRepository := &repository.AbiRepository{Db: *s.db}
+	s.Require().NoError(abiRepository.CreateTables())
+	s.registry = NewAbiRegistry(abiRepository)
+	parsed, err := abi.JSON(strings.NewReader(tokenAbi))
+	s.Require().NoError(err)
+	s.tokenAbi = parsed
+}
+
+func (s *AbiRegistrySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestAbiRegistrySuite(t *testing.T) {
+	suite.Run(t, new(AbiRegistrySuite))
+}
+
+func (s *AbiRegistrySuite) TestDecodeFunction() {
+	ctx := context.Background()
+	s.Require().NoError(s.registry.Register(ctx, Token, tokenAbi))
+	data, err := s.tokenAbi.Pack("transfer", Owner, big.NewInt(1000))
+	s.Require().NoError(err)
+
+	// the app has no ABI, so the destination one is used
+	decoded, err := s.registry.Decode(ctx, data, App, Token)
+	s.Require().NoError(err)
+	s.Require().NotNil(decoded)
+	s.Equal(DECODED_FUNCTION, decoded.Kind)
+	s.Equal("transfer", decoded.Name)
+	s.Equal("transfer(address,uint256)", decoded.Signature)
+	s.JSONEq(`{"to":"`+Owner.Hex()+`","amount":"1000"}`, decoded.Args)
+
+	decoded, err = s.registry.Decode(ctx, data, App)
+	s.Require().NoError(err)
+	s.Nil(decoded)
+}
+
+func (s *AbiRegistrySuite) TestDecodeEvent() {
+	ctx := context.Background()
+	s.Require().NoError(s.registry.Register(ctx, App, `{"contractName":"App","abi":`+tokenAbi+`}`))
+	event := s.tokenAbi.Events["Minted"]
+	args, err := abi.Arguments{
+		{Type: event.Inputs[0].Type}, {Type: event.Inputs[1].Type}, {Type: event.Inputs[2].Type},
+	}.Pack(Owner, []*big.Int{big.NewInt(1), big.NewInt(2)}, common.HexToHash("0x01"))
+	s.Require().NoError(err)
+	data := append(event.ID.Bytes()[:4], args...)
+
+	decoded, err := s.registry.Decode(ctx, data, App)
+	s.Require().NoError(err)
+	s.Require().NotNil(decoded)
+	s.Equal(DECODED_EVENT, decoded.Kind)
+	s.Equal("Minted", decoded.Name)
+	s.Equal(`{"owner":"`+Owner.Hex()+`","ids":["1","2"],"arg2":"`+common.HexToHash("0x01").Hex()+`"}`, decoded.Args)
+}
+
+func (s *AbiRegistrySuite) TestInvalidAbi() {
+	ctx := context.Background()
+	s.ErrorIs(s.registry.Register(ctx, App, `{"contractName":"App"}`), ErrInvalidAbi)
+	s.ErrorIs(s.registry.Register(ctx, App, `not json`), ErrInvalidAbi)
+	parsed, err := s.registry.Get(ctx, App)
+	s.Require().NoError(err)
+	s.Nil(parsed)
+}
+
+func (s *AbiRegistrySuite) TestCacheMisses() {
+	ctx := context.Background()
+	other := NewAbiRegistry(&repository.AbiRepository{Db: *s.db})
+	parsed, err := other.Get(ctx, Token)
+	s.Require().NoError(err)
+	s.Nil(parsed)
+
+	// registered by another instance, the miss is kept until it expires
+	s.Require().NoError(s.registry.Register(ctx, Token, tokenAbi))
+	parsed, err = other.Get(ctx, Token)
+	s.Require().NoError(err)
+	s.Nil(parsed)
+	other.missTTL = 0
+	parsed, err = other.Get(ctx, Token)
+	s.Require().NoError(err)
+	s.Require().NotNil(parsed)
+
+	// registered by the same instance, the miss is forgotten
+	parsed, err = s.registry.Get(ctx, App)
+	s.Require().NoError(err)
+	s.Nil(parsed)
+	s.Require().NoError(s.registry.Register(ctx, App, tokenAbi))
+	parsed, err = s.registry.Get(ctx, App)
+	s.Require().NoError(err)
+	s.NotNil(parsed)
+}
+
+func (s *AbiRegistrySuite) TestLoadDir() {
+	ctx := context.Background()
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, Token.Hex()+".json"), []byte(tokenAbi), 0600))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "README.json"), []byte(`{}`), 0600))
+	s.Require().NoError(s.registry.LoadDir(ctx, dir))
+
+	// another instance reads it from the database
+	other := NewAbiRegistry(&repository.AbiRepository{Db: *s.db})
+	parsed, err := other.Get(ctx, Token)
+	s.Require().NoError(err)
+	s.Require().NotNil(parsed)
+	s.Contains(parsed.Methods, "transfer")
+}
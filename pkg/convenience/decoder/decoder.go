@@ -4,10 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"math/big"
-	"net/http"
 	"strconv"
 	"time"
 
@@ -162,40 +160,6 @@ func (o *OutputDecoder) HandleReport(
 	return err
 }
 
-func (o *OutputDecoder) GetAbi(address common.Address) (*abi.ABI, error) {
-	baseURL := "https://api.etherscan.io/api"
-	contextPath := "?module=contract&action=getsourcecode&address="
-	url := fmt.Sprintf("%s/%s%s", baseURL, contextPath, address.String())
-
-	var apiResponse struct {
-		Status  string `json:"status"`
-		Message string `json:"message"`
-		Result  []struct {
-			ABI string `json:"ABI"`
-		} `json:"result"`
-	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected error")
-	}
-	defer resp.Body.Close()
-	apiResult, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected error io")
-	}
-	if err := json.Unmarshal(apiResult, &apiResponse); err != nil {
-		return nil, fmt.Errorf("unexpected error")
-	}
-	abiJSON := apiResponse.Result[0].ABI
-	var abiData abi.ABI
-	err2 := json.Unmarshal([]byte(abiJSON), &abiData)
-	if err2 != nil {
-		return nil, fmt.Errorf("unexpected error json %s", err2.Error())
-	}
-	return &abiData, nil
-}
-
 func jsonToAbi(abiJSON string) (*abi.ABI, error) {
 	var abiData abi.ABI
 	err2 := json.Unmarshal([]byte(abiJSON), &abiData)
@@ -8,13 +8,13 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/devnet"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -27,10 +27,12 @@ type OutputDecoderSuite struct {
 	noticeRepository  *repository.NoticeRepository
 	inputRepository   *repository.InputRepository
 	reportRepository  *repository.ReportRepository
+	dbFactory         *commons.DbFactory
 }
 
 func (s *OutputDecoderSuite) SetupTest() {
-	db := sqlx.MustConnect("sqlite3", ":memory:")
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("decoder.sqlite3")
 	outputRepository := repository.OutputRepository{
 		Db: *db,
 	}
@@ -69,6 +71,10 @@ func (s *OutputDecoderSuite) SetupTest() {
 	}
 }
 
+func (s *OutputDecoderSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
 func TestDecoderSuite(t *testing.T) {
 	suite.Run(t, new(OutputDecoderSuite))
 }
@@ -87,18 +93,6 @@ func (s *OutputDecoderSuite) TestHandleOutput() {
 	s.Equal("0x11", voucher.Payload)
 }
 
-func (s *OutputDecoderSuite) TestGetAbiFromEtherscan() {
-	s.T().Skip()
-	address := common.HexToAddress("0x26A61aF89053c847B4bd5084E2caFe7211874a29")
-	abi, err := s.decoder.GetAbi(address)
-	s.NoError(err)
-	selectorBytes, err := hex.DecodeString("a9059cbb")
-	s.NoError(err)
-	abiMethod, err := abi.MethodById(selectorBytes)
-	s.NoError(err)
-	s.Equal("transfer", abiMethod.RawName)
-}
-
 func (s *OutputDecoderSuite) XTestCreateVoucherIdempotency() {
 	// we need a better way to check the Idempotency
 	ctx := context.Background()
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PayloadContent returns the hex payload as UTF-8 text and, when the text is
+// a JSON object or array, as compact JSON. Binary payloads have neither.
+// The JSON with a \u0000 escape has no JSON either, as the Postgres jsonb
+// type rejects it.
+func PayloadContent(payload string) (*string, *string) {
+	data := common.FromHex(payload)
+	if len(data) == 0 || !utf8.Valid(data) {
+		return nil, nil
+	}
+	for _, r := range string(data) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return nil, nil
+		}
+	}
+	text := string(data)
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return &text, nil
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(trimmed)); err != nil {
+		return &text, nil
+	}
+	jsonText := compact.String()
+	if hasNullEscape(jsonText) {
+		return &text, nil
+	}
+	return &text, &jsonText
+}
+
+// hasNullEscape tells whether the JSON text has a \u0000 escape
+func hasNullEscape(jsonText string) bool {
+	for i := 0; i < len(jsonText); i++ {
+		if jsonText[i] != '\\' {
+			continue
+		}
+		if strings.HasPrefix(jsonText[i+1:], "u0000") {
+			return true
+		}
+		// skips the escaped character, which may be a backslash
+		i++
+	}
+	return false
+}
+
+// NoticePayloadContent is the PayloadContent of the notice data, which
+// may be stored as the encoded Notice output
+func NoticePayloadContent(payload string) (*string, *string) {
+	data := common.FromHex(payload)
+	if strings.HasPrefix(common.Bytes2Hex(data), NOTICE_SELECTOR) {
+		bytesType, err := abi.NewType("bytes", "", nil)
+		if err != nil {
+			return nil, nil
+		}
+		values, err := abi.Arguments{{Type: bytesType}}.Unpack(data[4:])
+		if err != nil {
+			return nil, nil
+		}
+		return PayloadContent(common.Bytes2Hex(values[0].([]byte)))
+	}
+	return PayloadContent(payload)
+}
@@ -11,6 +11,8 @@ import (
 
 const STATUS_PROPERTY = "Status"
 const EXECUTED = "Executed"
+const EXECUTED_AT = "ExecutedAt"
+const EXECUTED_BLOCK = "ExecutedBlock"
 const FALSE = "false"
 const DESTINATION = "Destination"
 const VOUCHER_SELECTOR = "237a816f" // deprecated ef615e2f
@@ -18,6 +20,7 @@ const DELEGATED_CALL_VOUCHER_SELECTOR = "10321e8b"
 const NOTICE_SELECTOR = "c258d6e5"
 const INPUT_INDEX = "InputIndex"
 const APP_CONTRACT = "AppContract"
+const PAYLOAD_JSON = "PayloadJson"
 
 // Completion status for inputs.
 type CompletionStatus int
@@ -40,6 +43,18 @@ type ConvenienceNotice struct {
 	OutputIndex          uint64 `db:"output_index"`
 	OutputHashesSiblings string `db:"output_hashes_siblings"`
 	ProofOutputIndex     uint64 `db:"proof_output_index"`
+	// UTF-8 text of the notice data, nil when it is binary
+	PayloadText *string `db:"payload_text"`
+	// Notice data as JSON, nil when the text is not a JSON object or array
+	PayloadJson *string `db:"payload_json"`
+}
+
+// Proof data of a voucher or notice, keyed by the output it belongs to
+type ConvenienceProof struct {
+	AppContract          string `db:"app_contract"`
+	OutputIndex          uint64 `db:"output_index"`
+	OutputHashesSiblings string `db:"output_hashes_siblings"`
+	ProofOutputIndex     uint64 `db:"proof_output_index"`
 }
 
 // Voucher metadata type
@@ -54,31 +69,172 @@ type ConvenienceVoucher struct {
 	OutputHashesSiblings string         `db:"output_hashes_siblings"`
 	TransactionHash      string         `db:"transaction_hash"`
 	ProofOutputIndex     uint64         `db:"proof_output_index"`
+	// Block number and timestamp (unix seconds) of the execution, zero while unknown
+	ExecutedBlock uint64 `db:"executed_block"`
+	ExecutedAt    uint64 `db:"executed_at"`
+	// Sender of the execution transaction, empty while unknown
+	ExecutedBy string `db:"executed_by"`
+	// InputIndexUnknown is set on the vouchers only known by their execution
+	// on the L1, the InputIndex is zero as the event does not carry it
+	InputIndexUnknown bool `db:"input_index_unknown"`
+	// Failed attempts to resolve the execution details, the unix time of
+	// the last one and the time after which the next one is made
+	ResolveAttempts    uint64 `db:"resolve_attempts"`
+	ResolveAttemptedAt uint64 `db:"resolve_attempted_at"`
+	ResolveAfter       uint64 `db:"resolve_after"`
 	// future improvements
 	// Contract        common.Address
 	// Beneficiary     common.Address
 	// Label           string
 	// Amount          uint64
-	// ExecutedAt      uint64
-	// ExecutedBlock   uint64
 	// InputIndex      int
 	// OutputIndex     int
 	// MethodSignature string
 	// ERCX            string
 }
 
+// Claim of the outputs of an epoch submitted to the consensus.
+// Once accepted, the proofs of the epoch outputs can be executed on the L1.
+type ConvenienceClaim struct {
+	AppContract     string `db:"app_contract"`
+	EpochIndex      uint64 `db:"epoch_index"`
+	FirstBlock      uint64 `db:"first_block"`
+	LastBlock       uint64 `db:"last_block"`
+	ClaimHash       string `db:"claim_hash"`
+	Submitter       string `db:"submitter"`
+	Accepted        bool   `db:"accepted"`
+	TransactionHash string `db:"transaction_hash"`
+	BlockNumber     uint64 `db:"block_number"`
+}
+
+// Execution of a voucher read from the L1 before the voucher was synced,
+// applied once the voucher is there
+type PendingExecution struct {
+	AppContract     string `db:"app_contract"`
+	OutputIndex     uint64 `db:"output_index"`
+	TransactionHash string `db:"transaction_hash"`
+	BlockNumber     uint64 `db:"block_number"`
+}
+
 type ConvenienceFilter struct {
-	Field *string              `json:"field,omitempty"`
-	Eq    *string              `json:"eq,omitempty"`
-	Ne    *string              `json:"ne,omitempty"`
-	Gt    *string              `json:"gt,omitempty"`
-	Gte   *string              `json:"gte,omitempty"`
-	Lt    *string              `json:"lt,omitempty"`
-	Lte   *string              `json:"lte,omitempty"`
-	In    []*string            `json:"in,omitempty"`
-	Nin   []*string            `json:"nin,omitempty"`
-	And   []*ConvenienceFilter `json:"and,omitempty"`
-	Or    []*ConvenienceFilter `json:"or,omitempty"`
+	Field *string `json:"field,omitempty"`
+	// JSON path of the value compared when the field is PAYLOAD_JSON
+	Path *string              `json:"path,omitempty"`
+	Eq   *string              `json:"eq,omitempty"`
+	Ne   *string              `json:"ne,omitempty"`
+	Gt   *string              `json:"gt,omitempty"`
+	Gte  *string              `json:"gte,omitempty"`
+	Lt   *string              `json:"lt,omitempty"`
+	Lte  *string              `json:"lte,omitempty"`
+	In   []*string            `json:"in,omitempty"`
+	Nin  []*string            `json:"nin,omitempty"`
+	And  []*ConvenienceFilter `json:"and,omitempty"`
+	Or   []*ConvenienceFilter `json:"or,omitempty"`
+}
+
+// InputStats aggregates the inputs of an application
+type InputStats struct {
+	ByStatus      map[CompletionStatus]uint64
+	UniqueSenders uint64
+	// Block timestamps of the first and last inputs, nil without inputs
+	FirstTimestamp *time.Time
+	LastTimestamp  *time.Time
+	// Number of inputs per UTC day, in ascending order
+	PerDay []DailyCount
+}
+
+type DailyCount struct {
+	Day   string `db:"day"`
+	Count uint64 `db:"count"`
+}
+
+// OutputStats counts the vouchers and notices of an application
+type OutputStats struct {
+	Vouchers         uint64 `db:"vouchers"`
+	ExecutedVouchers uint64 `db:"executed_vouchers"`
+	Notices          uint64 `db:"notices"`
+}
+
+type AppStats struct {
+	Inputs InputStats
+	OutputStats
+	Reports uint64
+}
+
+const SEARCH_REPORT = "report"
+const SEARCH_NOTICE = "notice"
+
+// SearchDocument is the text of a report or notice in the full-text index
+type SearchDocument struct {
+	Kind        string `db:"kind"`
+	AppContract string `db:"app_contract"`
+	InputIndex  uint64 `db:"input_index"`
+	OutputIndex uint64 `db:"output_index"`
+	Content     string `db:"content"`
+}
+
+const WEBHOOK_VOUCHER_PROOF = "voucher.proof"
+const WEBHOOK_VOUCHER_EXECUTED = "voucher.executed"
+const WEBHOOK_INPUT_EXCEPTION = "input.exception"
+
+const DELIVERY_PENDING = "PENDING"
+const DELIVERY_DELIVERED = "DELIVERED"
+const DELIVERY_FAILED = "FAILED"
+
+// WebhookEvent is an indexed event kept in the outbox until it is
+// fanned out to the endpoints. Data is the JSON body of the event.
+type WebhookEvent struct {
+	Id          int64  `db:"id"`
+	EventType   string `db:"event_type"`
+	AppContract string `db:"app_contract"`
+	Data        string `db:"data"`
+	CreatedAt   int64  `db:"created_at"`
+	Dispatched  bool   `db:"dispatched"`
+}
+
+// WebhookDelivery is the attempt to post an event to one endpoint.
+// Timestamps are unix seconds.
+type WebhookDelivery struct {
+	Id             int64  `db:"id"`
+	EventId        int64  `db:"event_id"`
+	EventType      string `db:"event_type"`
+	AppContract    string `db:"app_contract"`
+	Endpoint       string `db:"endpoint"`
+	Status         string `db:"status"`
+	Attempts       int    `db:"attempts"`
+	NextAttemptAt  int64  `db:"next_attempt_at"`
+	ResponseStatus int    `db:"response_status"`
+	LastError      string `db:"last_error"`
+	UpdatedAt      int64  `db:"updated_at"`
+}
+
+// VoucherProofEvent is the data of the voucher.proof webhook
+type VoucherProofEvent struct {
+	InputIndex           uint64   `json:"inputIndex"`
+	OutputIndex          uint64   `json:"outputIndex"`
+	OutputHashesSiblings []string `json:"outputHashesSiblings"`
+}
+
+// VoucherExecutedEvent is the data of the voucher.executed webhook.
+// The InputIndex is nil when the voucher is only known by its execution.
+type VoucherExecutedEvent struct {
+	InputIndex      *uint64 `json:"inputIndex"`
+	OutputIndex     uint64  `json:"outputIndex"`
+	TransactionHash string  `json:"transactionHash"`
+}
+
+// KnownInputIndex returns the input index of the voucher, nil when it is unknown
+func KnownInputIndex(voucher *ConvenienceVoucher) *uint64 {
+	if voucher.InputIndexUnknown {
+		return nil
+	}
+	inputIndex := voucher.InputIndex
+	return &inputIndex
+}
+
+// InputExceptionEvent is the data of the input.exception webhook
+type InputExceptionEvent struct {
+	InputIndex uint64 `json:"inputIndex"`
 }
 
 type SynchronizerFetch struct {
@@ -103,6 +259,10 @@ type Report struct {
 	Payload     string
 	AppContract common.Address `json:"app_contract"`
 	RawID       uint64
+	// UTF-8 text of the payload, nil when it is binary
+	PayloadText *string
+	// Payload as JSON, nil when the text is not a JSON object or array
+	PayloadJson *string
 }
 
 // Rollups advance input type.
@@ -128,6 +288,11 @@ type AdvanceInput struct {
 	AvailBlockTimestamp    time.Time `db:"avail_block_timestamp"`
 	Type                   string    `db:"type"`
 	CartesiTransactionId   string    `db:"cartesi_transaction_id"`
+	// L1 transaction that added the input, empty while unknown
+	TransactionHash string `db:"transaction_hash"`
+	// Index of the InputAdded log in its block, -1 while unknown
+	LogIndex  int    `db:"log_index"`
+	BlockHash string `db:"block_hash"`
 }
 
 type ConvertedInput struct {
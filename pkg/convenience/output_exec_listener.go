@@ -2,76 +2,220 @@ package convenience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
 	"time"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// ErrVoucherNotSynced means the voucher was executed before it was synced,
+// so its execution is kept as pending until the voucher is there
+var ErrVoucherNotSynced = errors.New("executed voucher not synced yet")
+
+// ErrNoApplications means the listener has no application to read the
+// events from. Without the addresses the filter would match every contract.
+var ErrNoApplications = errors.New("execlistener: no application address")
+
+// ExecListenerClient is the part of the ethclient used by the listener
+type ExecListenerClient interface {
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// OutputExecListener marks the vouchers as executed from the
+// OutputExecuted events of the applications. It can replace or
+// cross-check the executions synced from the node database.
 type OutputExecListener struct {
-	Provider           string
-	ApplicationAddress common.Address
-	EventName          string
-	ConvenienceService *services.ConvenienceService
-	FromBlock          *big.Int
+	Provider             string
+	Client               ExecListenerClient
+	ApplicationAddresses []common.Address
+	EventName            string
+	ConvenienceService   *services.ConvenienceService
+	CheckpointRepository *repository.L1CheckpointRepository
+	FromBlock            *big.Int
+	// ExecutionResolver is optional, without it the vouchers have no execution details
+	ExecutionResolver services.ExecutionResolver
+	// WebhookRepository is optional, without it no voucher.executed event is queued
+	WebhookRepository *repository.WebhookRepository
+	// PendingExecutionRepository is optional, without it the executions
+	// of the vouchers not synced yet are skipped
+	PendingExecutionRepository *repository.PendingExecutionRepository
+	application                *contracts.ApplicationFilterer
 }
 
 func NewExecListener(
 	provider string,
-	applicationAddress common.Address,
+	applicationAddresses []common.Address,
 	convenienceService *services.ConvenienceService,
+	checkpointRepository *repository.L1CheckpointRepository,
 	fromBlock *big.Int,
 ) OutputExecListener {
+	// the filterer is only used to parse the logs
+	application, err := contracts.NewApplicationFilterer(common.Address{}, nil)
+	if err != nil {
+		panic(err)
+	}
 	return OutputExecListener{
-		FromBlock:          fromBlock,
-		ConvenienceService: convenienceService,
-		Provider:           provider,
-		ApplicationAddress: applicationAddress,
-		EventName:          "OutputExecuted",
+		FromBlock:            fromBlock,
+		ConvenienceService:   convenienceService,
+		CheckpointRepository: checkpointRepository,
+		Provider:             provider,
+		ApplicationAddresses: applicationAddresses,
+		EventName:            "OutputExecuted",
+		application:          application,
 	}
 }
 
 // on event callback
 func (x OutputExecListener) OnEvent(
-	eventValues []interface{},
-	timestamp,
-	blockNumber uint64,
+	ctx context.Context,
+	appContract common.Address,
+	outputIndex uint64,
+	transactionHash common.Hash,
 ) error {
-	if len(eventValues) != 1 {
-		return fmt.Errorf("wrong event values length != 1")
-	}
-	voucherId, ok := eventValues[0].(*big.Int)
-	if !ok {
-		return fmt.Errorf("cannot cast voucher id to big.Int")
-	}
-
-	// Extract voucher and input using bit masking and shifting
-	var bitsToShift uint = 128
-	var maxHexBytes uint64 = 0xFFFFFFFFFFFFFFFF
-	bitMask := new(big.Int).SetUint64(maxHexBytes)
-	voucher := new(big.Int).Rsh(voucherId, bitsToShift)
-	input := new(big.Int).And(voucherId, bitMask)
-
-	// Print the extracted voucher and input
-	slog.Debug("Decoded voucher params",
-		"voucher", voucher,
-		"input", input,
-		"blockNumber", blockNumber,
+	slog.Debug("Voucher Executed",
+		"appContract", appContract.Hex(),
+		"outputIndex", outputIndex,
+		"transactionHash", transactionHash.Hex(),
 	)
+	voucher, err := x.ConvenienceService.FindVoucherByOutputIndexAndAppContract(
+		ctx, outputIndex, &appContract)
+	if err != nil {
+		return err
+	}
+	if voucher == nil {
+		return fmt.Errorf("%w: app_contract %s; output_index %d",
+			ErrVoucherNotSynced, appContract.Hex(), outputIndex)
+	}
+	if voucher.Executed {
+		// already set by the node sync, just cross-check it
+		if voucher.TransactionHash != transactionHash.Hex() {
+			slog.Warn("Voucher execution mismatch",
+				"appContract", appContract.Hex(),
+				"outputIndex", outputIndex,
+				"synced", voucher.TransactionHash,
+				"event", transactionHash.Hex(),
+			)
+		}
+		if voucher.ExecutedBy != "" {
+			return nil
+		}
+		return services.ResolveExecution(ctx, x.ExecutionResolver,
+			x.ConvenienceService.VoucherRepository, voucher, common.Hash{})
+	}
+	voucher.TransactionHash = transactionHash.Hex()
+	err = x.setExecuted(ctx, voucher)
+	if err != nil {
+		return err
+	}
+	return services.ResolveExecution(ctx, x.ExecutionResolver,
+		x.ConvenienceService.VoucherRepository, voucher, common.Hash{})
+}
 
-	// Print decoded event data
-	slog.Debug("Voucher Executed", "voucherId", voucherId.String())
+// OnRemoved reverts the execution of a log removed by a reorg
+func (x OutputExecListener) OnRemoved(
+	ctx context.Context,
+	appContract common.Address,
+	outputIndex uint64,
+	transactionHash common.Hash,
+) error {
+	slog.Warn("Voucher execution removed by a reorg",
+		"appContract", appContract.Hex(),
+		"outputIndex", outputIndex,
+		"transactionHash", transactionHash.Hex(),
+	)
+	if x.PendingExecutionRepository != nil {
+		err := x.PendingExecutionRepository.Delete(ctx, appContract.Hex(), outputIndex, transactionHash.Hex())
+		if err != nil {
+			return err
+		}
+	}
+	return x.ConvenienceService.UnsetVoucherExecuted(ctx, appContract, outputIndex, transactionHash)
+}
 
-	ctx := context.Background()
-	return x.ConvenienceService.UpdateExecuted(ctx, input.Uint64(), voucher.Uint64(), true)
+// savePending keeps the execution of a voucher not synced yet,
+// so the events after it are not held back
+func (x OutputExecListener) savePending(ctx context.Context, vLog types.Log, outputIndex uint64) error {
+	if x.PendingExecutionRepository == nil {
+		slog.Warn("Skipping the execution of a voucher not synced",
+			"appContract", vLog.Address.Hex(),
+			"outputIndex", outputIndex,
+			"transactionHash", vLog.TxHash.Hex(),
+		)
+		return nil
+	}
+	slog.Debug("Voucher execution pending",
+		"appContract", vLog.Address.Hex(),
+		"outputIndex", outputIndex,
+	)
+	return x.PendingExecutionRepository.Save(ctx, model.PendingExecution{
+		AppContract:     vLog.Address.Hex(),
+		OutputIndex:     outputIndex,
+		TransactionHash: vLog.TxHash.Hex(),
+		BlockNumber:     vLog.BlockNumber,
+	})
+}
+
+// ApplyPendingExecutions marks as executed the pending vouchers synced since
+func (x OutputExecListener) ApplyPendingExecutions(ctx context.Context) error {
+	if x.PendingExecutionRepository == nil {
+		return nil
+	}
+	executions, err := x.PendingExecutionRepository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, execution := range executions {
+		err := x.OnEvent(ctx,
+			common.HexToAddress(execution.AppContract),
+			execution.OutputIndex,
+			common.HexToHash(execution.TransactionHash),
+		)
+		if errors.Is(err, ErrVoucherNotSynced) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = x.PendingExecutionRepository.Delete(ctx,
+			execution.AppContract, execution.OutputIndex, execution.TransactionHash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setExecuted marks the voucher as executed and queues its webhook event
+// in the same transaction, so the event is not lost when queueing fails
+func (x OutputExecListener) setExecuted(ctx context.Context, voucher *model.ConvenienceVoucher) error {
+	voucherRepository := x.ConvenienceService.VoucherRepository
+	if x.WebhookRepository == nil {
+		_, err := services.SetVoucherExecuted(ctx, voucherRepository, nil, voucher)
+		return err
+	}
+	txCtx, tx, err := repository.StartTransactionContext(ctx, x.WebhookRepository.Db)
+	if err != nil {
+		return err
+	}
+	_, err = services.SetVoucherExecuted(txCtx, voucherRepository, x.WebhookRepository, voucher)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			slog.Error("transaction rollback error", "err", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
 }
 
 // String implements supervisor.Worker.
@@ -81,96 +225,171 @@ func (x OutputExecListener) String() string {
 
 func (x OutputExecListener) Start(ctx context.Context, ready chan<- struct{}) error {
 	var delay time.Duration = 5 * time.Second
-	slog.Info("Connecting to", "provider", x.Provider)
 
-	var client *ethclient.Client
-	var err error
+	if len(x.ApplicationAddresses) == 0 {
+		return ErrNoApplications
+	}
 
-	for {
-		ctxDial, cancel := context.WithCancel(ctx)
-		defer cancel()
+	if x.Client == nil {
+		slog.Info("Connecting to", "provider", x.Provider)
+		for {
+			client, err := ethclient.DialContext(ctx, x.Provider)
+			if err == nil {
+				defer client.Close()
+				x.Client = client
+				break
+			}
+			slog.Error("execlistener: dial: ", "error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+	}
+	ready <- struct{}{}
+	return x.WatchExecutions(ctx)
+}
 
-		client, err = ethclient.DialContext(ctxDial, x.Provider)
-		if err == nil {
-			break
+func (x *OutputExecListener) checkpointName(appContract common.Address) string {
+	return fmt.Sprintf("output_exec_listener:%s", appContract.Hex())
+}
+
+// nextBlock returns the first block not processed by every application,
+// nil without applications
+func (x *OutputExecListener) nextBlock(ctx context.Context) (*big.Int, error) {
+	var next *big.Int
+	for _, app := range x.ApplicationAddresses {
+		lastBlock, err := x.CheckpointRepository.GetLastBlock(ctx, x.checkpointName(app))
+		if err != nil {
+			return nil, err
+		}
+		appNext := x.FromBlock
+		if lastBlock != nil {
+			appNext = new(big.Int).SetUint64(*lastBlock + 1)
 		}
+		if appNext == nil {
+			appNext = big.NewInt(0)
+		}
+		if next == nil || appNext.Cmp(next) < 0 {
+			next = appNext
+		}
+	}
+	return next, nil
+}
 
-		slog.Error("execlistener: dial: ", "error", err)
-		time.Sleep(delay)
+func (x *OutputExecListener) saveCheckpoint(ctx context.Context, appContract common.Address, blockNumber uint64) error {
+	lastBlock, err := x.CheckpointRepository.GetLastBlock(ctx, x.checkpointName(appContract))
+	if err != nil {
+		return err
 	}
-	ready <- struct{}{}
-	return x.WatchExecutions(ctx, client)
+	if lastBlock != nil && *lastBlock >= blockNumber {
+		return nil
+	}
+	return x.CheckpointRepository.SaveLastBlock(ctx, x.checkpointName(appContract), blockNumber)
 }
 
-func (x *OutputExecListener) ReadPastExecutions(ctx context.Context, client *ethclient.Client, contractABI abi.ABI, query ethereum.FilterQuery) error {
-	slog.Debug("ReadPastExecutions", "FromBlock", x.FromBlock)
+// ReadPastExecutions reads the events from the checkpoints up to the latest block
+func (x *OutputExecListener) ReadPastExecutions(ctx context.Context) error {
+	fromBlock, err := x.nextBlock(ctx)
+	if err != nil || fromBlock == nil {
+		return err
+	}
+	latest, err := x.Client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if fromBlock.Uint64() > latest {
+		return nil
+	}
+	slog.Debug("ReadPastExecutions", "FromBlock", fromBlock, "ToBlock", latest)
+	query := x.query()
+	query.FromBlock = fromBlock
+	query.ToBlock = new(big.Int).SetUint64(latest)
 
 	// Retrieve logs for the specified block range
-	oldLogs, err := client.FilterLogs(ctx, query)
+	oldLogs, err := x.Client.FilterLogs(ctx, query)
 	if err != nil {
 		return err
 	}
 	// Process old logs
 	for _, vLog := range oldLogs {
-		err := x.HandleLog(vLog, client, contractABI)
+		err := x.HandleLog(ctx, vLog)
 		if err != nil {
-			slog.Error(err.Error())
-			continue
+			return err
 		}
 	}
-
-	return nil
+	for _, app := range x.ApplicationAddresses {
+		err := x.saveCheckpoint(ctx, app, latest)
+		if err != nil {
+			return err
+		}
+	}
+	return x.ApplyPendingExecutions(ctx)
 }
 
-func (x *OutputExecListener) WatchExecutions(ctx context.Context, client *ethclient.Client) error {
-	// ABI of your contract
+func (x *OutputExecListener) query() ethereum.FilterQuery {
 	abi, err := contracts.ApplicationMetaData.GetAbi()
 	if err != nil {
-		slog.Error(err.Error())
-		return err
+		panic(err)
 	}
-	contractABI := *abi
-
-	// Subscribe to event
-	query := ethereum.FilterQuery{
-		FromBlock: x.FromBlock,
-		Addresses: []common.Address{x.ApplicationAddress},
-		Topics:    [][]common.Hash{{contractABI.Events[x.EventName].ID}},
+	return ethereum.FilterQuery{
+		Addresses: x.ApplicationAddresses,
+		Topics:    [][]common.Hash{{abi.Events[x.EventName].ID}},
 	}
+}
 
+func (x *OutputExecListener) WatchExecutions(ctx context.Context) error {
 	var reconnectDelay time.Duration = 5 * time.Second
 
 	for {
-		ctxPastInputs, cancel := context.WithCancel(ctx)
-		defer cancel()
-
-		err = x.ReadPastExecutions(ctxPastInputs, client, contractABI, query)
+		err := x.ReadPastExecutions(ctx)
 		if err != nil {
 			slog.Error("unexpected readPastExecutions error", "error", err)
-			time.Sleep(reconnectDelay)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(reconnectDelay):
+			}
 			continue
 		}
 
 		ctxEth, cancel := context.WithCancel(ctx)
-		defer cancel()
 
 		// subscribe to new logs
 		logs := make(chan types.Log)
-		sub, err := client.SubscribeFilterLogs(ctxEth, query, logs)
+		sub, err := x.Client.SubscribeFilterLogs(ctxEth, x.query(), logs)
 		if err != nil {
+			cancel()
 			slog.Error("unexpected subscribe error", "error", err)
-			time.Sleep(reconnectDelay)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(reconnectDelay):
+			}
 			continue
 		}
 
 		slog.Info("Listening for execution events...")
 
 		errChannel := make(chan error, 1)
+		pending := time.NewTicker(reconnectDelay)
 
 		go func() {
 			// Process events
 			for {
 				select {
+				case <-pending.C:
+					if err := x.ApplyPendingExecutions(ctxEth); err != nil {
+						errChannel <- err
+						return
+					}
+					err := services.ResolveExecutions(ctxEth, x.ExecutionResolver,
+						x.ConvenienceService.VoucherRepository)
+					if err != nil {
+						errChannel <- err
+						return
+					}
 				case <-ctxEth.Done():
 					errChannel <- ctxEth.Err()
 					return
@@ -178,72 +397,54 @@ func (x *OutputExecListener) WatchExecutions(ctx context.Context, client *ethcli
 					errChannel <- err
 					return
 				case vLog := <-logs:
-					if err := x.HandleLog(vLog, client, contractABI); err != nil {
-						slog.Error(err.Error())
-						// errChannel <- err
-						continue
+					if err := x.HandleLog(ctxEth, vLog); err != nil {
+						// read it again from the checkpoint
+						errChannel <- err
+						return
 					}
 				}
 			}
 		}()
 
 		err = <-errChannel
+		pending.Stop()
 		sub.Unsubscribe()
+		cancel()
 
-		if ctxEth.Err() != nil {
-			return ctxEth.Err()
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
 
-		if err != nil {
-			slog.Error("OutputExecListener", "error", err)
-			slog.Info("OutputExecListener reconnecting", "reconnectDelay", reconnectDelay)
-			time.Sleep(reconnectDelay)
-		} else {
-			return nil
+		slog.Error("OutputExecListener", "error", err)
+		slog.Info("OutputExecListener reconnecting", "reconnectDelay", reconnectDelay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectDelay):
 		}
 	}
 }
 
-func (x *OutputExecListener) HandleLog(
-	vLog types.Log,
-	client *ethclient.Client,
-	contractABI abi.ABI,
-) error {
-	timestamp, blockNumber, values, err := x.GetEventData(
-		vLog,
-		client,
-		contractABI,
-	)
+// HandleLog processes the event and saves the checkpoint of its application.
+// The checkpoint stays before the event block, so a failure or other events
+// of the same block are read again. The logs removed by a reorg revert the execution.
+func (x *OutputExecListener) HandleLog(ctx context.Context, vLog types.Log) error {
+	event, err := x.application.ParseOutputExecuted(vLog)
 	if err != nil {
 		return err
 	}
-	err = x.OnEvent(values, timestamp, blockNumber)
-	if err != nil {
-		return err
+	if vLog.Removed {
+		return x.OnRemoved(ctx, vLog.Address, event.OutputIndex, vLog.TxHash)
 	}
-	return nil
-}
-
-func (x *OutputExecListener) GetEventData(
-	vLog types.Log,
-	client *ethclient.Client,
-	contractABI abi.ABI,
-) (uint64, uint64, []interface{}, error) {
-	// Get the block number of the event
-	blockNumber := vLog.BlockNumber
-	blockNumberBigInt := big.NewInt(int64(blockNumber))
-	// Fetch the block information
-	block, err := client.BlockByNumber(context.Background(), blockNumberBigInt)
-	if err != nil {
-		return 0, 0, nil, err
+	err = x.OnEvent(ctx, vLog.Address, event.OutputIndex, vLog.TxHash)
+	if errors.Is(err, ErrVoucherNotSynced) {
+		err = x.savePending(ctx, vLog, event.OutputIndex)
 	}
-
-	// Extract the timestamp from the block
-	timestamp := block.Time()
-
-	values, err := contractABI.Unpack(x.EventName, vLog.Data)
 	if err != nil {
-		return 0, 0, nil, err
+		return err
+	}
+	if vLog.BlockNumber == 0 {
+		return nil
 	}
-	return timestamp, blockNumber, values, nil
+	return x.saveCheckpoint(ctx, vLog.Address, vLog.BlockNumber-1)
 }
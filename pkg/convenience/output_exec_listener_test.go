@@ -2,16 +2,20 @@ package convenience
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"math/big"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -21,11 +25,14 @@ func TestExecListenerSuite(t *testing.T) {
 
 type ExecListenerSuite struct {
 	suite.Suite
-	ConvenienceService *services.ConvenienceService
-	repository         *repository.VoucherRepository
-	noticeRepository   *repository.NoticeRepository
-	inputRepository    *repository.InputRepository
-	reportRepository   *repository.ReportRepository
+	ConvenienceService   *services.ConvenienceService
+	repository           *repository.VoucherRepository
+	noticeRepository     *repository.NoticeRepository
+	inputRepository      *repository.InputRepository
+	reportRepository     *repository.ReportRepository
+	checkpointRepository *repository.L1CheckpointRepository
+	listener             OutputExecListener
+	dbFactory            *commons.DbFactory
 }
 
 var Bob = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
@@ -35,7 +42,8 @@ var Token = common.HexToAddress("0xc6e7DF5E7b4f2A278906862b61205850344D4e7d")
 
 func (s *ExecListenerSuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
-	db := sqlx.MustConnect("sqlite3", ":memory:")
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("exec_listener.sqlite3")
 	s.repository = &repository.VoucherRepository{
 		Db: *db,
 	}
@@ -70,48 +78,328 @@ func (s *ExecListenerSuite) SetupTest() {
 		s.inputRepository,
 		s.reportRepository,
 	)
-}
 
-func (s *ExecListenerSuite) TestItUpdateExecutedAtAndBlocknumber() {
-	{
-		createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
-			Destination: Bruno,
-			Payload:     "0x1122",
-			InputIndex:  1,
-			OutputIndex: 0,
-			Executed:    false,
-		})
-		createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
-			Destination: Bob,
-			Payload:     "0x1122",
-			InputIndex:  2,
-			OutputIndex: 0,
-			Executed:    false,
-		})
-		createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
-			Destination: Alice,
-			Payload:     "0x1122",
-			InputIndex:  3,
-			OutputIndex: 0,
-			Executed:    false,
-		})
-	}
-	listener := NewExecListener("not a problem", Token, s.ConvenienceService, nil)
-	eventValues := make([]interface{}, 1)
-	eventValues[0] = big.NewInt(2)
-	timestamp := uint64(9999)
-	blocknumber := uint64(2008)
-	err := listener.OnEvent(eventValues, timestamp, blocknumber)
+	s.checkpointRepository = &repository.L1CheckpointRepository{
+		Db: *db,
+	}
+	err = s.checkpointRepository.CreateTables()
 	if err != nil {
 		panic(err)
 	}
+	s.listener = NewExecListener(
+		"not a problem",
+		[]common.Address{Token, Alice},
+		s.ConvenienceService,
+		s.checkpointRepository,
+		big.NewInt(10), // nolint
+	)
+}
+
+func (s *ExecListenerSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func (s *ExecListenerSuite) TestItSetsTheVoucherExecuted() {
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		InputIndex:  1,
+		OutputIndex: 2,
+		AppContract: Token,
+	})
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bob,
+		Payload:     "0x1122",
+		InputIndex:  1,
+		OutputIndex: 2,
+		AppContract: Alice,
+	})
+	ctx := context.Background()
+	txHash := common.HexToHash("0xaa")
+	err := s.listener.OnEvent(ctx, Token, 2, txHash)
+	s.Require().NoError(err)
+
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Token)
+	s.Require().NoError(err)
+	s.True(voucher.Executed)
+	s.Equal(txHash.Hex(), voucher.TransactionHash)
+
+	other, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Alice)
+	s.Require().NoError(err)
+	s.False(other.Executed)
+
+	// the same event again is a cross-check
+	err = s.listener.OnEvent(ctx, Token, 2, txHash)
+	s.NoError(err)
+}
+
+type fakeExecutionResolver struct {
+	calls int
+	err   error
+}
+
+func (f *fakeExecutionResolver) Resolve(
+	ctx context.Context, voucher *model.ConvenienceVoucher, blockHash common.Hash,
+) error {
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	voucher.ExecutedBlock = 20 // nolint
+	voucher.ExecutedAt = 1700000000
+	voucher.ExecutedBy = Bob.Hex()
+	return nil
+}
+
+func (s *ExecListenerSuite) TestItSetsTheExecutionDetails() {
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		OutputIndex: 2,
+		AppContract: Token,
+	})
+	resolver := &fakeExecutionResolver{}
+	s.listener.ExecutionResolver = resolver
+	ctx := context.Background()
+	err := s.listener.OnEvent(ctx, Token, 2, common.HexToHash("0xaa"))
+	s.Require().NoError(err)
+
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Token)
+	s.Require().NoError(err)
+	s.Equal(uint64(20), voucher.ExecutedBlock)
+	s.Equal(uint64(1700000000), voucher.ExecutedAt)
+	s.Equal(Bob.Hex(), voucher.ExecutedBy)
+
+	// the details are resolved once
+	err = s.listener.OnEvent(ctx, Token, 2, common.HexToHash("0xaa"))
+	s.Require().NoError(err)
+	s.Equal(1, resolver.calls)
+}
+
+func (s *ExecListenerSuite) TestItRetriesTheUnresolvedExecution() {
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		OutputIndex: 2,
+		AppContract: Token,
+	})
+	resolver := &fakeExecutionResolver{err: errors.New("rpc unavailable")}
+	s.listener.ExecutionResolver = resolver
+	ctx := context.Background()
+	err := s.listener.OnEvent(ctx, Token, 2, common.HexToHash("0xaa"))
+	s.Require().NoError(err)
+
+	// executed without the details
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Token)
+	s.Require().NoError(err)
+	s.True(voucher.Executed)
+	s.Empty(voucher.ExecutedBy)
+	s.Equal(uint64(1), voucher.ResolveAttempts)
+	s.NotZero(voucher.ResolveAttemptedAt)
+	s.Greater(voucher.ResolveAfter, voucher.ResolveAttemptedAt)
+
+	// not retried before the backoff is over
+	resolver.err = nil
+	err = services.ResolveExecutions(ctx, resolver, s.repository)
+	s.Require().NoError(err)
+	s.Equal(1, resolver.calls)
+
+	_, err = s.repository.Db.Exec(`UPDATE vouchers SET resolve_after = 0`)
+	s.Require().NoError(err)
+	err = services.ResolveExecutions(ctx, resolver, s.repository)
+	s.Require().NoError(err)
+	voucher, err = s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Token)
+	s.Require().NoError(err)
+	s.Equal(uint64(20), voucher.ExecutedBlock)
+	s.Equal(Bob.Hex(), voucher.ExecutedBy)
+	s.Equal(2, resolver.calls)
+
+	// nothing left to resolve
+	err = services.ResolveExecutions(ctx, resolver, s.repository)
+	s.Require().NoError(err)
+	s.Equal(2, resolver.calls)
+}
+
+func (s *ExecListenerSuite) TestItQueuesTheWebhookInTheSameTransaction() {
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		InputIndex:  1,
+		OutputIndex: 2,
+		AppContract: Token,
+	})
+	ctx := context.Background()
+	// without its tables, queueing the event fails
+	webhookRepository := &repository.WebhookRepository{Db: &s.repository.Db}
+	s.listener.WebhookRepository = webhookRepository
+	err := s.listener.OnEvent(ctx, Token, 2, common.HexToHash("0xaa"))
+	s.Require().Error(err)
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Token)
+	s.Require().NoError(err)
+	s.False(voucher.Executed)
+
+	s.Require().NoError(webhookRepository.CreateTables())
+	s.Require().NoError(s.listener.OnEvent(ctx, Token, 2, common.HexToHash("0xaa")))
+	voucher, err = s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &Token)
+	s.Require().NoError(err)
+	s.True(voucher.Executed)
+	events, err := webhookRepository.FindPendingEvents(ctx, 10) // nolint
+	s.Require().NoError(err)
+	s.Len(events, 1)
+}
+
+func (s *ExecListenerSuite) TestItWaitsForTheVoucherSync() {
+	err := s.listener.OnEvent(context.Background(), Token, 0, common.HexToHash("0xaa"))
+	s.ErrorIs(err, ErrVoucherNotSynced)
+}
+
+func (s *ExecListenerSuite) TestItNeedsAnApplication() {
+	s.listener.ApplicationAddresses = nil
+	err := s.listener.ReadPastExecutions(context.Background())
+	s.NoError(err)
+	err = s.listener.Start(context.Background(), make(chan struct{}, 1))
+	s.ErrorIs(err, ErrNoApplications)
+}
+
+func (s *ExecListenerSuite) TestItSavesTheCheckpointPerApplication() {
+	ctx := context.Background()
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		OutputIndex: 1,
+		AppContract: Token,
+	})
+	client := &fakeExecClient{
+		latest: 30,                                             // nolint
+		logs:   []types.Log{s.outputExecutedLog(Token, 1, 20)}, // nolint
+	}
+	s.listener.Client = client
+
+	err := s.listener.ReadPastExecutions(ctx)
+	s.Require().NoError(err)
+	s.Equal(big.NewInt(10), client.lastQuery.FromBlock) // nolint
+	for _, app := range []common.Address{Token, Alice} {
+		lastBlock, err := s.checkpointRepository.GetLastBlock(ctx, s.listener.checkpointName(app))
+		s.Require().NoError(err)
+		s.Equal(uint64(30), *lastBlock)
+	}
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 1, &Token)
+	s.Require().NoError(err)
+	s.True(voucher.Executed)
+
+	// resumes from the checkpoint
+	client.latest = 40
+	client.logs = nil
+	err = s.listener.ReadPastExecutions(ctx)
+	s.Require().NoError(err)
+	s.Equal(big.NewInt(31), client.lastQuery.FromBlock) // nolint
+}
+
+func (s *ExecListenerSuite) TestItKeepsTheExecutionOfTheVoucherNotSynced() {
 	ctx := context.Background()
-	voucher, err2 := s.repository.FindVoucherByInputAndOutputIndex(ctx, 2, 0)
-	if err2 != nil {
-		panic(err2)
+	pendingRepository := &repository.PendingExecutionRepository{Db: s.repository.Db}
+	s.Require().NoError(pendingRepository.CreateTables())
+	s.listener.PendingExecutionRepository = pendingRepository
+	s.listener.Client = &fakeExecClient{
+		latest: 30,                                             // nolint
+		logs:   []types.Log{s.outputExecutedLog(Token, 1, 20)}, // nolint
 	}
-	s.Equal(Bob.String(), voucher.Destination.String())
-	s.Equal(true, voucher.Executed)
+	err := s.listener.ReadPastExecutions(ctx)
+	s.Require().NoError(err)
+	lastBlock, err := s.checkpointRepository.GetLastBlock(ctx, s.listener.checkpointName(Token))
+	s.Require().NoError(err)
+	s.Equal(uint64(30), *lastBlock)
+	pending, err := pendingRepository.FindAll(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(pending, 1)
+	s.Equal(uint64(20), pending[0].BlockNumber)
+
+	// applied once the voucher is synced
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		OutputIndex: 1,
+		AppContract: Token,
+	})
+	s.Require().NoError(s.listener.ApplyPendingExecutions(ctx))
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 1, &Token)
+	s.Require().NoError(err)
+	s.True(voucher.Executed)
+	s.Equal(common.BigToHash(big.NewInt(20)).Hex(), voucher.TransactionHash) // nolint
+	pending, err = pendingRepository.FindAll(ctx)
+	s.Require().NoError(err)
+	s.Empty(pending)
+}
+
+func (s *ExecListenerSuite) TestItSkipsTheVoucherNotSyncedWithoutPendingExecutions() {
+	ctx := context.Background()
+	s.listener.Client = &fakeExecClient{
+		latest: 30,                                             // nolint
+		logs:   []types.Log{s.outputExecutedLog(Token, 1, 20)}, // nolint
+	}
+	err := s.listener.ReadPastExecutions(ctx)
+	s.Require().NoError(err)
+	lastBlock, err := s.checkpointRepository.GetLastBlock(ctx, s.listener.checkpointName(Token))
+	s.Require().NoError(err)
+	s.Equal(uint64(30), *lastBlock)
+}
+
+func (s *ExecListenerSuite) TestItRevertsTheRemovedExecution() {
+	ctx := context.Background()
+	createVoucherMetadataOrFail(s, &model.ConvenienceVoucher{
+		Destination: Bruno,
+		Payload:     "0x1122",
+		OutputIndex: 1,
+		AppContract: Token,
+	})
+	vLog := s.outputExecutedLog(Token, 1, 20) // nolint
+	s.Require().NoError(s.listener.HandleLog(ctx, vLog))
+	voucher, err := s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 1, &Token)
+	s.Require().NoError(err)
+	s.True(voucher.Executed)
+
+	vLog.Removed = true
+	s.Require().NoError(s.listener.HandleLog(ctx, vLog))
+	voucher, err = s.repository.FindVoucherByOutputIndexAndAppContract(ctx, 1, &Token)
+	s.Require().NoError(err)
+	s.False(voucher.Executed)
+	s.Empty(voucher.TransactionHash)
+}
+
+func (s *ExecListenerSuite) outputExecutedLog(appContract common.Address, outputIndex uint64, blockNumber uint64) types.Log {
+	abi, err := contracts.ApplicationMetaData.GetAbi()
+	s.Require().NoError(err)
+	event := abi.Events["OutputExecuted"]
+	data, err := event.Inputs.Pack(outputIndex, []byte{})
+	s.Require().NoError(err)
+	return types.Log{
+		Address:     appContract,
+		Topics:      []common.Hash{event.ID},
+		Data:        data,
+		BlockNumber: blockNumber,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+	}
+}
+
+type fakeExecClient struct {
+	latest    uint64
+	logs      []types.Log
+	lastQuery ethereum.FilterQuery
+}
+
+func (f *fakeExecClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.latest, nil
+}
+
+func (f *fakeExecClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.lastQuery = q
+	return f.logs, nil
+}
+
+func (f *fakeExecClient) SubscribeFilterLogs(
+	ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("not implemented")
 }
 
 func createVoucherMetadataOrFail(s *ExecListenerSuite, voucher *model.ConvenienceVoucher) {
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// AbiRepository keeps the JSON ABIs used to decode the payloads,
+// one per application or voucher destination
+type AbiRepository struct {
+	Db sqlx.DB
+}
+
+func (r *AbiRepository) CreateTables() error {
+	schema := `CREATE TABLE IF NOT EXISTS convenience_abis (
+		address 	text NOT NULL PRIMARY KEY,
+		abi 		text NOT NULL);`
+
+	_, err := r.Db.Exec(schema)
+	if err != nil {
+		slog.Error("Failed to create tables", "error", err)
+		return err
+	}
+	slog.Debug("ABIs table created successfully")
+	return nil
+}
+
+// Save replaces the ABI of the address
+func (r *AbiRepository) Save(ctx context.Context, address common.Address, abiJSON string) error {
+	exec := DBExecutor{&r.Db}
+	_, err := exec.ExecContext(ctx, `INSERT INTO convenience_abis (address, abi)
+		VALUES ($1, $2)
+		ON CONFLICT (address) DO UPDATE SET abi = excluded.abi`,
+		address.Hex(), abiJSON,
+	)
+	return err
+}
+
+// FindByAddress returns nil when the address has no ABI
+func (r *AbiRepository) FindByAddress(ctx context.Context, address common.Address) (*string, error) {
+	var abiJSON string
+	err := r.Db.GetContext(ctx, &abiJSON,
+		`SELECT abi FROM convenience_abis WHERE address = $1`, address.Hex())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &abiJSON, nil
+}
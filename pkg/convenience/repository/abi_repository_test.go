@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type AbiRepositorySuite struct {
+	suite.Suite
+	repository *AbiRepository
+	dbFactory  *commons.DbFactory
+}
+
+func (s *AbiRepositorySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("abis.sqlite3")
+	s.repository = &AbiRepository{
+		Db: *db,
+	}
+	err := s.repository.CreateTables()
+	s.NoError(err)
+}
+
+func (s *AbiRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestAbiRepositorySuite(t *testing.T) {
+	suite.Run(t, new(AbiRepositorySuite))
+}
+
+func (s *AbiRepositorySuite) TestSaveAndFind() {
+	ctx := context.Background()
+	address := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	abiJSON, err := s.repository.FindByAddress(ctx, address)
+	s.Require().NoError(err)
+	s.Nil(abiJSON)
+
+	s.Require().NoError(s.repository.Save(ctx, address, `[]`))
+	s.Require().NoError(s.repository.Save(ctx, address, `[{"type":"fallback"}]`))
+
+	abiJSON, err = s.repository.FindByAddress(ctx, address)
+	s.Require().NoError(err)
+	s.Equal(`[{"type":"fallback"}]`, *abiJSON)
+}
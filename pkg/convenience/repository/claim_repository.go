@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// ClaimRepository keeps the claims submitted to the consensus,
+// one row per application, epoch and submitter
+type ClaimRepository struct {
+	Db     sqlx.DB
+	ReadDb *sqlx.DB // optional read replica
+}
+
+const claimColumns = `app_contract, epoch_index, first_block, last_block,
+	claim_hash, submitter, accepted, transaction_hash, block_number`
+
+func (r *ClaimRepository) CreateTables() error {
+	schema := `CREATE TABLE IF NOT EXISTS convenience_claims (
+		app_contract 		text NOT NULL,
+		epoch_index 		bigint NOT NULL,
+		first_block 		bigint NOT NULL,
+		last_block 			bigint NOT NULL,
+		claim_hash 			text NOT NULL,
+		submitter 			text NOT NULL,
+		accepted 			BOOLEAN NOT NULL DEFAULT false,
+		transaction_hash 	text NOT NULL DEFAULT '',
+		block_number 		bigint NOT NULL DEFAULT 0,
+		PRIMARY KEY (app_contract, last_block, submitter)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_claims_app_contract_blocks ON convenience_claims(app_contract, first_block, last_block);`
+
+	_, err := r.Db.Exec(schema)
+	if err != nil {
+		slog.Error("Failed to create tables", "error", err)
+		return err
+	}
+	slog.Debug("Claims table created successfully")
+	return nil
+}
+
+// Create stores a submitted claim, ignoring it when the same
+// submitter already claimed the epoch
+func (r *ClaimRepository) Create(ctx context.Context, claim model.ConvenienceClaim) error {
+	exec := DBExecutor{&r.Db}
+	_, err := exec.ExecContext(ctx, `INSERT INTO convenience_claims (`+claimColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (app_contract, last_block, submitter) DO NOTHING`,
+		claim.AppContract,
+		claim.EpochIndex,
+		claim.FirstBlock,
+		claim.LastBlock,
+		claim.ClaimHash,
+		claim.Submitter,
+		claim.Accepted,
+		claim.TransactionHash,
+		claim.BlockNumber,
+	)
+	return err
+}
+
+// SetAccepted marks the submissions of the accepted claim.
+// When the submission was not indexed, the claim is stored without a submitter.
+func (r *ClaimRepository) SetAccepted(ctx context.Context, claim model.ConvenienceClaim) error {
+	exec := DBExecutor{&r.Db}
+	res, err := exec.ExecContext(ctx, `UPDATE convenience_claims
+		SET accepted = true
+		WHERE app_contract = $1 AND last_block = $2 AND claim_hash = $3`,
+		claim.AppContract,
+		claim.LastBlock,
+		claim.ClaimHash,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	slog.Warn("Accepted claim without submission",
+		"appContract", claim.AppContract,
+		"lastBlock", claim.LastBlock,
+	)
+	claim.Accepted = true
+	return r.Create(ctx, claim)
+}
+
+// FindAcceptedByBlock returns the accepted claim whose epoch contains the block,
+// or nil when there is none yet
+func (r *ClaimRepository) FindAcceptedByBlock(
+	ctx context.Context,
+	appContract common.Address,
+	blockNumber uint64,
+) (*model.ConvenienceClaim, error) {
+	var claim model.ConvenienceClaim
+	err := r.readDb(ctx).GetContext(ctx, &claim, `SELECT `+claimColumns+`
+		FROM convenience_claims
+		WHERE app_contract = $1 AND first_block <= $2 AND last_block >= $2 AND accepted = true
+		ORDER BY last_block ASC
+		LIMIT 1`,
+		appContract.Hex(),
+		blockNumber,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &claim, nil
+}
+
+func (r *ClaimRepository) Count(ctx context.Context, appContract *common.Address) (uint64, error) {
+	where, args := claimWhere(appContract)
+	var count uint64
+	err := r.readDb(ctx).GetContext(ctx, &count,
+		`SELECT count(*) FROM convenience_claims `+where, args...)
+	if err != nil {
+		slog.Error("Count execution error", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *ClaimRepository) FindAll(
+	ctx context.Context,
+	first *int,
+	last *int,
+	after *string,
+	before *string,
+	appContract *common.Address,
+) (*commons.PageResult[model.ConvenienceClaim], error) {
+	total, err := r.Count(ctx, appContract)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := commons.ComputePage(first, last, after, before, int(total))
+	if err != nil {
+		return nil, err
+	}
+	where, args := claimWhere(appContract)
+	query := `SELECT ` + claimColumns + ` FROM convenience_claims ` + where +
+		`ORDER BY last_block ASC, submitter ASC ` +
+		fmt.Sprintf(`LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2) // nolint
+	args = append(args, limit, offset)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+
+	claims := []model.ConvenienceClaim{}
+	err = r.readDb(ctx).SelectContext(ctx, &claims, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &commons.PageResult[model.ConvenienceClaim]{
+		Rows:   claims,
+		Total:  total,
+		Offset: uint64(offset),
+	}, nil
+}
+
+func claimWhere(appContract *common.Address) (string, []any) {
+	if appContract == nil {
+		return "", []any{}
+	}
+	return "WHERE app_contract = $1 ", []any{appContract.Hex()}
+}
+
+func (r *ClaimRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, &r.Db, r.ReadDb)
+}
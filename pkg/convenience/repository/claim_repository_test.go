@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+var claimApp = common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")
+
+type ClaimRepositorySuite struct {
+	suite.Suite
+	repository *ClaimRepository
+	dbFactory  *commons.DbFactory
+}
+
+func (s *ClaimRepositorySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("claim.sqlite3")
+	s.repository = &ClaimRepository{
+		Db: *db,
+	}
+	err := s.repository.CreateTables()
+	s.NoError(err)
+}
+
+func (s *ClaimRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestClaimRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ClaimRepositorySuite))
+}
+
+func (s *ClaimRepositorySuite) newClaim(epochIndex uint64, submitter string) model.ConvenienceClaim {
+	return model.ConvenienceClaim{
+		AppContract: claimApp.Hex(),
+		EpochIndex:  epochIndex,
+		FirstBlock:  epochIndex * 10,   // nolint
+		LastBlock:   epochIndex*10 + 9, // nolint
+		ClaimHash:   common.BigToHash(common.Big1).Hex(),
+		Submitter:   submitter,
+	}
+}
+
+func (s *ClaimRepositorySuite) TestCreateAndAccept() {
+	ctx := context.Background()
+	claim := s.newClaim(1, "0x01")
+	s.Require().NoError(s.repository.Create(ctx, claim))
+	s.Require().NoError(s.repository.Create(ctx, claim))
+	s.Require().NoError(s.repository.Create(ctx, s.newClaim(1, "0x02")))
+
+	accepted, err := s.repository.FindAcceptedByBlock(ctx, claimApp, 15) // nolint
+	s.Require().NoError(err)
+	s.Nil(accepted)
+
+	s.Require().NoError(s.repository.SetAccepted(ctx, claim))
+
+	accepted, err = s.repository.FindAcceptedByBlock(ctx, claimApp, 15) // nolint
+	s.Require().NoError(err)
+	s.Require().NotNil(accepted)
+	s.True(accepted.Accepted)
+	s.Equal(uint64(1), accepted.EpochIndex)
+
+	accepted, err = s.repository.FindAcceptedByBlock(ctx, claimApp, 20) // nolint
+	s.Require().NoError(err)
+	s.Nil(accepted)
+
+	count, err := s.repository.Count(ctx, &claimApp)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+}
+
+func (s *ClaimRepositorySuite) TestAcceptWithoutSubmission() {
+	ctx := context.Background()
+	s.Require().NoError(s.repository.SetAccepted(ctx, s.newClaim(2, ""))) // nolint
+
+	accepted, err := s.repository.FindAcceptedByBlock(ctx, claimApp, 20) // nolint
+	s.Require().NoError(err)
+	s.Require().NotNil(accepted)
+	s.Equal("", accepted.Submitter)
+}
+
+func (s *ClaimRepositorySuite) TestFindAllPaginated() {
+	ctx := context.Background()
+	for i := uint64(0); i < 3; i++ {
+		s.Require().NoError(s.repository.Create(ctx, s.newClaim(i, "0x01")))
+	}
+	first := 2
+	page, err := s.repository.FindAll(ctx, &first, nil, nil, nil, &claimApp)
+	s.Require().NoError(err)
+	s.Equal(uint64(3), page.Total)
+	s.Require().Len(page.Rows, 2)
+	s.Equal(uint64(0), page.Rows[0].EpochIndex)
+	s.Equal(uint64(1), page.Rows[1].EpochIndex)
+
+	other := common.HexToAddress("0x01")
+	page, err = s.repository.FindAll(ctx, nil, nil, nil, nil, &other)
+	s.Require().NoError(err)
+	s.Equal(uint64(0), page.Total)
+}
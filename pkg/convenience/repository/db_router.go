@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const readRoutingKey contextKey = "readRouting"
+
+type readRouting int
+
+const (
+	readFromReplica readRouting = iota + 1
+	readFromPrimary
+)
+
+// WithReadReplica allows the queries executed with this context
+// to be served by the read replica, when one is configured.
+func WithReadReplica(ctx context.Context) context.Context {
+	if ctx.Value(readRoutingKey) == readFromPrimary {
+		return ctx
+	}
+	return context.WithValue(ctx, readRoutingKey, readFromReplica)
+}
+
+// WithReadPrimary forces the queries executed with this context to read
+// from the primary database, giving read-your-writes consistency.
+func WithReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readRoutingKey, readFromPrimary)
+}
+
+// routeRead picks the database used by a read query.
+// Reads only go to the replica when the context explicitly allows it
+// and there is no ongoing transaction, so the indexers always see their own writes.
+func routeRead(ctx context.Context, primary *sqlx.DB, replica *sqlx.DB) *sqlx.DB {
+	if replica == nil {
+		return primary
+	}
+	if _, ok := GetTransaction(ctx); ok {
+		return primary
+	}
+	if ctx.Value(readRoutingKey) == readFromReplica {
+		return replica
+	}
+	return primary
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	convenience "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+)
+
+type DbRouterSuite struct {
+	suite.Suite
+	inputRepository *InputRepository
+	dbFactory       *commons.DbFactory
+}
+
+func (s *DbRouterSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	primary := s.dbFactory.CreateDb("primary.sqlite3")
+	replica := s.dbFactory.CreateDb("replica.sqlite3")
+	replicaRepository := &InputRepository{Db: *replica}
+	err := replicaRepository.CreateTables()
+	s.Require().NoError(err)
+	s.inputRepository = &InputRepository{
+		Db:     *primary,
+		ReadDb: replica,
+	}
+	err = s.inputRepository.CreateTables()
+	s.Require().NoError(err)
+	_, err = s.inputRepository.Create(context.Background(), convenience.AdvanceInput{
+		Index:          0,
+		Status:         convenience.CompletionStatusUnprocessed,
+		MsgSender:      common.Address{},
+		Payload:        "0x1122",
+		BlockNumber:    1,
+		BlockTimestamp: time.Now(),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *DbRouterSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestDbRouterSuite(t *testing.T) {
+	suite.Run(t, new(DbRouterSuite))
+}
+
+func (s *DbRouterSuite) TestReadsFromPrimaryByDefault() {
+	input, err := s.inputRepository.FindByIndexAndAppContract(context.Background(), 0, nil)
+	s.Require().NoError(err)
+	s.NotNil(input)
+}
+
+func (s *DbRouterSuite) TestReadsFromReplicaWhenAllowed() {
+	ctx := WithReadReplica(context.Background())
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, nil)
+	s.Require().NoError(err)
+	s.Nil(input) // the replica has not received the write yet
+}
+
+func (s *DbRouterSuite) TestReadYourWrites() {
+	ctx := WithReadPrimary(context.Background())
+	ctx = WithReadReplica(ctx)
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, nil)
+	s.Require().NoError(err)
+	s.NotNil(input)
+}
+
+func (s *DbRouterSuite) TestReadsFromPrimaryInsideTransaction() {
+	ctx := WithReadReplica(context.Background())
+	ctx, tx, err := StartTransactionContext(ctx, &s.inputRepository.Db)
+	s.Require().NoError(err)
+	defer tx.Rollback() // nolint
+	s.Equal(&s.inputRepository.Db, s.inputRepository.readDb(ctx))
+}
+
+func (s *DbRouterSuite) TestCountsOutputsFromReplicaWhenAllowed() {
+	primary := &s.inputRepository.Db
+	replica := s.inputRepository.ReadDb
+	for _, db := range []*sqlx.DB{primary, replica} {
+		s.Require().NoError((&VoucherRepository{Db: *db}).CreateTables())
+		s.Require().NoError((&NoticeRepository{Db: *db}).CreateTables())
+	}
+	outputRepository := &OutputRepository{Db: *primary, ReadDb: replica}
+	_, err := (&VoucherRepository{Db: *primary}).CreateVoucher(context.Background(), &convenience.ConvenienceVoucher{
+		Destination: common.Address{},
+		Payload:     "0x1122",
+	})
+	s.Require().NoError(err)
+
+	stats, err := outputRepository.CountOutputs(context.Background(), nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), stats.Vouchers)
+
+	stats, err = outputRepository.CountOutputs(WithReadReplica(context.Background()), nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(0), stats.Vouchers)
+}
@@ -2,15 +2,12 @@ package repository
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	configtest "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/config_test"
-	"github.com/calindra/cartesi-rollups-hl-graphql/postgres/raw"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
 )
@@ -72,7 +69,7 @@ func (s *RawInputRefSuite) TestNoDuplicateInputs() {
 	s.Require().NoError(err)
 
 	var count int
-	err = s.RawInputRefRepository.Db.QueryRow(`SELECT COUNT(*) FROM convenience_input_raw_references WHERE raw_id = ? AND app_contract = ?`,
+	err = s.RawInputRefRepository.Db.QueryRow(`SELECT COUNT(*) FROM convenience_input_raw_references WHERE raw_id = $1 AND app_contract = $2`,
 		uint64(1), appContract.Hex()).Scan(&count)
 
 	s.Require().NoError(err)
@@ -151,8 +148,7 @@ func (s *RawInputRefSuite) TestUpdateStatusJustOneRawID() {
 	s.Require().NoError(err)
 }
 
-func (s *RawInputRefSuite) TestUpdateStatusJustOneRawIDUsingPG() {
-	s.setupPG()
+func (s *RawInputRefSuite) TestUpdateStatusJustOneRawIDAndFindIt() {
 	ctx := context.Background()
 	appContract := common.HexToAddress(configtest.DEFAULT_TEST_APP_CONTRACT)
 	err := s.RawInputRefRepository.Create(ctx, RawInputRef{
@@ -172,26 +168,3 @@ func (s *RawInputRefSuite) TestUpdateStatusJustOneRawIDUsingPG() {
 	s.Require().NoError(err)
 	s.Equal("ACCEPTED", rawInputRef.Status)
 }
-
-func (s *RawInputRefSuite) setupPG() {
-	envMap, err := raw.LoadMapEnvFile()
-	s.NoError(err)
-	dbName := "rollupsdb"
-	dbPass := "password"
-	if _, ok := envMap["POSTGRES_PASSWORD"]; ok {
-		dbPass = envMap["POSTGRES_PASSWORD"]
-	}
-	if _, ok := envMap["POSTGRES_DB"]; ok {
-		dbName = envMap["POSTGRES_DB"]
-	}
-	uri := fmt.Sprintf("postgres://postgres:%s@localhost:5432/%s?sslmode=disable", dbPass, dbName)
-	slog.Info("Raw Input URI", "uri", uri)
-	dbNodeV2 := sqlx.MustConnect("postgres", uri)
-
-	s.RawInputRefRepository = &RawInputRefRepository{
-		Db: *dbNodeV2,
-	}
-
-	err = s.RawInputRefRepository.CreateTables()
-	s.NoError(err)
-}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -15,8 +16,18 @@ import (
 
 const INDEX_FIELD = "Index"
 
+// Input types stored in the type column
+const (
+	INPUT_TYPE_INPUTBOX = "inputbox"
+	INPUT_TYPE_RELAY    = "relay"
+)
+
+// ErrMessageRelayed means the signed message, or its nonce, was relayed before
+var ErrMessageRelayed = errors.New("message already relayed")
+
 type InputRepository struct {
-	Db sqlx.DB
+	Db     sqlx.DB
+	ReadDb *sqlx.DB // optional read replica
 }
 
 type inputRow struct {
@@ -38,6 +49,9 @@ type inputRow struct {
 	Type                   string `db:"type"`
 	CartesiTransactionId   string `db:"cartesi_transaction_id"`
 	ChainId                string `db:"chain_id"`
+	TransactionHash        string `db:"transaction_hash"`
+	LogIndex               int    `db:"log_index"`
+	BlockHash              string `db:"block_hash"`
 }
 
 func (r *InputRepository) CreateTables() error {
@@ -67,8 +81,22 @@ func (r *InputRepository) CreateTables() error {
 	CREATE INDEX IF NOT EXISTS idx_status ON convenience_inputs(status);
 	CREATE INDEX IF NOT EXISTS idx_input_id ON convenience_inputs(app_contract, id);
 	CREATE INDEX IF NOT EXISTS idx_status_app_contract ON convenience_inputs(status, app_contract);
-	CREATE INDEX IF NOT EXISTS idx_input_index_app_contract ON convenience_inputs(input_index, app_contract);`
+	CREATE INDEX IF NOT EXISTS idx_input_index_app_contract ON convenience_inputs(input_index, app_contract);
+
+	CREATE TABLE IF NOT EXISTS convenience_relayed_messages (
+		app_contract	text NOT NULL,
+		msg_sender		text NOT NULL,
+		nonce			bigint NOT NULL,
+		message_id		text NOT NULL UNIQUE,
+		PRIMARY KEY (app_contract, msg_sender, nonce));`
 	_, err := r.Db.Exec(schema)
+	if err == nil {
+		err = addColumns(&r.Db, "convenience_inputs",
+			"transaction_hash text DEFAULT '' NOT NULL",
+			"log_index integer DEFAULT -1 NOT NULL",
+			"block_hash text DEFAULT '' NOT NULL",
+		)
+	}
 	if err == nil {
 		slog.Debug("Inputs table created")
 	} else {
@@ -112,7 +140,11 @@ func (r *InputRepository) rawCreate(ctx context.Context, input model.AdvanceInpu
 		avail_block_number,
 		avail_block_timestamp,
 		type,
-		chain_id
+		chain_id,
+		cartesi_transaction_id,
+		transaction_hash,
+		log_index,
+		block_hash
 	) VALUES (
 		$1,
 		$2,
@@ -130,15 +162,24 @@ func (r *InputRepository) rawCreate(ctx context.Context, input model.AdvanceInpu
 		$14,
 		$15,
 		$16,
-		$17
+		$17,
+		$18,
+		$19,
+		$20,
+		$21
 	);`
 
-	var typee string = "inputbox"
+	var typee string = INPUT_TYPE_INPUTBOX
 
 	if input.Type != "" {
 		typee = input.Type
 	}
 
+	logIndex := input.LogIndex
+	if input.TransactionHash == "" {
+		logIndex = -1
+	}
+
 	var hexPayload string
 	if !strings.HasPrefix(input.Payload, "0x") {
 		hexPayload = "0x" + input.Payload
@@ -167,6 +208,10 @@ func (r *InputRepository) rawCreate(ctx context.Context, input model.AdvanceInpu
 		input.AvailBlockTimestamp.UnixMilli(),
 		typee,
 		input.ChainId,
+		input.CartesiTransactionId,
+		input.TransactionHash,
+		logIndex,
+		input.BlockHash,
 	)
 	if err != nil {
 		return nil, err
@@ -174,6 +219,131 @@ func (r *InputRepository) rawCreate(ctx context.Context, input model.AdvanceInpu
 	return &input, nil
 }
 
+// SetRelayed marks the input as sent by the relay on behalf of the signer
+func (r *InputRepository) SetRelayed(
+	ctx context.Context,
+	appContract common.Address,
+	inputIndex uint64,
+	msgSender common.Address,
+	cartesiTransactionId string,
+) error {
+	sql := `UPDATE convenience_inputs
+	SET type = $1, msg_sender = $2, cartesi_transaction_id = $3
+	WHERE input_index = $4 and app_contract = $5`
+	exec := DBExecutor{&r.Db}
+	res, err := exec.ExecContext(
+		ctx,
+		sql,
+		INPUT_TYPE_RELAY,
+		msgSender.Hex(),
+		cartesiTransactionId,
+		inputIndex,
+		appContract.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no input relayed: input_index %d; app_contract %s", inputIndex, appContract.Hex())
+	}
+	return nil
+}
+
+// SetTransaction stores the L1 transaction and log that added the input
+func (r *InputRepository) SetTransaction(
+	ctx context.Context,
+	appContract common.Address,
+	inputIndex uint64,
+	transactionHash string,
+	logIndex int,
+	blockHash string,
+) error {
+	sql := `UPDATE convenience_inputs
+	SET transaction_hash = $1, log_index = $2, block_hash = $3
+	WHERE input_index = $4 and app_contract = $5`
+	exec := DBExecutor{&r.Db}
+	res, err := exec.ExecContext(
+		ctx,
+		sql,
+		transactionHash,
+		logIndex,
+		blockHash,
+		inputIndex,
+		appContract.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no input updated: input_index %d; app_contract %s", inputIndex, appContract.Hex())
+	}
+	return nil
+}
+
+// FindInputsWithoutTransaction returns the InputBox inputs whose L1
+// transaction is unknown, in block order, starting after the given input
+// when it is set
+func (r *InputRepository) FindInputsWithoutTransaction(
+	ctx context.Context,
+	after *model.AdvanceInput,
+	limit int,
+) ([]*model.AdvanceInput, error) {
+	args := []any{INPUT_TYPE_INPUTBOX}
+	where := ""
+	if after != nil {
+		args = append(args, after.BlockNumber, after.AppContract.Hex(), after.Index)
+		where = "AND (block_number, app_contract, input_index) > ($2, $3, $4)"
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(`SELECT
+			id,
+			input_index,
+			status,
+			msg_sender,
+			payload,
+			block_number,
+			block_timestamp,
+			prev_randao,
+			exception,
+			app_contract,
+			espresso_block_number,
+			espresso_block_timestamp,
+			input_box_index,
+			avail_block_number,
+			avail_block_timestamp,
+			type,
+			chain_id,
+			transaction_hash,
+			log_index,
+			block_hash
+		FROM convenience_inputs
+		WHERE transaction_hash = '' AND type = $1 %s
+		ORDER BY block_number, app_contract, input_index
+		LIMIT $%d`, where, len(args))
+	rows, err := r.readDb(ctx).QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	inputs := []*model.AdvanceInput{}
+	for rows.Next() {
+		input, err := parseInput(rows)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, rows.Err()
+}
+
 func (r *InputRepository) UpdateStatus(ctx context.Context, appContract common.Address, inputIndex uint64, status model.CompletionStatus) error {
 	sql := `UPDATE convenience_inputs
 	SET status = $1
@@ -237,9 +407,12 @@ func (r *InputRepository) FindByStatusNeDesc(ctx context.Context, status model.C
 		avail_block_number,
 		avail_block_timestamp,
 		type,
-		chain_id FROM convenience_inputs WHERE status <> $1
+		chain_id,
+		transaction_hash,
+		log_index,
+		block_hash FROM convenience_inputs WHERE status <> $1
 		ORDER BY input_index DESC`
-	res, err := r.Db.QueryxContext(
+	res, err := r.readDb(ctx).QueryxContext(
 		ctx,
 		sql,
 		status,
@@ -276,10 +449,13 @@ func (r *InputRepository) FindByStatus(ctx context.Context, status model.Complet
 			avail_block_number,
 			avail_block_timestamp,
 			type,
-			chain_id
+			chain_id,
+			transaction_hash,
+			log_index,
+			block_hash
 		FROM convenience_inputs WHERE status = $1
 		ORDER BY input_index ASC`
-	res, err := r.Db.QueryxContext(
+	res, err := r.readDb(ctx).QueryxContext(
 		ctx,
 		sql,
 		status,
@@ -339,7 +515,7 @@ func (r *InputRepository) queryByIdAndAppContract(
 	appContract *common.Address,
 ) (*sqlx.Rows, error) {
 	if appContract != nil {
-		return r.Db.QueryxContext(ctx, `
+		return r.readDb(ctx).QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -357,14 +533,17 @@ func (r *InputRepository) queryByIdAndAppContract(
 				avail_block_number,
 				avail_block_timestamp,
 				type,
-				chain_id FROM convenience_inputs
+				chain_id,
+				transaction_hash,
+				log_index,
+				block_hash FROM convenience_inputs
 			WHERE id = $1 and app_contract = $2
 			LIMIT 1`,
 			id,
 			appContract.Hex(),
 		)
 	} else {
-		return r.Db.QueryxContext(ctx, `
+		return r.readDb(ctx).QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -382,7 +561,10 @@ func (r *InputRepository) queryByIdAndAppContract(
 				avail_block_number,
 				avail_block_timestamp,
 				type,
-				chain_id FROM convenience_inputs
+				chain_id,
+				transaction_hash,
+				log_index,
+				block_hash FROM convenience_inputs
 			WHERE id = $1
 			LIMIT 1`,
 			id,
@@ -396,7 +578,7 @@ func (r *InputRepository) queryByIndexAndAppContract(
 	appContract *common.Address,
 ) (*sqlx.Rows, error) {
 	if appContract != nil {
-		return r.Db.QueryxContext(ctx, `
+		return r.readDb(ctx).QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -414,14 +596,17 @@ func (r *InputRepository) queryByIndexAndAppContract(
 				avail_block_number,
 				avail_block_timestamp,
 				type,
-				chain_id FROM convenience_inputs
+				chain_id,
+				transaction_hash,
+				log_index,
+				block_hash FROM convenience_inputs
 			WHERE input_index = $1 and app_contract = $2
 			LIMIT 1`,
 			id,
 			appContract.Hex(),
 		)
 	} else {
-		return r.Db.QueryxContext(ctx, `
+		return r.readDb(ctx).QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -439,7 +624,10 @@ func (r *InputRepository) queryByIndexAndAppContract(
 				avail_block_number,
 				avail_block_timestamp,
 				type,
-				chain_id FROM convenience_inputs
+				chain_id,
+				transaction_hash,
+				log_index,
+				block_hash FROM convenience_inputs
 			WHERE input_index = $1
 			LIMIT 1`,
 			id,
@@ -454,7 +642,7 @@ func (c *InputRepository) GetNonce(
 ) (uint64, error) {
 	query := `SELECT count(*) FROM convenience_inputs 
 	WHERE app_contract = $1 and msg_sender = $2`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("Count execution error")
 		return 0, err
@@ -469,6 +657,140 @@ func (c *InputRepository) GetNonce(
 	return count, nil
 }
 
+// HasInputs tells whether the application has any input
+func (c *InputRepository) HasInputs(ctx context.Context, appContract common.Address) (bool, error) {
+	var exists bool
+	err := c.readDb(ctx).GetContext(ctx, &exists,
+		`SELECT EXISTS (SELECT 1 FROM convenience_inputs WHERE app_contract = $1)`,
+		appContract.Hex())
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// SaveRelayedMessage records the nonce of the signer taken by the relayed
+// message. It fails with ErrMessageRelayed when the nonce or the message
+// were relayed before.
+func (c *InputRepository) SaveRelayedMessage(
+	ctx context.Context,
+	appContract common.Address,
+	msgSender common.Address,
+	nonce uint64,
+	messageId string,
+) error {
+	exec := DBExecutor{&c.Db}
+	res, err := exec.ExecContext(ctx, `INSERT INTO convenience_relayed_messages
+		(app_contract, msg_sender, nonce, message_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`,
+		appContract.Hex(), msgSender.Hex(), nonce, messageId,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: nonce %d of %s", ErrMessageRelayed, nonce, msgSender.Hex())
+	}
+	return nil
+}
+
+// GetRelayedNonce returns the nonce after the last message relayed for the signer
+func (c *InputRepository) GetRelayedNonce(
+	ctx context.Context,
+	appContract common.Address,
+	msgSender common.Address,
+) (uint64, error) {
+	var nonce uint64
+	err := c.Db.GetContext(ctx, &nonce, `SELECT COALESCE(MAX(nonce) + 1, 0)
+		FROM convenience_relayed_messages
+		WHERE app_contract = $1 AND msg_sender = $2`,
+		appContract.Hex(), msgSender.Hex())
+	if err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// IsMessageRelayed tells whether the message was relayed before
+func (c *InputRepository) IsMessageRelayed(ctx context.Context, messageId string) (bool, error) {
+	var exists bool
+	err := c.Db.GetContext(ctx, &exists,
+		`SELECT EXISTS (SELECT 1 FROM convenience_relayed_messages WHERE message_id = $1)`,
+		messageId)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Stats aggregates the inputs of the application, or of all of them when nil.
+// Inputs without a block timestamp are left out of the timestamps and days.
+func (c *InputRepository) Stats(
+	ctx context.Context,
+	appContract *common.Address,
+) (*model.InputStats, error) {
+	where, args := "", []any{}
+	if appContract != nil {
+		where, args = "WHERE app_contract = $1 ", []any{appContract.Hex()}
+	}
+	db := c.readDb(ctx)
+
+	statuses := []struct {
+		Status model.CompletionStatus `db:"status"`
+		Count  uint64                 `db:"count"`
+	}{}
+	err := db.SelectContext(ctx, &statuses,
+		`SELECT status, COUNT(*) AS count FROM convenience_inputs `+where+`GROUP BY status`, args...)
+	if err != nil {
+		slog.Error("Stats execution error", "err", err)
+		return nil, err
+	}
+	stats := model.InputStats{ByStatus: map[model.CompletionStatus]uint64{}}
+	for _, status := range statuses {
+		stats.ByStatus[status.Status] += status.Count
+	}
+
+	var summary struct {
+		Senders uint64 `db:"senders"`
+		First   *int64 `db:"first"`
+		Last    *int64 `db:"last"`
+	}
+	err = db.GetContext(ctx, &summary, `SELECT
+		COUNT(DISTINCT msg_sender) AS senders,
+		CAST(MIN(NULLIF(block_timestamp, 0)) AS BIGINT) AS first,
+		CAST(MAX(NULLIF(block_timestamp, 0)) AS BIGINT) AS last
+		FROM convenience_inputs `+where, args...)
+	if err != nil {
+		slog.Error("Stats execution error", "err", err)
+		return nil, err
+	}
+	stats.UniqueSenders = summary.Senders
+	if summary.First != nil && summary.Last != nil {
+		first, last := time.UnixMilli(*summary.First), time.UnixMilli(*summary.Last)
+		stats.FirstTimestamp, stats.LastTimestamp = &first, &last
+	}
+
+	// the timestamps are stored in milliseconds
+	day := `date(block_timestamp / 1000, 'unixepoch')`
+	if c.Db.DriverName() == "postgres" {
+		day = `to_char(to_timestamp(block_timestamp / 1000) AT TIME ZONE 'UTC', 'YYYY-MM-DD')`
+	}
+	stats.PerDay = []model.DailyCount{}
+	err = db.SelectContext(ctx, &stats.PerDay, `SELECT `+day+` AS day, COUNT(*) AS count
+		FROM convenience_inputs WHERE block_timestamp > 0 `+strings.Replace(where, "WHERE", "AND", 1)+
+		`GROUP BY 1 ORDER BY 1`, args...)
+	if err != nil {
+		slog.Error("Stats execution error", "err", err)
+		return nil, err
+	}
+	return &stats, nil
+}
+
 func (c *InputRepository) Count(
 	ctx context.Context,
 	filter []*model.ConvenienceFilter,
@@ -480,8 +802,8 @@ func (c *InputRepository) Count(
 		return 0, err
 	}
 	query += where
-	slog.Debug("Query", "query", query, "args", args)
-	stmt, err := c.Db.Preparex(query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("Count execution error")
 		return 0, err
@@ -526,7 +848,10 @@ func (c *InputRepository) FindAll(
 			avail_block_number,
 			avail_block_timestamp,
 			type,
-			chain_id
+			chain_id,
+			transaction_hash,
+			log_index,
+			block_hash
 		FROM convenience_inputs `
 	where, args, argsCount, err := transformToInputQuery(filter)
 	if err != nil {
@@ -547,8 +872,8 @@ func (c *InputRepository) FindAll(
 	query += fmt.Sprintf(`OFFSET $%d `, argsCount)
 	args = append(args, offset)
 
-	slog.Debug("Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("Find all error", "error", err)
 		return nil, err
@@ -682,6 +1007,9 @@ func parseRowInput(row inputRow) model.AdvanceInput {
 		Type:                   row.Type,
 		CartesiTransactionId:   row.CartesiTransactionId,
 		ChainId:                row.ChainId,
+		TransactionHash:        row.TransactionHash,
+		LogIndex:               row.LogIndex,
+		BlockHash:              row.BlockHash,
 	}
 }
 
@@ -715,6 +1043,9 @@ func parseInput(res *sqlx.Rows) (*model.AdvanceInput, error) {
 		&availBlockTimestamp,
 		&input.Type,
 		&input.ChainId,
+		&input.TransactionHash,
+		&input.LogIndex,
+		&input.BlockHash,
 	)
 	if err != nil {
 		return nil, err
@@ -730,6 +1061,60 @@ func parseInput(res *sqlx.Rows) (*model.AdvanceInput, error) {
 	return &input, nil
 }
 
+// StreamByIndex calls fn with each input of the application whose index is
+// in [fromIndex, toIndex], in index order, reading them in batches
+func (r *InputRepository) StreamByIndex(
+	ctx context.Context,
+	appContract common.Address,
+	fromIndex uint64,
+	toIndex uint64,
+	fn func(*model.AdvanceInput) error,
+) error {
+	query := `SELECT
+			id,
+			input_index,
+			status,
+			msg_sender,
+			payload,
+			block_number,
+			block_timestamp,
+			prev_randao,
+			exception,
+			app_contract,
+			espresso_block_number,
+			espresso_block_timestamp,
+			input_box_index,
+			avail_block_number,
+			avail_block_timestamp,
+			type,
+			chain_id,
+			transaction_hash,
+			log_index,
+			block_hash
+		FROM convenience_inputs
+		WHERE app_contract = $1 AND input_index >= $2 AND input_index <= $3
+		ORDER BY input_index ASC
+		LIMIT $4`
+	find := func(fromIndex uint64, limit int) ([]*model.AdvanceInput, error) {
+		rows, err := r.readDb(ctx).QueryxContext(ctx, query, appContract.Hex(), fromIndex, toIndex, limit)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		inputs := []*model.AdvanceInput{}
+		for rows.Next() {
+			input, err := parseInput(rows)
+			if err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, input)
+		}
+		return inputs, rows.Err()
+	}
+	index := func(input *model.AdvanceInput) uint64 { return uint64(input.Index) }
+	return streamByIndex(fromIndex, toIndex, find, index, fn)
+}
+
 func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 	ctx context.Context,
 	filters []*BatchFilterItem,
@@ -753,7 +1138,10 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 		avail_block_number,
 		avail_block_timestamp,
 		type,
-		chain_id
+		chain_id,
+		transaction_hash,
+		log_index,
+		block_hash
 	FROM convenience_inputs WHERE `
 
 	args := []interface{}{}
@@ -768,12 +1156,11 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 
 	query += strings.Join(where, " or ")
 
-	errors := []error{}
 	results := []*model.AdvanceInput{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("BatchFind prepare context", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 	defer stmt.Close()
 
@@ -782,7 +1169,7 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 	err = stmt.SelectContext(ctx, &inputs, args...)
 	if err != nil {
 		slog.Error("BatchFind", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 
 	inputMap := make(map[string]*model.AdvanceInput)
@@ -806,3 +1193,7 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 func GenerateBatchInputKey(appContract string, inputIndex uint64) string {
 	return fmt.Sprintf("%s|%d", appContract, inputIndex)
 }
+
+func (r *InputRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, &r.Db, r.ReadDb)
+}
@@ -133,6 +133,82 @@ func (s *InputRepositorySuite) TestCreateInputAndUpdateStatus() {
 	s.Equal("0x70997970C51812dc3A010C7d01b50e0d17dc79C8", input2.AppContract.Hex())
 }
 
+func (s *InputRepositorySuite) TestSetRelayed() {
+	ctx := context.Background()
+	appContract := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	signer := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	_, err := s.inputRepository.Create(ctx, convenience.AdvanceInput{
+		ID:             "3",
+		Index:          3,
+		Status:         convenience.CompletionStatusUnprocessed,
+		MsgSender:      common.Address{},
+		Payload:        "0x1122",
+		BlockNumber:    1,
+		BlockTimestamp: time.Now(),
+		AppContract:    appContract,
+	})
+	s.Require().NoError(err)
+
+	err = s.inputRepository.SetRelayed(ctx, appContract, 3, signer, "0xabcd")
+	s.Require().NoError(err)
+	input, err := s.inputRepository.FindByIDAndAppContract(ctx, "3", &appContract)
+	s.Require().NoError(err)
+	s.Equal(INPUT_TYPE_RELAY, input.Type)
+	s.Equal(signer, input.MsgSender)
+	nonce, err := s.inputRepository.GetNonce(ctx, appContract, signer)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), nonce)
+
+	err = s.inputRepository.SetRelayed(ctx, appContract, 4, signer, "0xabcd")
+	s.Error(err)
+}
+
+func (s *InputRepositorySuite) TestInputTransaction() {
+	ctx := context.Background()
+	appContract := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	txHash := common.HexToHash("0xaa").Hex()
+	blockHash := common.HexToHash("0xbb").Hex()
+	for i := 0; i < 2; i++ {
+		input := convenience.AdvanceInput{
+			ID:             strconv.Itoa(i),
+			Index:          i,
+			Status:         convenience.CompletionStatusUnprocessed,
+			Payload:        "0x1122",
+			BlockNumber:    1,
+			BlockTimestamp: time.Now(),
+			AppContract:    appContract,
+		}
+		if i == 1 {
+			input.TransactionHash = txHash
+			input.LogIndex = 2
+			input.BlockHash = blockHash
+		}
+		_, err := s.inputRepository.Create(ctx, input)
+		s.Require().NoError(err)
+	}
+
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &appContract)
+	s.Require().NoError(err)
+	s.Equal("", input.TransactionHash)
+	s.Equal(-1, input.LogIndex)
+	input, err = s.inputRepository.FindByIndexAndAppContract(ctx, 1, &appContract)
+	s.Require().NoError(err)
+	s.Equal(txHash, input.TransactionHash)
+	s.Equal(2, input.LogIndex)
+	s.Equal(blockHash, input.BlockHash)
+
+	err = s.inputRepository.SetTransaction(ctx, appContract, 0, txHash, 1, blockHash)
+	s.Require().NoError(err)
+	page, err := s.inputRepository.FindAll(ctx, nil, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Len(page.Rows, 2)
+	s.Equal(txHash, page.Rows[0].TransactionHash)
+	s.Equal(1, page.Rows[0].LogIndex)
+
+	err = s.inputRepository.SetTransaction(ctx, appContract, 5, txHash, 1, blockHash) // nolint
+	s.Error(err)
+}
+
 func (s *InputRepositorySuite) TestCreateInputFindByStatus() {
 	ctx := context.Background()
 	input, err := s.inputRepository.Create(ctx, convenience.AdvanceInput{
@@ -338,3 +414,89 @@ func (s *InputRepositorySuite) TestBatchFindInput() {
 func (s *InputRepositorySuite) TearDownTest() {
 	defer s.dbFactory.Cleanup()
 }
+
+func (s *InputRepositorySuite) TestStats() {
+	ctx := context.Background()
+	app := common.HexToAddress(devnet.ApplicationAddress)
+	day := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	for i, input := range []convenience.AdvanceInput{
+		{Status: convenience.CompletionStatusAccepted, MsgSender: common.HexToAddress("0x01"), BlockTimestamp: day},
+		{Status: convenience.CompletionStatusAccepted, MsgSender: common.HexToAddress("0x02"), BlockTimestamp: day.Add(2 * time.Hour)},
+		{Status: convenience.CompletionStatusRejected, MsgSender: common.HexToAddress("0x01"), BlockTimestamp: day.Add(3 * time.Hour)},
+		{Status: convenience.CompletionStatusUnprocessed, MsgSender: common.HexToAddress("0x01"), BlockTimestamp: time.UnixMilli(0)},
+	} {
+		input.ID = strconv.Itoa(i)
+		input.Index = i
+		input.AppContract = app
+		_, err := s.inputRepository.Create(ctx, input)
+		s.Require().NoError(err)
+	}
+	_, err := s.inputRepository.Create(ctx, convenience.AdvanceInput{
+		ID:             "other",
+		AppContract:    common.HexToAddress("0x03"),
+		MsgSender:      common.HexToAddress("0x03"),
+		BlockTimestamp: day,
+	})
+	s.Require().NoError(err)
+
+	stats, err := s.inputRepository.Stats(ctx, &app)
+	s.Require().NoError(err)
+	s.Equal(map[convenience.CompletionStatus]uint64{
+		convenience.CompletionStatusAccepted:    2,
+		convenience.CompletionStatusRejected:    1,
+		convenience.CompletionStatusUnprocessed: 1,
+	}, stats.ByStatus)
+	s.Equal(2, int(stats.UniqueSenders))
+	s.Equal(day.Unix(), stats.FirstTimestamp.Unix())
+	s.Equal(day.Add(3*time.Hour).Unix(), stats.LastTimestamp.Unix())
+	s.Equal([]convenience.DailyCount{
+		{Day: "2024-05-01", Count: 1},
+		{Day: "2024-05-02", Count: 2},
+	}, stats.PerDay)
+
+	stats, err = s.inputRepository.Stats(ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(3, int(stats.UniqueSenders))
+
+	empty := common.HexToAddress("0x04")
+	stats, err = s.inputRepository.Stats(ctx, &empty)
+	s.Require().NoError(err)
+	s.Empty(stats.ByStatus)
+	s.Nil(stats.FirstTimestamp)
+	s.Empty(stats.PerDay)
+}
+
+func (s *InputRepositorySuite) TestFindInputsWithoutTransaction() {
+	ctx := context.Background()
+	app := common.HexToAddress(devnet.ApplicationAddress)
+	for i := 0; i < 3; i++ {
+		input := convenience.AdvanceInput{
+			ID:          strconv.Itoa(i),
+			Index:       i,
+			AppContract: app,
+			BlockNumber: uint64(10 - i),
+		}
+		if i == 1 {
+			input.TransactionHash = common.HexToHash("0xaa").Hex()
+		}
+		_, err := s.inputRepository.Create(ctx, input)
+		s.Require().NoError(err)
+	}
+	_, err := s.inputRepository.Create(ctx, convenience.AdvanceInput{
+		ID:          "relayed",
+		Index:       3,
+		AppContract: app,
+		Type:        INPUT_TYPE_RELAY,
+	})
+	s.Require().NoError(err)
+
+	inputs, err := s.inputRepository.FindInputsWithoutTransaction(ctx, nil, 1)
+	s.Require().NoError(err)
+	s.Require().Len(inputs, 1)
+	s.Equal(2, inputs[0].Index)
+
+	inputs, err = s.inputRepository.FindInputsWithoutTransaction(ctx, inputs[0], 10)
+	s.Require().NoError(err)
+	s.Require().Len(inputs, 1)
+	s.Equal(0, inputs[0].Index)
+}
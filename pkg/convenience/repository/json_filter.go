@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+var jsonPathSegment = regexp.MustCompile(`^(?:\.([A-Za-z_][A-Za-z0-9_]*)|\[([0-9]+)\])`)
+
+// jsonColumnType is jsonb on Postgres, so the filters can use its operators
+func jsonColumnType(db *sqlx.DB) string {
+	if db.DriverName() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+// ParseJsonPath splits a path like $.items[0].type in its keys and indexes
+func ParseJsonPath(path string) ([]string, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok || rest == "" {
+		return nil, fmt.Errorf("%w: JSON path must start with $. or $[: %s", ErrInvalidFilter, path)
+	}
+	segments := []string{}
+	for rest != "" {
+		match := jsonPathSegment.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("%w: unsupported JSON path: %s", ErrInvalidFilter, path)
+		}
+		if match[1] != "" {
+			segments = append(segments, match[1])
+		} else {
+			segments = append(segments, match[2])
+		}
+		rest = rest[len(match[0]):]
+	}
+	return segments, nil
+}
+
+// transformToJsonQuery compares the value at the path of the JSON column as
+// text, or as a number for the gt, gte, lt and lte operators. Postgres keeps
+// the column as jsonb and SQLite as text.
+func transformToJsonQuery(
+	driver string, column string, filter *model.ConvenienceFilter, count int,
+) ([]string, []any, int, error) {
+	if filter.Path == nil {
+		return nil, nil, 0, fmt.Errorf("%w: missing JSON path", ErrInvalidFilter)
+	}
+	segments, err := ParseJsonPath(*filter.Path)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	where := []string{}
+	args := []any{}
+	var value, number string
+	if driver == "postgres" {
+		args = append(args, pq.Array(segments))
+		value = fmt.Sprintf("jsonb_extract_path_text(%s, VARIADIC $%d::text[])", column, count)
+		number = fmt.Sprintf(`CASE WHEN jsonb_typeof(%[1]s #> $%[2]d::text[]) = 'number'
+			THEN (%[1]s #>> $%[2]d::text[])::numeric END`, column, count)
+	} else {
+		args = append(args, *filter.Path)
+		value = fmt.Sprintf(`CASE json_type(%[1]s, $%[2]d) WHEN 'true' THEN 'true' WHEN 'false' THEN 'false'
+			ELSE CAST(json_extract(%[1]s, $%[2]d) AS TEXT) END`, column, count)
+		number = fmt.Sprintf(`CASE WHEN json_type(%[1]s, $%[2]d) IN ('integer', 'real')
+			THEN json_extract(%[1]s, $%[2]d) END`, column, count)
+	}
+	count += 1
+
+	compare := func(expr string, operator string, operand *string) {
+		if operand == nil {
+			return
+		}
+		where = append(where, fmt.Sprintf("%s %s $%d ", expr, operator, count))
+		args = append(args, *operand)
+		count += 1
+	}
+	compare(value, "=", filter.Eq)
+	compare(value, "<>", filter.Ne)
+	numbers := []struct {
+		operator string
+		operand  *string
+	}{{">", filter.Gt}, {">=", filter.Gte}, {"<", filter.Lt}, {"<=", filter.Lte}}
+	for _, n := range numbers {
+		if n.operand == nil {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(*n.operand, 64)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("%w: %s is not a number", ErrInvalidFilter, *n.operand)
+		}
+		where = append(where, fmt.Sprintf("%s %s $%d ", number, n.operator, count))
+		args = append(args, parsed)
+		count += 1
+	}
+	for _, list := range []struct {
+		operator string
+		items    []*string
+	}{{"IN", filter.In}, {"NOT IN", filter.Nin}} {
+		if len(list.items) == 0 {
+			continue
+		}
+		placeholders := make([]string, len(list.items))
+		for i, item := range list.items {
+			if item == nil {
+				return nil, nil, 0, fmt.Errorf("%w: null in the %s list", ErrInvalidFilter, list.operator)
+			}
+			placeholders[i] = fmt.Sprintf("$%d", count)
+			args = append(args, *item)
+			count += 1
+		}
+		where = append(where, fmt.Sprintf("%s %s (%s) ", value, list.operator, strings.Join(placeholders, ", ")))
+	}
+	if len(where) == 0 {
+		return nil, nil, 0, fmt.Errorf("%w: JSON filter without an operator", ErrInvalidFilter)
+	}
+	return where, args, count, nil
+}
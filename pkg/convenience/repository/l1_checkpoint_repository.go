@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// L1CheckpointRepository keeps the last block indexed from the L1,
+// one row per indexer, so a restart resumes where it stopped
+type L1CheckpointRepository struct {
+	Db sqlx.DB
+}
+
+func (r *L1CheckpointRepository) CreateTables() error {
+	schema := `CREATE TABLE IF NOT EXISTS l1_checkpoints (
+		name 			text NOT NULL PRIMARY KEY,
+		block_number 	bigint NOT NULL);`
+
+	_, err := r.Db.Exec(schema)
+	if err != nil {
+		slog.Error("Failed to create tables", "error", err)
+		return err
+	}
+	slog.Debug("L1 checkpoints table created successfully")
+	return nil
+}
+
+// GetLastBlock returns nil when nothing was indexed yet
+func (r *L1CheckpointRepository) GetLastBlock(ctx context.Context, name string) (*uint64, error) {
+	var blockNumber uint64
+	err := r.Db.GetContext(ctx, &blockNumber,
+		`SELECT block_number FROM l1_checkpoints WHERE name = $1`, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &blockNumber, nil
+}
+
+func (r *L1CheckpointRepository) SaveLastBlock(ctx context.Context, name string, blockNumber uint64) error {
+	exec := DBExecutor{&r.Db}
+	_, err := exec.ExecContext(ctx, `INSERT INTO l1_checkpoints (name, block_number)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET block_number = excluded.block_number`,
+		name, blockNumber,
+	)
+	return err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/stretchr/testify/suite"
+)
+
+type L1CheckpointRepositorySuite struct {
+	suite.Suite
+	repository *L1CheckpointRepository
+	dbFactory  *commons.DbFactory
+}
+
+func (s *L1CheckpointRepositorySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("l1checkpoint.sqlite3")
+	s.repository = &L1CheckpointRepository{
+		Db: *db,
+	}
+	err := s.repository.CreateTables()
+	s.NoError(err)
+}
+
+func (s *L1CheckpointRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestL1CheckpointRepositorySuite(t *testing.T) {
+	suite.Run(t, new(L1CheckpointRepositorySuite))
+}
+
+func (s *L1CheckpointRepositorySuite) TestSaveAndGetLastBlock() {
+	ctx := context.Background()
+	lastBlock, err := s.repository.GetLastBlock(ctx, "indexer")
+	s.Require().NoError(err)
+	s.Nil(lastBlock)
+
+	s.Require().NoError(s.repository.SaveLastBlock(ctx, "indexer", 10))
+	s.Require().NoError(s.repository.SaveLastBlock(ctx, "indexer", 20))
+	s.Require().NoError(s.repository.SaveLastBlock(ctx, "other", 5))
+
+	lastBlock, err = s.repository.GetLastBlock(ctx, "indexer")
+	s.Require().NoError(err)
+	s.Equal(uint64(20), *lastBlock)
+}
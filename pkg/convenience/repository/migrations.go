@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// addColumns adds the columns the tables created by the previous versions
+// do not have, as CREATE TABLE IF NOT EXISTS leaves them as they are.
+// Each column is its definition, like "payload_text text".
+func addColumns(db *sqlx.DB, table string, columns ...string) error {
+	for _, column := range columns {
+		if db.DriverName() == "postgres" {
+			_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, column))
+			if err != nil {
+				return fmt.Errorf("add column %s.%s: %w", table, column, err)
+			}
+			continue
+		}
+		// SQLite has no ADD COLUMN IF NOT EXISTS
+		name, _, _ := strings.Cut(column, " ")
+		var count int
+		err := db.Get(&count, "SELECT count(*) FROM pragma_table_info($1) WHERE name = $2", table, name)
+		if err != nil {
+			return fmt.Errorf("get columns of %s: %w", table, err)
+		}
+		if count > 0 {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, column)); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", table, column, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+// the tables as the first release created them
+const previousSchema = `CREATE TABLE convenience_inputs (
+	id text NOT NULL,
+	input_index integer,
+	app_contract text,
+	status text,
+	msg_sender text,
+	payload text,
+	block_number integer,
+	block_timestamp NUMERIC,
+	prev_randao text,
+	exception text,
+	espresso_block_number integer,
+	espresso_block_timestamp NUMERIC,
+	input_box_index integer,
+	avail_block_number integer,
+	avail_block_timestamp NUMERIC,
+	type text,
+	cartesi_transaction_id text,
+	chain_id text);
+
+CREATE TABLE vouchers (
+	destination text,
+	payload text,
+	executed BOOLEAN,
+	input_index integer,
+	output_index integer,
+	value text,
+	output_hashes_siblings text,
+	app_contract text,
+	transaction_hash text DEFAULT '' NOT NULL,
+	proof_output_index integer DEFAULT 0,
+	PRIMARY KEY (input_index, output_index, app_contract));
+
+CREATE TABLE notices (
+	payload text,
+	input_index integer,
+	output_index integer,
+	app_contract text,
+	output_hashes_siblings text,
+	proof_output_index integer DEFAULT 0,
+	PRIMARY KEY (input_index, output_index, app_contract));
+
+CREATE TABLE convenience_reports (
+	output_index integer,
+	payload text,
+	input_index integer,
+	app_contract text,
+	raw_id integer,
+	PRIMARY KEY (input_index, output_index, app_contract));`
+
+var migrationApp = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+
+type MigrationsSuite struct {
+	suite.Suite
+	dbFactory *commons.DbFactory
+	db        *sqlx.DB
+}
+
+func TestMigrationsSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsSuite))
+}
+
+func (s *MigrationsSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	s.db = s.dbFactory.CreateDb("migrations.sqlite3")
+	_, err := s.db.Exec(previousSchema)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`INSERT INTO convenience_inputs VALUES
+		('0', 0, $1, 0, $1, '0x00', 1, 0, '0x', '0x', -1, -1, 0, -1, -1, 'inputbox', '', '31337')`,
+		migrationApp.Hex())
+	s.Require().NoError(err)
+}
+
+func (s *MigrationsSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func (s *MigrationsSuite) TestUpgrade() {
+	ctx := context.Background()
+	inputs := &InputRepository{Db: *s.db}
+	vouchers := &VoucherRepository{Db: *s.db}
+	notices := &NoticeRepository{Db: *s.db}
+	reports := &ReportRepository{Db: s.db}
+	// twice, the second time there is nothing to add
+	for i := 0; i < 2; i++ {
+		s.Require().NoError(inputs.CreateTables())
+		s.Require().NoError(vouchers.CreateTables())
+		s.Require().NoError(notices.CreateTables())
+		s.Require().NoError(reports.CreateTables())
+	}
+
+	input, err := inputs.FindByIndexAndAppContract(ctx, 0, &migrationApp)
+	s.Require().NoError(err)
+	s.Require().NotNil(input)
+	s.Empty(input.TransactionHash)
+	s.Equal(-1, input.LogIndex)
+	_, err = inputs.Create(ctx, model.AdvanceInput{
+		ID:              "1",
+		Index:           1,
+		AppContract:     migrationApp,
+		BlockTimestamp:  time.Unix(1, 0),
+		TransactionHash: common.HexToHash("0x01").Hex(),
+		LogIndex:        2,
+		BlockHash:       common.HexToHash("0x02").Hex(),
+	})
+	s.Require().NoError(err)
+	input, err = inputs.FindByIndexAndAppContract(ctx, 1, &migrationApp)
+	s.Require().NoError(err)
+	s.Equal(common.HexToHash("0x01").Hex(), input.TransactionHash)
+	s.Equal(2, input.LogIndex)
+
+	executed := &model.ConvenienceVoucher{
+		AppContract: migrationApp,
+		ExecutedBy:  migrationApp.Hex(),
+		ExecutedAt:  10,
+	}
+	_, err = vouchers.CreateVoucher(ctx, executed)
+	s.Require().NoError(err)
+	s.Require().NoError(vouchers.SetExecutionDetails(ctx, executed))
+	voucher, err := vouchers.FindVoucherByOutputIndexAndAppContract(ctx, 0, &migrationApp)
+	s.Require().NoError(err)
+	s.Require().NotNil(voucher)
+	s.Equal(migrationApp.Hex(), voucher.ExecutedBy)
+	s.Equal(uint64(10), voucher.ExecutedAt)
+
+	text := "hello"
+	_, err = notices.Create(ctx, &model.ConvenienceNotice{
+		AppContract: migrationApp.Hex(),
+		Payload:     "0x68656c6c6f",
+		PayloadText: &text,
+	})
+	s.Require().NoError(err)
+	notice, err := notices.FindNoticeByOutputIndexAndAppContract(ctx, 0, &migrationApp)
+	s.Require().NoError(err)
+	s.Require().NotNil(notice)
+	s.Equal(&text, notice.PayloadText)
+
+	_, err = reports.CreateReport(ctx, model.Report{
+		AppContract: migrationApp,
+		Payload:     "0x68656c6c6f",
+		PayloadText: &text,
+	})
+	s.Require().NoError(err)
+	report, err := reports.FindReportByAppContractAndIndex(ctx, 0, migrationApp)
+	s.Require().NoError(err)
+	s.Require().NotNil(report)
+	s.Equal(&text, report.PayloadText)
+}
@@ -18,8 +18,14 @@ type NoticeRepository struct {
 	Db               sqlx.DB
 	OutputRepository OutputRepository
 	AutoCount        bool
+	ReadDb           *sqlx.DB // optional read replica
 }
 
+// noticeListColumns are the columns of the notice lists. The proofs
+// are left out, they are loaded in batches by the proof loader.
+const noticeListColumns = `payload, input_index, output_index, app_contract,
+	payload_text, payload_json`
+
 func (c *NoticeRepository) CreateTables() error {
 	schema := `CREATE TABLE IF NOT EXISTS notices (
 		payload 		text,
@@ -36,7 +42,13 @@ func (c *NoticeRepository) CreateTables() error {
 	CREATE INDEX IF NOT EXISTS idx_input_index_output_index ON notices(input_index, output_index);`
 	// execute a query on the server
 	_, err := c.Db.Exec(schema)
-	return err
+	if err != nil {
+		return err
+	}
+	return addColumns(&c.Db, "notices",
+		"payload_text text",
+		"payload_json "+jsonColumnType(&c.Db),
+	)
 }
 
 func (c *NoticeRepository) Create(
@@ -56,8 +68,11 @@ func (c *NoticeRepository) Create(
 		output_index,
 		app_contract,
 		output_hashes_siblings,
-		proof_output_index) VALUES ($1, $2, $3, $4, $5, $6)`
+		proof_output_index,
+		payload_text,
+		payload_json) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
+	data.PayloadText, data.PayloadJson = model.NoticePayloadContent(data.Payload)
 	exec := DBExecutor{&c.Db}
 	_, err := exec.ExecContext(ctx,
 		insertSql,
@@ -67,6 +82,8 @@ func (c *NoticeRepository) Create(
 		common.HexToAddress(data.AppContract).Hex(),
 		data.OutputHashesSiblings,
 		data.ProofOutputIndex,
+		data.PayloadText,
+		data.PayloadJson,
 	)
 	if err != nil {
 		slog.Error("Error creating notice", "Error", err)
@@ -79,13 +96,16 @@ func (c *NoticeRepository) Update(
 	ctx context.Context, data *model.ConvenienceNotice,
 ) (*model.ConvenienceNotice, error) {
 	sqlUpdate := `UPDATE notices SET 
-		payload = $1
-		WHERE input_index = $2 and output_index = $3`
+		payload = $1, payload_text = $2, payload_json = $3
+		WHERE input_index = $4 and output_index = $5`
+	data.PayloadText, data.PayloadJson = model.NoticePayloadContent(data.Payload)
 	exec := DBExecutor{&c.Db}
 	_, err := exec.ExecContext(
 		ctx,
 		sqlUpdate,
 		data.Payload,
+		data.PayloadText,
+		data.PayloadJson,
 		data.InputIndex,
 		data.OutputIndex,
 	)
@@ -132,13 +152,13 @@ func (c *NoticeRepository) Count(
 	filter []*model.ConvenienceFilter,
 ) (uint64, error) {
 	query := `SELECT count(*) FROM notices `
-	where, args, _, err := transformToNoticeQuery(filter)
+	where, args, _, err := transformToNoticeQuery(c.Db.DriverName(), filter)
 	if err != nil {
 		return 0, err
 	}
 	query += where
-	slog.Debug("Query", "query", query, "args", args)
-	stmt, err := c.Db.Preparex(query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -163,8 +183,8 @@ func (c *NoticeRepository) FindAllNotices(
 	if err != nil {
 		return nil, err
 	}
-	query := `SELECT * FROM notices `
-	where, args, argsCount, err := transformToNoticeQuery(filter)
+	query := `SELECT ` + noticeListColumns + ` FROM notices `
+	where, args, argsCount, err := transformToNoticeQuery(c.Db.DriverName(), filter)
 	if err != nil {
 		return nil, err
 	}
@@ -180,8 +200,8 @@ func (c *NoticeRepository) FindAllNotices(
 	query += fmt.Sprintf("OFFSET $%d ", argsCount)
 	args = append(args, offset)
 
-	slog.Debug("Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.Preparex(query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +222,7 @@ func (c *NoticeRepository) FindAllNotices(
 func (c *NoticeRepository) FindAllNoticesByBlockNumber(
 	ctx context.Context, startBlockGte uint64, endBlockLt uint64,
 ) ([]*model.ConvenienceNotice, error) {
-	stmt, err := c.Db.Preparex(`
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, `
 		SELECT
 			n.payload,
 			n.input_index,
@@ -260,7 +280,7 @@ func (c *NoticeRepository) queryByOutputIndexAndAppContract(
 	appContract *common.Address,
 ) (*sqlx.Rows, error) {
 	if appContract != nil {
-		return c.Db.QueryxContext(ctx, `
+		return c.readDb(ctx).QueryxContext(ctx, `
 			SELECT * FROM notices
 			WHERE output_index = $1 and app_contract = $2
 			LIMIT 1`,
@@ -268,7 +288,7 @@ func (c *NoticeRepository) queryByOutputIndexAndAppContract(
 			appContract.Hex(),
 		)
 	} else {
-		return c.Db.QueryxContext(ctx, `
+		return c.readDb(ctx).QueryxContext(ctx, `
 			SELECT * FROM notices
 			WHERE output_index = $1
 			LIMIT 1`,
@@ -277,11 +297,34 @@ func (c *NoticeRepository) queryByOutputIndexAndAppContract(
 	}
 }
 
+// StreamByIndex calls fn with each notice of the application whose output
+// index is in [fromIndex, toIndex], in index order, reading them in batches
+func (c *NoticeRepository) StreamByIndex(
+	ctx context.Context,
+	appContract common.Address,
+	fromIndex uint64,
+	toIndex uint64,
+	fn func(*model.ConvenienceNotice) error,
+) error {
+	find := func(fromIndex uint64, limit int) ([]*model.ConvenienceNotice, error) {
+		notices := []*model.ConvenienceNotice{}
+		err := c.readDb(ctx).SelectContext(ctx, &notices, `SELECT * FROM notices
+			WHERE app_contract = $1 AND output_index >= $2 AND output_index <= $3
+			ORDER BY output_index ASC
+			LIMIT $4`,
+			appContract.Hex(), fromIndex, toIndex, limit,
+		)
+		return notices, err
+	}
+	index := func(notice *model.ConvenienceNotice) uint64 { return notice.OutputIndex }
+	return streamByIndex(fromIndex, toIndex, find, index, fn)
+}
+
 func (c *NoticeRepository) FindByInputAndOutputIndex(
 	ctx context.Context, inputIndex uint64, outputIndex uint64,
 ) (*model.ConvenienceNotice, error) {
 	query := `SELECT * FROM notices WHERE input_index = $1 and output_index = $2 LIMIT 1`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +341,7 @@ func (c *NoticeRepository) FindByInputAndOutputIndex(
 }
 
 func transformToNoticeQuery(
+	driver string,
 	filter []*model.ConvenienceFilter,
 ) (string, []interface{}, int, error) {
 	query := ""
@@ -330,6 +374,14 @@ func transformToNoticeQuery(
 			} else {
 				return "", nil, 0, fmt.Errorf("operation not implemented")
 			}
+		} else if *filter.Field == model.PAYLOAD_JSON {
+			jsonWhere, jsonArgs, jsonCount, err := transformToJsonQuery(driver, "payload_json", filter, count)
+			if err != nil {
+				return "", nil, 0, err
+			}
+			where = append(where, jsonWhere...)
+			args = append(args, jsonArgs...)
+			count = jsonCount
 		} else {
 			return "", nil, 0, fmt.Errorf("unexpected field %s", *filter.Field)
 		}
@@ -349,7 +401,7 @@ func (c *NoticeRepository) BatchFindAllNoticesByInputIndexAndAppContract(
 ) ([]*commons.PageResult[model.ConvenienceNotice], []error) {
 	slog.Debug("BatchFindAllNoticesByInputIndexAndAppContract", "len", len(filters))
 
-	query := `SELECT * FROM notices WHERE `
+	query := `SELECT ` + noticeListColumns + ` FROM notices WHERE `
 
 	args := []interface{}{}
 	where := []string{}
@@ -363,12 +415,11 @@ func (c *NoticeRepository) BatchFindAllNoticesByInputIndexAndAppContract(
 
 	query += strings.Join(where, " or ")
 
-	errors := []error{}
 	results := []*commons.PageResult[model.ConvenienceNotice]{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("BatchFind prepare context", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 	defer stmt.Close()
 
@@ -377,7 +428,7 @@ func (c *NoticeRepository) BatchFindAllNoticesByInputIndexAndAppContract(
 	err = stmt.SelectContext(ctx, &notices, args...)
 	if err != nil {
 		slog.Error("BatchFind", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 
 	noticeMap := make(map[string]*commons.PageResult[model.ConvenienceNotice])
@@ -407,3 +458,14 @@ func (c *NoticeRepository) BatchFindAllNoticesByInputIndexAndAppContract(
 func GenerateBatchNoticeKey(appContract string, inputIndex uint64) string {
 	return fmt.Sprintf("%s|%d", appContract, inputIndex)
 }
+
+func (c *NoticeRepository) BatchFindProofsByOutputIndexAndAppContract(
+	ctx context.Context,
+	filters []*BatchFilterItemForProof,
+) ([]*model.ConvenienceProof, []error) {
+	return batchFindProofs(ctx, c.readDb(ctx), "notices", filters)
+}
+
+func (c *NoticeRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, &c.Db, c.ReadDb)
+}
@@ -6,10 +6,11 @@ import (
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/devnet"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/stretchr/testify/suite"
@@ -18,11 +19,13 @@ import (
 type NoticeRepositorySuite struct {
 	suite.Suite
 	repository *NoticeRepository
+	dbFactory  *commons.DbFactory
 }
 
 func (s *NoticeRepositorySuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
-	db := sqlx.MustConnect("sqlite3", ":memory:")
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("notice.sqlite3")
 	s.repository = &NoticeRepository{
 		Db: *db,
 	}
@@ -30,6 +33,10 @@ func (s *NoticeRepositorySuite) SetupTest() {
 	s.NoError(err)
 }
 
+func (s *NoticeRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
 func TestNoticeRepositorySuite(t *testing.T) {
 	suite.Run(t, new(NoticeRepositorySuite))
 }
@@ -179,3 +186,31 @@ func (s *NoticeRepositorySuite) TestBatchFindAll() {
 	s.Equal(4, len(results[0].Rows))
 	s.Equal(4, int(results[0].Total))
 }
+
+func (s *NoticeRepositorySuite) TestNoticePayloadContent() {
+	ctx := context.Background()
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	rawOutput, err := outputsAbi.Pack("Notice", []byte(`{"type":"trade"}`))
+	s.Require().NoError(err)
+	_, err = s.repository.Create(ctx, &model.ConvenienceNotice{
+		OutputIndex: 1,
+		Payload:     hexutil.Encode(rawOutput),
+	})
+	s.Require().NoError(err)
+	_, err = s.repository.Create(ctx, &model.ConvenienceNotice{
+		OutputIndex: 2,
+		Payload:     hexutil.Encode([]byte(`{"type":"cancel"}`)),
+	})
+	s.Require().NoError(err)
+
+	field, path, trade := model.PAYLOAD_JSON, "$.type", "trade"
+	res, err := s.repository.FindAllNotices(ctx, nil, nil, nil, nil, []*model.ConvenienceFilter{
+		{Field: &field, Path: &path, Eq: &trade},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(res.Rows, 1)
+	s.Equal(uint64(1), res.Rows[0].OutputIndex)
+	s.Equal(`{"type":"trade"}`, *res.Rows[0].PayloadText)
+	s.Equal(`{"type":"trade"}`, *res.Rows[0].PayloadJson)
+}
@@ -2,13 +2,18 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
 )
 
 type OutputRepository struct {
-	Db sqlx.DB
+	Db     sqlx.DB
+	ReadDb *sqlx.DB // optional read replica
 }
 
 func (c *OutputRepository) CountProofs(ctx context.Context) (uint64, error) {
@@ -32,7 +37,7 @@ func (c *OutputRepository) CountNoticeProofs(ctx context.Context) (uint64, error
 			output_hashes_siblings is not null 
 			and output_hashes_siblings <> ''
 		`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("query error")
 		return 0, err
@@ -53,7 +58,7 @@ func (c *OutputRepository) CountVoucherProofs(ctx context.Context) (uint64, erro
 			output_hashes_siblings is not null 
 			and output_hashes_siblings <> ''
 		`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("query error")
 		return 0, err
@@ -87,7 +92,7 @@ func (c *OutputRepository) CountAllVouchers(
 	ctx context.Context,
 ) (uint64, error) {
 	query := `SELECT COUNT(*) FROM vouchers`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("query error")
 		return 0, err
@@ -105,7 +110,7 @@ func (c *OutputRepository) CountAllNotices(
 	ctx context.Context,
 ) (uint64, error) {
 	query := `SELECT COUNT(*) FROM notices`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("query error")
 		return 0, err
@@ -118,3 +123,103 @@ func (c *OutputRepository) CountAllNotices(
 	}
 	return countVoucher, nil
 }
+
+// CountOutputs counts the vouchers and notices of the application,
+// or of all of them when nil
+func (c *OutputRepository) CountOutputs(
+	ctx context.Context,
+	appContract *common.Address,
+) (*model.OutputStats, error) {
+	where, args := "", []any{}
+	if appContract != nil {
+		where, args = "WHERE app_contract = $1", []any{appContract.Hex()}
+	}
+	query := fmt.Sprintf(`SELECT
+		(SELECT COUNT(*) FROM vouchers %[1]s) AS vouchers,
+		(SELECT COUNT(*) FROM vouchers %[1]s %[2]s executed = true) AS executed_vouchers,
+		(SELECT COUNT(*) FROM notices %[1]s) AS notices`, where, andOrWhere(where))
+	var stats model.OutputStats
+	err := c.readDb(ctx).GetContext(ctx, &stats, query, args...)
+	if err != nil {
+		slog.Error("query error", "err", err)
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// andOrWhere continues the where clause, or starts one when it is empty
+func andOrWhere(where string) string {
+	if where == "" {
+		return WHERE
+	}
+	return "AND "
+}
+
+type BatchFilterItemForProof struct {
+	AppContract string
+	OutputIndex int
+}
+
+// GenerateBatchProofKey expects the checksummed app contract, as stored
+func GenerateBatchProofKey(appContract string, outputIndex uint64) string {
+	return fmt.Sprintf("%s|%d", appContract, outputIndex)
+}
+
+// batchFindProofs loads the proofs of many outputs of the given table at once.
+// The result is aligned with the filters; a nil entry means output not found.
+func batchFindProofs(
+	ctx context.Context,
+	db *sqlx.DB,
+	table string,
+	filters []*BatchFilterItemForProof,
+) ([]*model.ConvenienceProof, []error) {
+	slog.Debug("BatchFindProofs", "table", table, "len", len(filters))
+	results := make([]*model.ConvenienceProof, len(filters))
+	if len(filters) == 0 {
+		return results, nil
+	}
+	query := fmt.Sprintf(`SELECT
+			app_contract, output_index,
+			COALESCE(output_hashes_siblings, '') as output_hashes_siblings,
+			COALESCE(proof_output_index, 0) as proof_output_index
+		FROM %s WHERE `, table)
+
+	args := []interface{}{}
+	where := []string{}
+	for i, filter := range filters {
+		// nolint
+		where = append(where, fmt.Sprintf(" (app_contract = $%d and output_index = $%d) ", i*2+1, i*2+2))
+		args = append(args, common.HexToAddress(filter.AppContract).Hex())
+		args = append(args, filter.OutputIndex)
+	}
+	query += strings.Join(where, " or ")
+
+	stmt, err := db.PreparexContext(ctx, query)
+	if err != nil {
+		slog.Error("BatchFindProofs prepare context", "error", err)
+		return nil, []error{err}
+	}
+	defer stmt.Close()
+
+	var proofs []model.ConvenienceProof
+	err = stmt.SelectContext(ctx, &proofs, args...)
+	if err != nil {
+		slog.Error("BatchFindProofs", "error", err)
+		return nil, []error{err}
+	}
+
+	proofMap := make(map[string]*model.ConvenienceProof)
+	for i := range proofs {
+		key := GenerateBatchProofKey(common.HexToAddress(proofs[i].AppContract).Hex(), proofs[i].OutputIndex)
+		proofMap[key] = &proofs[i]
+	}
+	for i, filter := range filters {
+		key := GenerateBatchProofKey(common.HexToAddress(filter.AppContract).Hex(), uint64(filter.OutputIndex))
+		results[i] = proofMap[key]
+	}
+	return results, nil
+}
+
+func (c *OutputRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, &c.Db, c.ReadDb)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// PendingExecutionRepository keeps the voucher executions read from
+// the L1 before the vouchers were synced, one row per voucher
+type PendingExecutionRepository struct {
+	Db sqlx.DB
+}
+
+func (r *PendingExecutionRepository) CreateTables() error {
+	schema := `CREATE TABLE IF NOT EXISTS pending_executions (
+		app_contract 		text NOT NULL,
+		output_index 		bigint NOT NULL,
+		transaction_hash 	text NOT NULL,
+		block_number 		bigint NOT NULL,
+		PRIMARY KEY (app_contract, output_index)
+	);`
+
+	_, err := r.Db.Exec(schema)
+	if err != nil {
+		slog.Error("Failed to create tables", "error", err)
+		return err
+	}
+	slog.Debug("Pending executions table created successfully")
+	return nil
+}
+
+// Save replaces the pending execution of the same voucher, if any
+func (r *PendingExecutionRepository) Save(ctx context.Context, execution model.PendingExecution) error {
+	exec := DBExecutor{&r.Db}
+	_, err := exec.ExecContext(ctx, `INSERT INTO pending_executions
+		(app_contract, output_index, transaction_hash, block_number)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (app_contract, output_index) DO UPDATE SET
+		transaction_hash = excluded.transaction_hash,
+		block_number = excluded.block_number`,
+		execution.AppContract,
+		execution.OutputIndex,
+		execution.TransactionHash,
+		execution.BlockNumber,
+	)
+	return err
+}
+
+// FindAll returns the pending executions in the order they happened
+func (r *PendingExecutionRepository) FindAll(ctx context.Context) ([]model.PendingExecution, error) {
+	executions := []model.PendingExecution{}
+	err := r.Db.SelectContext(ctx, &executions, `SELECT
+		app_contract, output_index, transaction_hash, block_number
+		FROM pending_executions ORDER BY block_number, app_contract, output_index`)
+	if err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// Delete removes the pending execution of the voucher when it
+// comes from the transaction, or from any transaction when it is empty
+func (r *PendingExecutionRepository) Delete(
+	ctx context.Context,
+	appContract string,
+	outputIndex uint64,
+	transactionHash string,
+) error {
+	exec := DBExecutor{&r.Db}
+	_, err := exec.ExecContext(ctx, `DELETE FROM pending_executions
+		WHERE app_contract = $1 AND output_index = $2
+		AND ($3 = '' OR transaction_hash = $3)`,
+		appContract, outputIndex, transactionHash,
+	)
+	return err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type PendingExecutionRepositorySuite struct {
+	suite.Suite
+	repository *PendingExecutionRepository
+	dbFactory  *commons.DbFactory
+}
+
+func (s *PendingExecutionRepositorySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("pending_execution.sqlite3")
+	s.repository = &PendingExecutionRepository{
+		Db: *db,
+	}
+	err := s.repository.CreateTables()
+	s.NoError(err)
+}
+
+func (s *PendingExecutionRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestPendingExecutionRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PendingExecutionRepositorySuite))
+}
+
+func (s *PendingExecutionRepositorySuite) TestSaveFindAndDelete() {
+	ctx := context.Background()
+	app := "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+	s.Require().NoError(s.repository.Save(ctx, model.PendingExecution{
+		AppContract: app, OutputIndex: 2, TransactionHash: "0x02", BlockNumber: 20,
+	}))
+	s.Require().NoError(s.repository.Save(ctx, model.PendingExecution{
+		AppContract: app, OutputIndex: 1, TransactionHash: "0x01", BlockNumber: 10,
+	}))
+	// the same voucher again replaces it
+	s.Require().NoError(s.repository.Save(ctx, model.PendingExecution{
+		AppContract: app, OutputIndex: 2, TransactionHash: "0x03", BlockNumber: 30,
+	}))
+
+	executions, err := s.repository.FindAll(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(executions, 2)
+	s.Equal(uint64(1), executions[0].OutputIndex)
+	s.Equal("0x03", executions[1].TransactionHash)
+
+	// another transaction does not delete it
+	s.Require().NoError(s.repository.Delete(ctx, app, 2, "0x02"))
+	s.Require().NoError(s.repository.Delete(ctx, app, 1, ""))
+	executions, err = s.repository.FindAll(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(executions, 1)
+	s.Equal(uint64(2), executions[0].OutputIndex)
+}
@@ -16,6 +16,7 @@ import (
 
 type ReportRepository struct {
 	Db        *sqlx.DB
+	ReadDb    *sqlx.DB // optional read replica
 	AutoCount bool
 }
 
@@ -33,6 +34,12 @@ func (r *ReportRepository) CreateTables() error {
 	CREATE INDEX IF NOT EXISTS idx_input_index_app_contract ON convenience_reports(input_index, app_contract);
 	CREATE INDEX IF NOT EXISTS idx_output_index_app_contract ON convenience_reports(output_index, app_contract);`
 	_, err := r.Db.Exec(schema)
+	if err == nil {
+		err = addColumns(r.Db, "convenience_reports",
+			"payload_text text",
+			"payload_json "+jsonColumnType(r.Db),
+		)
+	}
 	if err == nil {
 		slog.Debug("Reports table created")
 	} else {
@@ -56,7 +63,9 @@ func (r *ReportRepository) CreateReport(ctx context.Context, report cModel.Repor
 		payload,
 		input_index,
 		app_contract,
-		raw_id) VALUES ($1, $2, $3, $4, $5)`
+		raw_id,
+		payload_text,
+		payload_json) VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
 	var hexPayload string
 	if !strings.HasPrefix(report.Payload, "0x") {
@@ -64,6 +73,7 @@ func (r *ReportRepository) CreateReport(ctx context.Context, report cModel.Repor
 	} else {
 		hexPayload = report.Payload
 	}
+	report.PayloadText, report.PayloadJson = cModel.PayloadContent(hexPayload)
 
 	exec := DBExecutor{r.Db}
 	_, err := exec.ExecContext(
@@ -74,6 +84,8 @@ func (r *ReportRepository) CreateReport(ctx context.Context, report cModel.Repor
 		report.InputIndex,
 		report.AppContract.Hex(),
 		report.RawID,
+		report.PayloadText,
+		report.PayloadJson,
 	)
 
 	if err != nil {
@@ -89,14 +101,17 @@ func (r *ReportRepository) CreateReport(ctx context.Context, report cModel.Repor
 
 func (r *ReportRepository) Update(ctx context.Context, report cModel.Report) (*cModel.Report, error) {
 	sql := `UPDATE convenience_reports
-		SET payload = $1
-		WHERE input_index = $2 and output_index = $3 `
+		SET payload = $1, payload_text = $2, payload_json = $3
+		WHERE input_index = $4 and output_index = $5 `
 
+	report.PayloadText, report.PayloadJson = cModel.PayloadContent(report.Payload)
 	exec := DBExecutor{r.Db}
 	_, err := exec.ExecContext(
 		ctx,
 		sql,
 		report.Payload,
+		report.PayloadText,
+		report.PayloadJson,
 		report.InputIndex,
 		report.Index,
 	)
@@ -112,16 +127,16 @@ func (r *ReportRepository) queryByOutputIndexAndAppContract(
 	appContract *common.Address,
 ) (*sqlx.Rows, error) {
 	if appContract != nil {
-		return r.Db.QueryxContext(ctx, `
-			SELECT payload, input_index FROM convenience_reports
+		return r.readDb(ctx).QueryxContext(ctx, `
+			SELECT payload, input_index, app_contract, payload_text, payload_json FROM convenience_reports
 			WHERE output_index = $1 and app_contract = $2
 			LIMIT 1`,
 			outputIndex,
 			appContract.Hex(),
 		)
 	} else {
-		return r.Db.QueryxContext(ctx, `
-			SELECT payload, input_index FROM convenience_reports
+		return r.readDb(ctx).QueryxContext(ctx, `
+			SELECT payload, input_index, app_contract, payload_text, payload_json FROM convenience_reports
 			WHERE output_index = $1
 			LIMIT 1`,
 			outputIndex,
@@ -131,7 +146,7 @@ func (r *ReportRepository) queryByOutputIndexAndAppContract(
 
 func (r *ReportRepository) FindLastRawId(ctx context.Context) (uint64, error) {
 	var outputId uint64
-	err := r.Db.GetContext(ctx, &outputId, `SELECT raw_id FROM convenience_reports ORDER BY raw_id DESC LIMIT 1`)
+	err := r.readDb(ctx).GetContext(ctx, &outputId, `SELECT raw_id FROM convenience_reports ORDER BY raw_id DESC LIMIT 1`)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -157,13 +172,18 @@ func (r *ReportRepository) FindByOutputIndexAndAppContract(
 	if rows.Next() {
 		var payload string
 		var inputIndex int
-		if err := rows.Scan(&payload, &inputIndex); err != nil {
+		var appContract string
+		var payloadText, payloadJson *string
+		if err := rows.Scan(&payload, &inputIndex, &appContract, &payloadText, &payloadJson); err != nil {
 			return nil, err
 		}
 		report := &cModel.Report{
-			InputIndex: inputIndex,
-			Index:      int(outputIndex),
-			Payload:    payload,
+			InputIndex:  inputIndex,
+			Index:       int(outputIndex),
+			Payload:     payload,
+			AppContract: common.HexToAddress(appContract),
+			PayloadText: payloadText,
+			PayloadJson: payloadJson,
 		}
 		return report, nil
 	}
@@ -180,8 +200,8 @@ func (r *ReportRepository) FindByInputAndOutputIndex(
 	inputIndex uint64,
 	outputIndex uint64,
 ) (*cModel.Report, error) {
-	rows, err := r.Db.QueryxContext(ctx, `
-		SELECT payload FROM convenience_reports
+	rows, err := r.readDb(ctx).QueryxContext(ctx, `
+		SELECT payload, payload_text, payload_json FROM convenience_reports
 		WHERE input_index = $1 AND output_index = $2
 		LIMIT 1`,
 		inputIndex, outputIndex,
@@ -194,13 +214,16 @@ func (r *ReportRepository) FindByInputAndOutputIndex(
 
 	if rows.Next() {
 		var payload string
-		if err := rows.Scan(&payload); err != nil {
+		var payloadText, payloadJson *string
+		if err := rows.Scan(&payload, &payloadText, &payloadJson); err != nil {
 			return nil, err
 		}
 		report := &cModel.Report{
-			InputIndex: int(inputIndex),
-			Index:      int(outputIndex),
-			Payload:    payload,
+			InputIndex:  int(inputIndex),
+			Index:       int(outputIndex),
+			Payload:     payload,
+			PayloadText: payloadText,
+			PayloadJson: payloadJson,
 		}
 		return report, nil
 	}
@@ -218,9 +241,11 @@ func (r *ReportRepository) FindReportByAppContractAndIndex(ctx context.Context,
 		input_index, 
 		output_index, 
 		payload, 
-		app_contract FROM convenience_reports WHERE input_index = $1 AND app_contract = $2`
+		app_contract,
+		payload_text,
+		payload_json FROM convenience_reports WHERE input_index = $1 AND app_contract = $2`
 
-	res, err := r.Db.QueryxContext(
+	res, err := r.readDb(ctx).QueryxContext(
 		ctx,
 		query,
 		uint64(index),
@@ -247,21 +272,21 @@ func (c *ReportRepository) Count(
 	filter []*cModel.ConvenienceFilter,
 ) (uint64, error) {
 	query := `SELECT count(*) FROM convenience_reports `
-	where, args, _, err := transformToReportQuery(filter)
+	where, args, _, err := transformToReportQuery(c.Db.DriverName(), filter)
 	if err != nil {
 		slog.Error("Count execution error")
 		return 0, err
 	}
 	query += where
-	slog.Debug("Query", "query", query, "args", args)
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("Count execution error")
 		return 0, err
 	}
 	defer stmt.Close()
 	var count uint64
-	err = stmt.Get(&count, args...)
+	err = stmt.GetContext(ctx, &count, args...)
 	if err != nil {
 		slog.Error("Count execution error")
 		return 0, err
@@ -310,8 +335,8 @@ func (c *ReportRepository) FindAll(
 		return nil, err
 	}
 
-	query := `SELECT input_index, output_index, payload FROM convenience_reports `
-	where, args, argsCount, err := transformToReportQuery(filter)
+	query := `SELECT input_index, output_index, payload, app_contract, payload_text, payload_json FROM convenience_reports `
+	where, args, argsCount, err := transformToReportQuery(c.Db.DriverName(), filter)
 	if err != nil {
 		slog.Error("database error", "err", err)
 		return nil, err
@@ -329,8 +354,8 @@ func (c *ReportRepository) FindAll(
 	query += fmt.Sprintf(`OFFSET $%d `, argsCount)
 	args = append(args, offset)
 
-	slog.Debug("Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -344,17 +369,10 @@ func (c *ReportRepository) FindAll(
 	defer rows.Close()
 
 	for rows.Next() {
-		var payload string
-		var inputIndex int
-		var outputIndex int
-		if err := rows.Scan(&inputIndex, &outputIndex, &payload); err != nil {
+		report, err := parseReport(rows)
+		if err != nil {
 			return nil, err
 		}
-		report := &cModel.Report{
-			InputIndex: inputIndex,
-			Index:      outputIndex,
-			Payload:    payload,
-		}
 		reports = append(reports, *report)
 	}
 
@@ -371,6 +389,7 @@ func (c *ReportRepository) FindAll(
 }
 
 func transformToReportQuery(
+	driver string,
 	filter []*cModel.ConvenienceFilter,
 ) (string, []interface{}, int, error) {
 	query := ""
@@ -405,6 +424,14 @@ func transformToReportQuery(
 			} else {
 				return "", nil, 0, fmt.Errorf("operation not implemented")
 			}
+		} else if *filter.Field == cModel.PAYLOAD_JSON {
+			jsonWhere, jsonArgs, jsonCount, err := transformToJsonQuery(driver, "payload_json", filter, count)
+			if err != nil {
+				return "", nil, 0, err
+			}
+			where = append(where, jsonWhere...)
+			args = append(args, jsonArgs...)
+			count = jsonCount
 		} else {
 			return "", nil, 0, fmt.Errorf("unexpected field %s", *filter.Field)
 		}
@@ -418,13 +445,49 @@ type BatchFilterItem struct {
 	InputIndex  int
 }
 
+// StreamByIndex calls fn with each report of the application whose index
+// is in [fromIndex, toIndex], in index order, reading them in batches
+func (c *ReportRepository) StreamByIndex(
+	ctx context.Context,
+	appContract common.Address,
+	fromIndex uint64,
+	toIndex uint64,
+	fn func(*cModel.Report) error,
+) error {
+	find := func(fromIndex uint64, limit int) ([]*cModel.Report, error) {
+		rows, err := c.readDb(ctx).QueryxContext(ctx, `SELECT
+				input_index, output_index, payload, app_contract, payload_text, payload_json
+			FROM convenience_reports
+			WHERE app_contract = $1 AND output_index >= $2 AND output_index <= $3
+			ORDER BY output_index ASC
+			LIMIT $4`,
+			appContract.Hex(), fromIndex, toIndex, limit,
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		reports := []*cModel.Report{}
+		for rows.Next() {
+			report, err := parseReport(rows)
+			if err != nil {
+				return nil, err
+			}
+			reports = append(reports, report)
+		}
+		return reports, rows.Err()
+	}
+	index := func(report *cModel.Report) uint64 { return uint64(report.Index) }
+	return streamByIndex(fromIndex, toIndex, find, index, fn)
+}
+
 func (c *ReportRepository) BatchFindAllByInputIndexAndAppContract(
 	ctx context.Context,
 	filters []*BatchFilterItem,
 ) ([]*commons.PageResult[cModel.Report], []error) {
 	slog.Debug("BatchFindAllByInputIndexAndAppContract", "len", len(filters))
 	query := `SELECT 
-				input_index, output_index, payload, app_contract FROM convenience_reports
+					input_index, output_index, payload, app_contract, payload_text, payload_json FROM convenience_reports
 		WHERE
 	`
 	args := []interface{}{}
@@ -437,12 +500,11 @@ func (c *ReportRepository) BatchFindAllByInputIndexAndAppContract(
 	}
 	query += strings.Join(where, " or ")
 
-	errors := []error{}
 	results := []*commons.PageResult[cModel.Report]{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("BatchFind prepare context", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 	defer stmt.Close()
 
@@ -450,29 +512,20 @@ func (c *ReportRepository) BatchFindAllByInputIndexAndAppContract(
 	rows, err := stmt.QueryxContext(ctx, args...)
 	if err != nil {
 		slog.Error("BatchFind query context", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var payload string
-		var inputIndex int
-		var outputIndex int
-		var appContract string
-		if err := rows.Scan(&inputIndex, &outputIndex, &payload, &appContract); err != nil {
-			return nil, errors
-		}
-		report := &cModel.Report{
-			InputIndex:  inputIndex,
-			Index:       outputIndex,
-			Payload:     payload,
-			AppContract: common.HexToAddress(appContract),
+		report, err := parseReport(rows)
+		if err != nil {
+			return nil, []error{err}
 		}
 		reports = append(reports, *report)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, errors
+		return nil, []error{err}
 	}
 	reportMap := make(map[string]*commons.PageResult[cModel.Report])
 	for _, report := range reports {
@@ -510,6 +563,8 @@ func parseReport(res *sqlx.Rows) (*cModel.Report, error) {
 		&report.Index,
 		&payload,
 		&appContract,
+		&report.PayloadText,
+		&report.PayloadJson,
 	)
 	if err != nil {
 		return nil, err
@@ -519,3 +574,7 @@ func parseReport(res *sqlx.Rows) (*cModel.Report, error) {
 	report.AppContract = common.HexToAddress(appContract)
 	return &report, nil
 }
+
+func (r *ReportRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, r.Db, r.ReadDb)
+}
@@ -9,7 +9,6 @@ import (
 	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/devnet"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/stretchr/testify/suite"
@@ -22,11 +21,13 @@ import (
 type ReportRepositorySuite struct {
 	suite.Suite
 	reportRepository *ReportRepository
+	dbFactory        *commons.DbFactory
 }
 
 func (s *ReportRepositorySuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
-	db := sqlx.MustConnect("sqlite3", ":memory:")
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("report.sqlite3")
 	s.reportRepository = &ReportRepository{
 		Db: db,
 	}
@@ -34,6 +35,10 @@ func (s *ReportRepositorySuite) SetupTest() {
 	s.NoError(err)
 }
 
+func (s *ReportRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
 func TestReportRepositorySuite(t *testing.T) {
 	suite.Run(t, new(ReportRepositorySuite))
 }
@@ -177,3 +182,96 @@ func (r *ReportRepositorySuite) TestFindReportByAppContractAndIndex() {
 	r.Equal(3333, report.Index)
 
 }
+
+func (s *ReportRepositorySuite) jsonFilter(path string, filter cModel.ConvenienceFilter) []*cModel.ConvenienceFilter {
+	field := cModel.PAYLOAD_JSON
+	filter.Field = &field
+	filter.Path = &path
+	return []*cModel.ConvenienceFilter{&filter}
+}
+
+func (s *ReportRepositorySuite) TestPayloadContent() {
+	ctx := context.Background()
+	payloads := []string{
+		`{"type": "trade", "amount": 10, "items": [{"id": "a"}], "final": true}`,
+		`{"type": "trade", "amount": 9}`,
+		`{"type": "cancel", "amount": "x"}`,
+		"hello",
+	}
+	for i, payload := range payloads {
+		_, err := s.reportRepository.CreateReport(ctx, cModel.Report{
+			Index:   i,
+			Payload: common.Bytes2Hex([]byte(payload)),
+		})
+		s.Require().NoError(err)
+	}
+	_, err := s.reportRepository.CreateReport(ctx, cModel.Report{Index: 4, Payload: "0x00ff"})
+	s.Require().NoError(err)
+
+	res, err := s.reportRepository.FindAll(ctx, nil, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Len(res.Rows, 5)
+	s.Equal(`{"type":"trade","amount":9}`, *res.Rows[1].PayloadJson)
+	s.Equal(`{"type": "trade", "amount": 9}`, *res.Rows[1].PayloadText)
+	s.Nil(res.Rows[3].PayloadJson)
+	s.Equal("hello", *res.Rows[3].PayloadText)
+	s.Nil(res.Rows[4].PayloadText)
+	s.Nil(res.Rows[4].PayloadJson)
+
+	trade, ten, nine, a, yes := "trade", "10", "9", "a", "true"
+	cases := []struct {
+		path    string
+		filter  cModel.ConvenienceFilter
+		indexes []int
+	}{
+		{"$.type", cModel.ConvenienceFilter{Eq: &trade}, []int{0, 1}},
+		{"$.type", cModel.ConvenienceFilter{Ne: &trade}, []int{2}},
+		{"$.amount", cModel.ConvenienceFilter{Gte: &nine, Lt: &ten}, []int{1}},
+		{"$.amount", cModel.ConvenienceFilter{Gt: &nine}, []int{0}},
+		{"$.amount", cModel.ConvenienceFilter{In: []*string{&ten, &nine}}, []int{0, 1}},
+		{"$.items[0].id", cModel.ConvenienceFilter{Eq: &a}, []int{0}},
+		{"$.final", cModel.ConvenienceFilter{Eq: &yes}, []int{0}},
+	}
+	for _, c := range cases {
+		res, err := s.reportRepository.FindAll(ctx, nil, nil, nil, nil, s.jsonFilter(c.path, c.filter))
+		s.Require().NoError(err, c.path)
+		indexes := []int{}
+		for _, report := range res.Rows {
+			indexes = append(indexes, report.Index)
+		}
+		s.Equal(c.indexes, indexes, c.path)
+		s.Equal(uint64(len(c.indexes)), res.Total, c.path)
+	}
+
+	_, err = s.reportRepository.FindAll(ctx, nil, nil, nil, nil,
+		s.jsonFilter("type", cModel.ConvenienceFilter{Eq: &trade}))
+	s.ErrorIs(err, ErrInvalidFilter)
+	_, err = s.reportRepository.FindAll(ctx, nil, nil, nil, nil,
+		s.jsonFilter("$.amount", cModel.ConvenienceFilter{Gt: &trade}))
+	s.ErrorIs(err, ErrInvalidFilter)
+}
+
+// TestPayloadWithNullEscape runs against the jsonb column with
+// TEST_DB_DRIVER=postgres, which rejects the \u0000 escape
+func (s *ReportRepositorySuite) TestPayloadWithNullEscape() {
+	ctx := context.Background()
+	payloads := []string{
+		`{"name": "a\u0000b"}`,
+		`{"name": "a\\u0000b"}`,
+	}
+	for i, payload := range payloads {
+		_, err := s.reportRepository.CreateReport(ctx, cModel.Report{
+			Index:   i,
+			Payload: common.Bytes2Hex([]byte(payload)),
+		})
+		s.Require().NoError(err)
+	}
+
+	res, err := s.reportRepository.FindAll(ctx, nil, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Len(res.Rows, 2)
+	s.Equal(payloads[0], *res.Rows[0].PayloadText)
+	s.Nil(res.Rows[0].PayloadJson)
+	// an escaped backslash followed by u0000
+	s.Equal(`{"name":"a\\u0000b"}`, *res.Rows[1].PayloadJson)
+}
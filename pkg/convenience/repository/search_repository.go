@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchRepository keeps the full-text index of the report and notice
+// payloads. It is a tsvector on Postgres and a FTS5 table on SQLite.
+type SearchRepository struct {
+	Db     *sqlx.DB
+	ReadDb *sqlx.DB // optional read replica
+}
+
+func (r *SearchRepository) CreateTables() error {
+	schema := `CREATE VIRTUAL TABLE IF NOT EXISTS convenience_search USING fts5 (
+		kind UNINDEXED,
+		app_contract UNINDEXED,
+		input_index UNINDEXED,
+		output_index UNINDEXED,
+		content
+	);`
+	if r.Db.DriverName() == "postgres" {
+		schema = `CREATE TABLE IF NOT EXISTS convenience_search (
+		kind 			text NOT NULL,
+		app_contract 	text NOT NULL,
+		input_index 	integer NOT NULL,
+		output_index 	integer NOT NULL,
+		content 		text NOT NULL,
+		document 		tsvector NOT NULL,
+		PRIMARY KEY (kind, app_contract, input_index, output_index));
+
+		CREATE INDEX IF NOT EXISTS idx_convenience_search_document ON convenience_search USING GIN (document);`
+	}
+	_, err := r.Db.Exec(schema)
+	if err != nil {
+		slog.Error("Failed to create tables", "error", err)
+		return err
+	}
+	slog.Debug("Search table created successfully")
+	return nil
+}
+
+// Index adds the text of the report or notice to the search index
+func (r *SearchRepository) Index(ctx context.Context, doc model.SearchDocument) error {
+	insertSql := `INSERT INTO convenience_search (kind, app_contract, input_index, output_index, content)
+		VALUES ($1, $2, $3, $4, $5)`
+	if r.Db.DriverName() == "postgres" {
+		insertSql = `INSERT INTO convenience_search (kind, app_contract, input_index, output_index, content, document)
+		VALUES ($1, $2, $3, $4, $5, to_tsvector('simple', $5))`
+	}
+	exec := DBExecutor{r.Db}
+	_, err := exec.ExecContext(ctx, insertSql,
+		doc.Kind,
+		common.HexToAddress(doc.AppContract).Hex(),
+		doc.InputIndex,
+		doc.OutputIndex,
+		doc.Content,
+	)
+	return err
+}
+
+func (r *SearchRepository) Count(
+	ctx context.Context,
+	appContract *common.Address,
+	text string,
+) (uint64, error) {
+	where, args, err := r.searchWhere(appContract, text)
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	err = r.readDb(ctx).GetContext(ctx, &count, `SELECT count(*) FROM convenience_search `+where, args...)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Search finds the documents with all the words of the text,
+// in the order their inputs were processed
+func (r *SearchRepository) Search(
+	ctx context.Context,
+	appContract *common.Address,
+	text string,
+	first *int,
+	after *string,
+) (*commons.PageResult[model.SearchDocument], error) {
+	total, err := r.Count(ctx, appContract, text)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := commons.ComputePage(first, nil, after, nil, int(total))
+	if err != nil {
+		return nil, err
+	}
+	where, args, err := r.searchWhere(appContract, text)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT kind, app_contract, input_index, output_index, content FROM convenience_search ` + where +
+		`ORDER BY input_index ASC, kind ASC, output_index ASC ` +
+		fmt.Sprintf(`LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2) // nolint
+	args = append(args, limit, offset)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+
+	docs := []model.SearchDocument{}
+	err = r.readDb(ctx).SelectContext(ctx, &docs, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &commons.PageResult[model.SearchDocument]{
+		Rows:   docs,
+		Total:  total,
+		Offset: uint64(offset),
+	}, nil
+}
+
+func (r *SearchRepository) searchWhere(appContract *common.Address, text string) (string, []any, error) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return "", nil, fmt.Errorf("%w: search text is empty", ErrInvalidFilter)
+	}
+	where := "WHERE convenience_search MATCH $1 "
+	args := []any{ftsQuery(words)}
+	if r.Db.DriverName() == "postgres" {
+		where = "WHERE document @@ plainto_tsquery('simple', $1) "
+		args = []any{text}
+	}
+	if appContract != nil {
+		where += "AND app_contract = $2 "
+		args = append(args, appContract.Hex())
+	}
+	return where, args, nil
+}
+
+// ftsQuery quotes the words so FTS5 does not read them as its query syntax
+func ftsQuery(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+func (r *SearchRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, r.Db, r.ReadDb)
+}
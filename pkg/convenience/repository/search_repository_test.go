@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchRepositorySuite struct {
+	suite.Suite
+	repository *SearchRepository
+	dbFactory  *commons.DbFactory
+}
+
+func (s *SearchRepositorySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("search.sqlite3")
+	s.repository = &SearchRepository{
+		Db: db,
+	}
+	err := s.repository.CreateTables()
+	s.NoError(err)
+}
+
+func (s *SearchRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestSearchRepositorySuite(t *testing.T) {
+	suite.Run(t, new(SearchRepositorySuite))
+}
+
+func (s *SearchRepositorySuite) TestSearch() {
+	ctx := context.Background()
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	other := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	docs := []model.SearchDocument{
+		{Kind: model.SEARCH_REPORT, AppContract: app.Hex(), InputIndex: 2, OutputIndex: 0, Content: "error: balance too low"},
+		{Kind: model.SEARCH_NOTICE, AppContract: app.Hex(), InputIndex: 1, OutputIndex: 0, Content: `{"balance": 10}`},
+		{Kind: model.SEARCH_REPORT, AppContract: app.Hex(), InputIndex: 3, OutputIndex: 1, Content: "deposit accepted"},
+		{Kind: model.SEARCH_REPORT, AppContract: other.Hex(), InputIndex: 0, OutputIndex: 0, Content: "Balance updated"},
+	}
+	for _, doc := range docs {
+		s.Require().NoError(s.repository.Index(ctx, doc))
+	}
+
+	res, err := s.repository.Search(ctx, nil, "balance", nil, nil)
+	s.Require().NoError(err)
+	s.Equal(3, int(res.Total))
+	s.Equal(other.Hex(), res.Rows[0].AppContract)
+	s.Equal(model.SEARCH_NOTICE, res.Rows[1].Kind)
+	s.Equal(uint64(2), res.Rows[2].InputIndex)
+
+	// all the words must match
+	res, err = s.repository.Search(ctx, &app, "balance low", nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(1, int(res.Total))
+	s.Equal("error: balance too low", res.Rows[0].Content)
+
+	// pagination
+	first := 1
+	res, err = s.repository.Search(ctx, &app, "balance", &first, nil)
+	s.Require().NoError(err)
+	s.Equal(2, int(res.Total))
+	s.Require().Len(res.Rows, 1)
+	s.Equal(uint64(1), res.Rows[0].InputIndex)
+	after := commons.EncodeCursor(0)
+	res, err = s.repository.Search(ctx, &app, "balance", &first, &after)
+	s.Require().NoError(err)
+	s.Require().Len(res.Rows, 1)
+	s.Equal(uint64(2), res.Rows[0].InputIndex)
+}
+
+func (s *SearchRepositorySuite) TestQuerySyntax() {
+	ctx := context.Background()
+	for i, content := range []string{`failed "AND" OR NOT`, `100% done`} {
+		err := s.repository.Index(ctx, model.SearchDocument{
+			Kind: model.SEARCH_REPORT, InputIndex: uint64(i), Content: content,
+		})
+		s.Require().NoError(err)
+	}
+	// the operators are plain words
+	res, err := s.repository.Search(ctx, nil, `"AND" NOT*`, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(1, int(res.Total))
+	s.Equal(`failed "AND" OR NOT`, res.Rows[0].Content)
+
+	res, err = s.repository.Search(ctx, nil, "100%", nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(1, int(res.Total))
+	s.Equal(`100% done`, res.Rows[0].Content)
+
+	_, err = s.repository.Search(ctx, nil, "  ", nil, nil)
+	s.ErrorIs(err, ErrInvalidFilter)
+}
+
+func (s *SearchRepositorySuite) TestFts5Table() {
+	if s.repository.Db.DriverName() == "postgres" {
+		s.T().Skip("the FTS5 table is the SQLite index")
+	}
+	var sql string
+	err := s.repository.Db.Get(&sql, `SELECT sql FROM sqlite_master WHERE name = 'convenience_search'`)
+	s.Require().NoError(err)
+	s.Contains(sql, "fts5")
+
+	// FTS5 ignores the case of the non-ASCII letters too
+	ctx := context.Background()
+	err = s.repository.Index(ctx, model.SearchDocument{Kind: model.SEARCH_REPORT, Content: "Ação concluída"})
+	s.Require().NoError(err)
+	res, err := s.repository.Search(ctx, nil, "AÇÃO", nil, nil)
+	s.Require().NoError(err)
+	s.Equal(1, int(res.Total))
+}
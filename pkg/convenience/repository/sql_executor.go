@@ -23,3 +23,12 @@ func (c *DBExecutor) ExecContext(ctx context.Context, query string, args ...any)
 		return tx.ExecContext(ctx, query, args...)
 	}
 }
+
+func (c *DBExecutor) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx, isTxEnable := GetTransaction(ctx)
+
+	if !isTxEnable {
+		return c.db.GetContext(ctx, dest, query, args...)
+	}
+	return tx.GetContext(ctx, dest, query, args...)
+}
@@ -0,0 +1,37 @@
+package repository
+
+// STREAM_BATCH_SIZE is how many rows StreamByIndex reads at a time. The rows
+// of a batch are closed before it is handed over, so a slow reader does not
+// keep a query open for the whole stream.
+const STREAM_BATCH_SIZE = 500
+
+// streamByIndex calls fn with each entry whose index is in [fromIndex, toIndex],
+// in index order. It reads them in batches with find, each one starting after
+// the last index of the previous batch.
+func streamByIndex[T any](
+	fromIndex uint64,
+	toIndex uint64,
+	find func(fromIndex uint64, limit int) ([]T, error),
+	index func(T) uint64,
+	fn func(T) error,
+) error {
+	for {
+		batch, err := find(fromIndex, STREAM_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+		for _, entry := range batch {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		if len(batch) < STREAM_BATCH_SIZE {
+			return nil
+		}
+		last := index(batch[len(batch)-1])
+		if last >= toIndex {
+			return nil
+		}
+		fromIndex = last + 1
+	}
+}
@@ -82,7 +82,7 @@ func (c *SynchronizerRepository) Count(
 	ctx context.Context,
 ) (uint64, error) {
 	var count int
-	err := c.Db.Get(&count, "SELECT count(*) FROM synchronizer_fetch")
+	err := c.Db.GetContext(ctx, &count, "SELECT count(*) FROM synchronizer_fetch")
 	if err != nil {
 		return 0, err
 	}
@@ -93,14 +93,14 @@ func (c *SynchronizerRepository) GetLastFetched(
 	ctx context.Context,
 ) (*model.SynchronizerFetch, error) {
 	query := `SELECT * FROM synchronizer_fetch ORDER BY id DESC LIMIT 1`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.Db.PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("Error searching for last fetched", "Error", err)
 		return nil, err
 	}
 	defer stmt.Close()
 	var p model.SynchronizerFetch
-	err = stmt.Get(&p)
+	err = stmt.GetContext(ctx, &p)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
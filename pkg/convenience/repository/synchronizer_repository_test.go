@@ -7,7 +7,6 @@ import (
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
-	"github.com/jmoiron/sqlx"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/stretchr/testify/suite"
@@ -16,11 +15,13 @@ import (
 type SynchronizerRepositorySuite struct {
 	suite.Suite
 	repository *SynchronizerRepository
+	dbFactory  *commons.DbFactory
 }
 
 func (s *SynchronizerRepositorySuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
-	db := sqlx.MustConnect("sqlite3", ":memory:")
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("synchronizer.sqlite3")
 	s.repository = &SynchronizerRepository{
 		Db: *db,
 	}
@@ -31,6 +32,7 @@ func (s *SynchronizerRepositorySuite) SetupTest() {
 func (s *SynchronizerRepositorySuite) TearDownTest() {
 	err := s.repository.Db.Close()
 	s.NoError(err)
+	s.dbFactory.Cleanup()
 }
 
 func TestSynchronizerRepositorySuiteSuite(t *testing.T) {
@@ -21,6 +21,7 @@ type VoucherRepository struct {
 	Db               sqlx.DB
 	OutputRepository OutputRepository
 	AutoCount        bool
+	ReadDb           *sqlx.DB // optional read replica
 }
 
 type voucherRow struct {
@@ -34,8 +35,21 @@ type voucherRow struct {
 	AppContract          string `db:"app_contract"`
 	TransactionHash      string `db:"transaction_hash"`
 	ProofOutputIndex     uint64 `db:"proof_output_index"`
+	ExecutedBlock        uint64 `db:"executed_block"`
+	ExecutedAt           uint64 `db:"executed_at"`
+	ExecutedBy           string `db:"executed_by"`
+	InputIndexUnknown    bool   `db:"input_index_unknown"`
+	ResolveAttempts      uint64 `db:"resolve_attempts"`
+	ResolveAttemptedAt   uint64 `db:"resolve_attempted_at"`
+	ResolveAfter         uint64 `db:"resolve_after"`
 }
 
+// voucherListColumns are the columns of the voucher lists. The proofs
+// are left out, they are loaded in batches by the proof loader.
+const voucherListColumns = `destination, payload, executed, input_index,
+	output_index, value, app_contract, transaction_hash,
+	executed_block, executed_at, executed_by, input_index_unknown`
+
 func (c *VoucherRepository) CreateTables() error {
 	schema := `CREATE TABLE IF NOT EXISTS vouchers (
 		destination            text,
@@ -49,15 +63,32 @@ func (c *VoucherRepository) CreateTables() error {
 		transaction_hash       text DEFAULT '' NOT NULL,
 		proof_output_index     integer DEFAULT 0,
 		PRIMARY KEY (input_index, output_index, app_contract)
-	);
-
+	);`
+	_, err := c.Db.Exec(schema)
+	if err != nil {
+		return err
+	}
+	err = addColumns(&c.Db, "vouchers",
+		"executed_block integer DEFAULT 0 NOT NULL",
+		"executed_at integer DEFAULT 0 NOT NULL",
+		"executed_by text DEFAULT '' NOT NULL",
+		"input_index_unknown boolean DEFAULT false NOT NULL",
+		"resolve_attempts integer DEFAULT 0 NOT NULL",
+		"resolve_attempted_at integer DEFAULT 0 NOT NULL",
+		"resolve_after integer DEFAULT 0 NOT NULL",
+	)
+	if err != nil {
+		return err
+	}
+	indexes := `
 	CREATE INDEX IF NOT EXISTS idx_input_index_output_index ON vouchers(input_index, output_index);
 	CREATE INDEX IF NOT EXISTS idx_app_contract_output_index ON vouchers(app_contract, output_index);
 	CREATE INDEX IF NOT EXISTS idx_app_contract_input_index ON vouchers(app_contract, input_index);
+	CREATE INDEX IF NOT EXISTS idx_vouchers_executed_at ON vouchers(executed_at);
 	`
 
 	// execute a query on the server
-	_, err := c.Db.Exec(schema)
+	_, err = c.Db.Exec(indexes)
 	return err
 }
 
@@ -81,8 +112,9 @@ func (c *VoucherRepository) CreateVoucher(
 		value,
 		output_hashes_siblings,
 		app_contract,
-		proof_output_index
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		proof_output_index,
+		input_index_unknown
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	exec := DBExecutor{&c.Db}
 
@@ -98,6 +130,7 @@ func (c *VoucherRepository) CreateVoucher(
 		voucher.OutputHashesSiblings,
 		voucher.AppContract.Hex(),
 		voucher.ProofOutputIndex,
+		voucher.InputIndexUnknown,
 	)
 	if err != nil {
 		slog.Error("Error creating vouchers", "Error", err)
@@ -135,13 +168,15 @@ func (c *VoucherRepository) SetProof(
 	return nil
 }
 
+// SetExecuted marks the voucher as executed by its transaction. It returns
+// false, leaving the voucher as it is, when it was already executed.
 func (c *VoucherRepository) SetExecuted(
 	ctx context.Context, voucher *model.ConvenienceVoucher,
-) error {
-	updateVoucher := `UPDATE vouchers SET 
+) (bool, error) {
+	updateVoucher := `UPDATE vouchers SET
 		transaction_hash = $1,
 		executed = true
-		WHERE app_contract = $2 and output_index = $3`
+		WHERE app_contract = $2 and output_index = $3 and executed = false`
 	exec := DBExecutor{&c.Db}
 	res, err := exec.ExecContext(
 		ctx,
@@ -150,6 +185,72 @@ func (c *VoucherRepository) SetExecuted(
 		voucher.AppContract.Hex(),
 		voucher.OutputIndex,
 	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 1 {
+		return true, nil
+	}
+	var count int
+	err = exec.GetContext(ctx, &count,
+		`SELECT count(*) FROM vouchers WHERE app_contract = $1 and output_index = $2`,
+		voucher.AppContract.Hex(), voucher.OutputIndex)
+	if err != nil {
+		return false, err
+	}
+	if count != 1 {
+		return false, fmt.Errorf("wrong number of vouchers affected: %d; app_contract %v; output_index %d", count, voucher.AppContract, voucher.OutputIndex)
+	}
+	return false, nil
+}
+
+// UnsetExecuted reverts the execution of the voucher by the transaction,
+// when its block was reorganized out of the chain
+func (c *VoucherRepository) UnsetExecuted(
+	ctx context.Context, voucher *model.ConvenienceVoucher,
+) error {
+	updateVoucher := `UPDATE vouchers SET
+		transaction_hash = '',
+		executed = false,
+		executed_block = 0,
+		executed_at = 0,
+		executed_by = ''
+		WHERE app_contract = $1 and output_index = $2 and transaction_hash = $3`
+	exec := DBExecutor{&c.Db}
+	_, err := exec.ExecContext(
+		ctx,
+		updateVoucher,
+		voucher.AppContract.Hex(),
+		voucher.OutputIndex,
+		voucher.TransactionHash,
+	)
+	return err
+}
+
+// SetExecutionDetails stores the block and the sender of the execution
+// transaction of an executed voucher
+func (c *VoucherRepository) SetExecutionDetails(
+	ctx context.Context, voucher *model.ConvenienceVoucher,
+) error {
+	updateVoucher := `UPDATE vouchers SET
+		executed_block = $1,
+		executed_at = $2,
+		executed_by = $3
+		WHERE app_contract = $4 and output_index = $5`
+	exec := DBExecutor{&c.Db}
+	res, err := exec.ExecContext(
+		ctx,
+		updateVoucher,
+		voucher.ExecutedBlock,
+		voucher.ExecutedAt,
+		voucher.ExecutedBy,
+		voucher.AppContract.Hex(),
+		voucher.OutputIndex,
+	)
 	if err != nil {
 		return err
 	}
@@ -194,7 +295,7 @@ func (c *VoucherRepository) VoucherCount(
 	ctx context.Context,
 ) (uint64, error) {
 	var count int
-	err := c.Db.GetContext(ctx, &count, "SELECT count(*) FROM vouchers")
+	err := c.readDb(ctx).GetContext(ctx, &count, "SELECT count(*) FROM vouchers")
 	if err != nil {
 		return 0, nil
 	}
@@ -207,7 +308,7 @@ func (c *VoucherRepository) queryByOutputIndexAndAppContract(
 	appContract *common.Address,
 ) (*sqlx.Rows, error) {
 	if appContract != nil {
-		return c.Db.QueryxContext(ctx, `
+		return c.readDb(ctx).QueryxContext(ctx, `
 			SELECT * FROM vouchers
 			WHERE output_index = $1 and app_contract = $2
 			LIMIT 1`,
@@ -215,7 +316,7 @@ func (c *VoucherRepository) queryByOutputIndexAndAppContract(
 			appContract.Hex(),
 		)
 	} else {
-		return c.Db.QueryxContext(ctx, `
+		return c.readDb(ctx).QueryxContext(ctx, `
 			SELECT * FROM vouchers
 			WHERE output_index = $1
 			LIMIT 1`,
@@ -256,7 +357,7 @@ func (c *VoucherRepository) FindVoucherByOutputIndexAndAppContract(
 func (c *VoucherRepository) FindAllVouchersByBlockNumber(
 	ctx context.Context, startBlockGte uint64, endBlockLt uint64,
 ) ([]*model.ConvenienceVoucher, error) {
-	stmt, err := c.Db.Preparex(`
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, `
 		SELECT
 			v.destination,
 			v.payload,
@@ -269,7 +370,8 @@ func (c *VoucherRepository) FindAllVouchersByBlockNumber(
 		FROM vouchers v
 			INNER JOIN convenience_inputs i
 				ON i.app_contract = v.app_contract AND i.input_index = v.input_index
-		WHERE i.block_number >= $1 and i.block_number < $2`)
+		WHERE i.block_number >= $1 and i.block_number < $2
+			and v.input_index_unknown = false`)
 	if err != nil {
 		return nil, err
 	}
@@ -287,13 +389,106 @@ func (c *VoucherRepository) FindAllVouchersByBlockNumber(
 	return vouchers, nil
 }
 
+// SetResolveAttempt records a failed attempt to resolve the execution
+// details of the voucher, made at attemptedAt, the next one is made after
+// resolveAfter (unix seconds)
+func (c *VoucherRepository) SetResolveAttempt(
+	ctx context.Context,
+	voucher *model.ConvenienceVoucher,
+	attemptedAt uint64,
+	resolveAfter uint64,
+) error {
+	updateVoucher := `UPDATE vouchers SET
+		resolve_attempts = resolve_attempts + 1,
+		resolve_attempted_at = $1,
+		resolve_after = $2
+		WHERE app_contract = $3 and output_index = $4`
+	exec := DBExecutor{&c.Db}
+	_, err := exec.ExecContext(
+		ctx,
+		updateVoucher,
+		attemptedAt,
+		resolveAfter,
+		voucher.AppContract.Hex(),
+		voucher.OutputIndex,
+	)
+	if err != nil {
+		return err
+	}
+	voucher.ResolveAttempts++
+	voucher.ResolveAttemptedAt = attemptedAt
+	voucher.ResolveAfter = resolveAfter
+	return nil
+}
+
+// FindUnresolvedExecutions returns the executed vouchers without the
+// execution details, the ones whose RPC call failed or was never made,
+// that are due at now (unix seconds). The ones attempted the longest ago
+// come first, so the failing ones do not hold back the others.
+func (c *VoucherRepository) FindUnresolvedExecutions(
+	ctx context.Context, now uint64, limit int,
+) ([]*model.ConvenienceVoucher, error) {
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, `
+		SELECT * FROM vouchers
+		WHERE executed = true AND executed_by = '' AND transaction_hash <> ''
+		AND resolve_after <= $1
+		ORDER BY resolve_attempted_at, app_contract, output_index
+		LIMIT $2`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var rows []voucherRow
+	err = stmt.SelectContext(ctx, &rows, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	vouchers := make([]*model.ConvenienceVoucher, len(rows))
+	for i, row := range rows {
+		cVoucher := convertToConvenienceVoucher(row)
+		vouchers[i] = &cVoucher
+	}
+	return vouchers, nil
+}
+
+// StreamByIndex calls fn with each voucher of the application whose output
+// index is in [fromIndex, toIndex], in index order, reading them in batches
+func (c *VoucherRepository) StreamByIndex(
+	ctx context.Context,
+	appContract common.Address,
+	fromIndex uint64,
+	toIndex uint64,
+	fn func(*model.ConvenienceVoucher) error,
+) error {
+	find := func(fromIndex uint64, limit int) ([]*model.ConvenienceVoucher, error) {
+		var rows []voucherRow
+		err := c.readDb(ctx).SelectContext(ctx, &rows, `SELECT * FROM vouchers
+			WHERE app_contract = $1 AND output_index >= $2 AND output_index <= $3
+			ORDER BY output_index ASC
+			LIMIT $4`,
+			appContract.Hex(), fromIndex, toIndex, limit,
+		)
+		if err != nil {
+			return nil, err
+		}
+		vouchers := make([]*model.ConvenienceVoucher, len(rows))
+		for i, row := range rows {
+			voucher := convertToConvenienceVoucher(row)
+			vouchers[i] = &voucher
+		}
+		return vouchers, nil
+	}
+	index := func(voucher *model.ConvenienceVoucher) uint64 { return voucher.OutputIndex }
+	return streamByIndex(fromIndex, toIndex, find, index, fn)
+}
+
 func (c *VoucherRepository) FindVoucherByInputAndOutputIndex(
 	ctx context.Context, inputIndex uint64, outputIndex uint64,
 ) (*model.ConvenienceVoucher, error) {
 
 	query := `SELECT * FROM vouchers WHERE input_index = $1 and output_index = $2 LIMIT 1`
 
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -334,8 +529,8 @@ func (c *VoucherRepository) Count(
 		return 0, err
 	}
 	query += where
-	slog.Debug("Query", "query", query, "args", args)
-	stmt, err := c.Db.Preparex(query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -360,7 +555,7 @@ func (c *VoucherRepository) FindAllVouchers(
 	if err != nil {
 		return nil, err
 	}
-	query := `SELECT * FROM vouchers `
+	query := `SELECT ` + voucherListColumns + ` FROM vouchers `
 	where, args, argsCount, err := transformToQuery(filter)
 	if err != nil {
 		return nil, err
@@ -380,8 +575,8 @@ func (c *VoucherRepository) FindAllVouchers(
 	query += `OFFSET $` + strconv.Itoa(argsCount) + ` `
 	args = append(args, offset)
 
-	slog.Debug("Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.Preparex(query)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -410,7 +605,7 @@ func (c *VoucherRepository) FindVoucherByAppContractAndIndex(ctx context.Context
 
 	query := `SELECT * FROM convenience_vouchers WHERE input_index = $1 AND app_contract = $2`
 
-	res, err := c.Db.QueryxContext(
+	res, err := c.readDb(ctx).QueryxContext(
 		ctx,
 		query,
 		uint64(index),
@@ -446,6 +641,13 @@ func convertToConvenienceVoucher(row voucherRow) model.ConvenienceVoucher {
 		OutputHashesSiblings: row.OutputHashesSiblings,
 		TransactionHash:      row.TransactionHash,
 		ProofOutputIndex:     row.ProofOutputIndex,
+		ExecutedBlock:        row.ExecutedBlock,
+		ExecutedAt:           row.ExecutedAt,
+		ExecutedBy:           row.ExecutedBy,
+		InputIndexUnknown:    row.InputIndexUnknown,
+		ResolveAttempts:      row.ResolveAttempts,
+		ResolveAttemptedAt:   row.ResolveAttemptedAt,
+		ResolveAfter:         row.ResolveAfter,
 	}
 	return voucher
 }
@@ -472,9 +674,39 @@ func transformToQuery(
 				count += 1
 			} else {
 				return "", nil, 0, fmt.Errorf(
-					"unexpected executed value %s", *filter.Eq,
+					"%w: unexpected executed value %s", ErrInvalidFilter, *filter.Eq,
 				)
 			}
+		} else if *filter.Field == model.EXECUTED_AT || *filter.Field == model.EXECUTED_BLOCK {
+			column := "executed_at"
+			if *filter.Field == model.EXECUTED_BLOCK {
+				column = "executed_block"
+			}
+			ranges := []struct {
+				value    *string
+				operator string
+			}{
+				{filter.Gt, ">"}, {filter.Gte, ">="}, {filter.Lt, "<"}, {filter.Lte, "<="},
+			}
+			hasRange := false
+			for _, r := range ranges {
+				if r.value == nil {
+					continue
+				}
+				value, err := strconv.ParseUint(*r.value, 10, 64) // nolint
+				if err != nil {
+					return "", nil, 0, fmt.Errorf("%w: unexpected %s value %s", ErrInvalidFilter, *filter.Field, *r.value)
+				}
+				where = append(where, fmt.Sprintf("%s %s $%d ", column, r.operator, count))
+				args = append(args, value)
+				count += 1
+				hasRange = true
+			}
+			if !hasRange {
+				return "", nil, 0, fmt.Errorf("operation not implemented")
+			}
+			// vouchers with unknown execution details are out of any range
+			where = append(where, fmt.Sprintf("%s > 0 ", column))
 		} else if *filter.Field == model.DESTINATION {
 			if filter.Eq != nil {
 				where = append(where, fmt.Sprintf("destination = $%d ", count))
@@ -488,7 +720,8 @@ func transformToQuery(
 			}
 		} else if *filter.Field == model.INPUT_INDEX {
 			if filter.Eq != nil {
-				where = append(where, fmt.Sprintf("input_index = $%d ", count))
+				// the vouchers of an unknown input are not of any input
+				where = append(where, fmt.Sprintf("input_index = $%d and input_index_unknown = false ", count))
 				args = append(args, *filter.Eq)
 				count += 1
 			} else {
@@ -515,38 +748,31 @@ func (c *VoucherRepository) BatchFindAllByInputIndexAndAppContract(
 	filters []*BatchFilterItem,
 ) ([]*commons.PageResult[model.ConvenienceVoucher], []error) {
 	slog.Debug("BatchFindAllByInputIndexAndAppContract", "len", len(filters))
-	query := `SELECT * FROM vouchers WHERE `
+	query := `SELECT ` + voucherListColumns + ` FROM vouchers WHERE `
 
 	args := []interface{}{}
 	where := []string{}
 	for i, filter := range filters {
 		// nolint
-		where = append(where, fmt.Sprintf(" (app_contract = $%d and input_index = $%d) ", i*2+1, i*2+2))
+		where = append(where, fmt.Sprintf(" (app_contract = $%d and input_index = $%d and input_index_unknown = false) ", i*2+1, i*2+2))
 		args = append(args, filter.AppContract.Hex())
 		args = append(args, filter.InputIndex)
 	}
 	query += strings.Join(where, " or ")
 
-	errors := []error{}
 	results := []*commons.PageResult[model.ConvenienceVoucher]{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := c.readDb(ctx).PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("BatchFind prepare context", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryxContext(ctx, args...)
-	if err != nil {
-		slog.Error("BatchFind query context", "error", err)
-		return nil, errors
-	}
-	defer rows.Close()
-
 	var voucherRows []voucherRow
 	err = stmt.SelectContext(ctx, &voucherRows, args...)
 	if err != nil {
-		return nil, errors
+		slog.Error("BatchFind", "error", err)
+		return nil, []error{err}
 	}
 
 	vouchers := make([]model.ConvenienceVoucher, len(voucherRows))
@@ -555,10 +781,6 @@ func (c *VoucherRepository) BatchFindAllByInputIndexAndAppContract(
 		vouchers[i] = convertToConvenienceVoucher(row)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, errors
-	}
-
 	voucherMap := make(map[string]*commons.PageResult[model.ConvenienceVoucher])
 
 	for _, voucher := range vouchers {
@@ -608,3 +830,14 @@ func parseVoucher(res *sqlx.Rows) (*model.ConvenienceVoucher, error) {
 	voucher.Destination = common.HexToAddress(destination)
 	return &voucher, nil
 }
+
+func (c *VoucherRepository) BatchFindProofsByOutputIndexAndAppContract(
+	ctx context.Context,
+	filters []*BatchFilterItemForProof,
+) ([]*model.ConvenienceProof, []error) {
+	return batchFindProofs(ctx, c.readDb(ctx), "vouchers", filters)
+}
+
+func (c *VoucherRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, &c.Db, c.ReadDb)
+}
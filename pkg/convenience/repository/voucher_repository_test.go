@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
@@ -25,11 +26,11 @@ func (s *VoucherRepositorySuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
 	s.dbFactory = commons.NewDbFactory()
 	db := s.dbFactory.CreateDb("voucher.sqlite3")
-	outputRepository := OutputRepository{*db}
+	outputRepository := OutputRepository{Db: *db}
 	s.voucherRepository = &VoucherRepository{
 		Db: *db, OutputRepository: outputRepository,
 	}
-	noticeRepository := NoticeRepository{*db, outputRepository, false}
+	noticeRepository := NoticeRepository{Db: *db, OutputRepository: outputRepository}
 	err := noticeRepository.CreateTables()
 	s.NoError(err)
 	err = s.voucherRepository.CreateTables()
@@ -137,6 +138,66 @@ func (s *VoucherRepositorySuite) TestCountVoucher() {
 	s.Equal(1, int(total))
 }
 
+func (s *VoucherRepositorySuite) TestFilterByExecutionRange() {
+	ctx := context.Background()
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	executor := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266").Hex()
+	for i := uint64(0); i < 4; i++ {
+		_, err := s.voucherRepository.CreateVoucher(ctx, &model.ConvenienceVoucher{
+			Destination: common.HexToAddress("0x26A61aF89053c847B4bd5084E2caFe7211874a29"),
+			Payload:     "0x0011",
+			InputIndex:  i,
+			OutputIndex: i,
+			AppContract: app,
+		})
+		s.Require().NoError(err)
+		if i == 0 {
+			// executed without the details
+			continue
+		}
+		voucher := &model.ConvenienceVoucher{
+			AppContract:     app,
+			OutputIndex:     i,
+			TransactionHash: common.BigToHash(new(big.Int).SetUint64(i)).Hex(),
+			ExecutedBlock:   100 + i,
+			ExecutedAt:      1000 + i,
+			ExecutedBy:      executor,
+		}
+		executed, err := s.voucherRepository.SetExecuted(ctx, voucher)
+		s.Require().NoError(err)
+		s.True(executed)
+		s.Require().NoError(s.voucherRepository.SetExecutionDetails(ctx, voucher))
+	}
+	voucher, err := s.voucherRepository.FindVoucherByOutputIndexAndAppContract(ctx, 2, &app)
+	s.Require().NoError(err)
+	s.Equal(uint64(102), voucher.ExecutedBlock)
+	s.Equal(uint64(1002), voucher.ExecutedAt)
+	s.Equal(executor, voucher.ExecutedBy)
+
+	field := model.EXECUTED_AT
+	gte, lt := "1002", "1004"
+	total, err := s.voucherRepository.Count(ctx, []*model.ConvenienceFilter{
+		{Field: &field, Gte: &gte, Lt: &lt},
+	})
+	s.Require().NoError(err)
+	s.Equal(2, int(total))
+
+	field = model.EXECUTED_BLOCK
+	lte := "101"
+	page, err := s.voucherRepository.FindAllVouchers(ctx, nil, nil, nil, nil, []*model.ConvenienceFilter{
+		{Field: &field, Lte: &lte},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(page.Rows, 1)
+	s.Equal(uint64(1), page.Rows[0].OutputIndex)
+
+	wrong := "yesterday"
+	_, err = s.voucherRepository.Count(ctx, []*model.ConvenienceFilter{
+		{Field: &field, Gt: &wrong},
+	})
+	s.Error(err)
+}
+
 func (s *VoucherRepositorySuite) TestPagination() {
 	destination := common.HexToAddress("0x26A61aF89053c847B4bd5084E2caFe7211874a29")
 	ctx := context.Background()
@@ -254,3 +315,62 @@ func (s *VoucherRepositorySuite) TestBatchFindAllVouchers() {
 	s.Equal(4, len(results[0].Rows))
 	s.Equal(4, int(results[0].Total))
 }
+
+func (s *VoucherRepositorySuite) TestCountOutputs() {
+	ctx := context.Background()
+	app := common.HexToAddress(devnet.ApplicationAddress)
+	other := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	for i, voucher := range []model.ConvenienceVoucher{
+		{AppContract: app, Executed: true},
+		{AppContract: app},
+		{AppContract: other, Executed: true},
+	} {
+		voucher.OutputIndex = uint64(i)
+		_, err := s.voucherRepository.CreateVoucher(ctx, &voucher)
+		s.Require().NoError(err)
+	}
+	noticeRepository := NoticeRepository{Db: s.voucherRepository.Db}
+	_, err := noticeRepository.Create(ctx, &model.ConvenienceNotice{
+		AppContract: app.Hex(), Payload: "0x", OutputIndex: 3,
+	})
+	s.Require().NoError(err)
+
+	stats, err := s.voucherRepository.OutputRepository.CountOutputs(ctx, &app)
+	s.Require().NoError(err)
+	s.Equal(model.OutputStats{Vouchers: 2, ExecutedVouchers: 1, Notices: 1}, *stats)
+
+	stats, err = s.voucherRepository.OutputRepository.CountOutputs(ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(model.OutputStats{Vouchers: 3, ExecutedVouchers: 2, Notices: 1}, *stats)
+}
+
+func (s *VoucherRepositorySuite) TestSetExecutedOnce() {
+	ctx := context.Background()
+	app := common.HexToAddress(devnet.ApplicationAddress)
+	_, err := s.voucherRepository.CreateVoucher(ctx, &model.ConvenienceVoucher{
+		AppContract: app,
+		OutputIndex: 1,
+	})
+	s.Require().NoError(err)
+	voucher := &model.ConvenienceVoucher{
+		AppContract:     app,
+		OutputIndex:     1,
+		TransactionHash: common.HexToHash("0xaa").Hex(),
+	}
+	executed, err := s.voucherRepository.SetExecuted(ctx, voucher)
+	s.Require().NoError(err)
+	s.True(executed)
+
+	// the first execution is kept
+	voucher.TransactionHash = common.HexToHash("0xbb").Hex()
+	executed, err = s.voucherRepository.SetExecuted(ctx, voucher)
+	s.Require().NoError(err)
+	s.False(executed)
+	saved, err := s.voucherRepository.FindVoucherByOutputIndexAndAppContract(ctx, 1, &app)
+	s.Require().NoError(err)
+	s.Equal(common.HexToHash("0xaa").Hex(), saved.TransactionHash)
+
+	voucher.OutputIndex = 2
+	_, err = s.voucherRepository.SetExecuted(ctx, voucher)
+	s.Error(err)
+}
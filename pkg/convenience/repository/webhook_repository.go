@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// WebhookRepository keeps the outbox of the indexed events and
+// the log of their deliveries to the webhook endpoints
+type WebhookRepository struct {
+	Db     *sqlx.DB
+	ReadDb *sqlx.DB // optional read replica
+}
+
+// WebhookDeliveryFilter narrows the delivery log, nil fields match all
+type WebhookDeliveryFilter struct {
+	AppContract *common.Address
+	EventType   *string
+	Status      *string
+}
+
+func (r *WebhookRepository) CreateTables() error {
+	idType := "INTEGER"
+
+	if r.Db.DriverName() == "postgres" {
+		idType = "SERIAL"
+	}
+
+	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS convenience_webhook_outbox (
+		id 				%s NOT NULL PRIMARY KEY,
+		event_type 		text NOT NULL,
+		app_contract 	text NOT NULL,
+		data 			text NOT NULL,
+		created_at 		bigint NOT NULL,
+		dispatched 		BOOLEAN NOT NULL DEFAULT FALSE);
+
+	CREATE INDEX IF NOT EXISTS idx_webhook_outbox_dispatched ON convenience_webhook_outbox(dispatched, id);
+
+	CREATE TABLE IF NOT EXISTS convenience_webhook_deliveries (
+		id 				%s NOT NULL PRIMARY KEY,
+		event_id 		integer NOT NULL,
+		event_type 		text NOT NULL,
+		app_contract 	text NOT NULL,
+		endpoint 		text NOT NULL,
+		status 			text NOT NULL,
+		attempts 		integer NOT NULL DEFAULT 0,
+		next_attempt_at bigint NOT NULL,
+		response_status integer NOT NULL DEFAULT 0,
+		last_error 		text NOT NULL DEFAULT '',
+		updated_at 		bigint NOT NULL,
+		UNIQUE (event_id, endpoint));
+
+	CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON convenience_webhook_deliveries(status, next_attempt_at);`,
+		idType, idType)
+
+	_, err := r.Db.Exec(schema)
+	if err != nil {
+		slog.Error("Failed to create tables", "error", err)
+		return err
+	}
+	slog.Debug("Webhook tables created successfully")
+	return nil
+}
+
+// AddEvent writes the event to the outbox. It joins the transaction of
+// the context, so the event is only kept if the indexed change is.
+func (r *WebhookRepository) AddEvent(
+	ctx context.Context,
+	eventType string,
+	appContract common.Address,
+	data any,
+) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	insertSql := `INSERT INTO convenience_webhook_outbox (event_type, app_contract, data, created_at)
+		VALUES ($1, $2, $3, $4)`
+	exec := DBExecutor{r.Db}
+	_, err = exec.ExecContext(ctx, insertSql,
+		eventType,
+		appContract.Hex(),
+		string(body),
+		time.Now().Unix(),
+	)
+	return err
+}
+
+// FindPendingEvents returns the events not fanned out yet, oldest first
+func (r *WebhookRepository) FindPendingEvents(ctx context.Context, limit int) ([]model.WebhookEvent, error) {
+	query := `SELECT id, event_type, app_contract, data, created_at, dispatched
+		FROM convenience_webhook_outbox
+		WHERE dispatched = $1
+		ORDER BY id ASC
+		LIMIT $2`
+	events := []model.WebhookEvent{}
+	err := r.Db.SelectContext(ctx, &events, query, false, limit)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *WebhookRepository) FindEventById(ctx context.Context, id int64) (*model.WebhookEvent, error) {
+	query := `SELECT id, event_type, app_contract, data, created_at, dispatched
+		FROM convenience_webhook_outbox
+		WHERE id = $1`
+	var event model.WebhookEvent
+	err := r.Db.GetContext(ctx, &event, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Dispatch creates one pending delivery per endpoint and marks the event
+// as dispatched. Call it inside a transaction to do both or none.
+func (r *WebhookRepository) Dispatch(
+	ctx context.Context,
+	event model.WebhookEvent,
+	endpoints []string,
+	now int64,
+) error {
+	exec := DBExecutor{r.Db}
+	insertSql := `INSERT INTO convenience_webhook_deliveries (
+		event_id, event_type, app_contract, endpoint, status, next_attempt_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (event_id, endpoint) DO NOTHING`
+	for _, endpoint := range endpoints {
+		_, err := exec.ExecContext(ctx, insertSql,
+			event.Id,
+			event.EventType,
+			event.AppContract,
+			endpoint,
+			model.DELIVERY_PENDING,
+			now,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := exec.ExecContext(ctx,
+		`UPDATE convenience_webhook_outbox SET dispatched = $1 WHERE id = $2`,
+		true, event.Id,
+	)
+	return err
+}
+
+// FindDueDeliveries returns the pending deliveries whose next attempt is due
+func (r *WebhookRepository) FindDueDeliveries(
+	ctx context.Context, now int64, limit int,
+) ([]model.WebhookDelivery, error) {
+	query := `SELECT * FROM convenience_webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT $3`
+	deliveries := []model.WebhookDelivery{}
+	err := r.Db.SelectContext(ctx, &deliveries, query, model.DELIVERY_PENDING, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the outcome of an attempt
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	updateSql := `UPDATE convenience_webhook_deliveries SET
+		status = $1,
+		attempts = $2,
+		next_attempt_at = $3,
+		response_status = $4,
+		last_error = $5,
+		updated_at = $6
+		WHERE id = $7`
+	exec := DBExecutor{r.Db}
+	_, err := exec.ExecContext(ctx, updateSql,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.UpdatedAt,
+		delivery.Id,
+	)
+	return err
+}
+
+func (r *WebhookRepository) CountDeliveries(
+	ctx context.Context,
+	filter WebhookDeliveryFilter,
+) (uint64, error) {
+	where, args := deliveriesWhere(filter)
+	var count uint64
+	err := r.readDb(ctx).GetContext(ctx, &count,
+		`SELECT count(*) FROM convenience_webhook_deliveries `+where, args...)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindAllDeliveries pages through the delivery log in creation order
+func (r *WebhookRepository) FindAllDeliveries(
+	ctx context.Context,
+	first *int,
+	last *int,
+	after *string,
+	before *string,
+	filter WebhookDeliveryFilter,
+) (*commons.PageResult[model.WebhookDelivery], error) {
+	total, err := r.CountDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := commons.ComputePage(first, last, after, before, int(total))
+	if err != nil {
+		return nil, err
+	}
+	where, args := deliveriesWhere(filter)
+	query := `SELECT * FROM convenience_webhook_deliveries ` + where +
+		`ORDER BY id ASC ` +
+		fmt.Sprintf(`LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2) // nolint
+	args = append(args, limit, offset)
+	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
+
+	deliveries := []model.WebhookDelivery{}
+	err = r.readDb(ctx).SelectContext(ctx, &deliveries, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &commons.PageResult[model.WebhookDelivery]{
+		Rows:   deliveries,
+		Total:  total,
+		Offset: uint64(offset),
+	}, nil
+}
+
+func deliveriesWhere(filter WebhookDeliveryFilter) (string, []any) {
+	where := ""
+	args := []any{}
+	add := func(condition string, value any) {
+		if where == "" {
+			where = "WHERE "
+		} else {
+			where += "AND "
+		}
+		args = append(args, value)
+		where += fmt.Sprintf("%s = $%d ", condition, len(args))
+	}
+	if filter.AppContract != nil {
+		add("app_contract", filter.AppContract.Hex())
+	}
+	if filter.EventType != nil {
+		add("event_type", *filter.EventType)
+	}
+	if filter.Status != nil {
+		add("status", *filter.Status)
+	}
+	return where, args
+}
+
+func (r *WebhookRepository) readDb(ctx context.Context) *sqlx.DB {
+	return routeRead(ctx, r.Db, r.ReadDb)
+}
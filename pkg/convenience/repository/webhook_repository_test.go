@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookRepositorySuite struct {
+	suite.Suite
+	repository *WebhookRepository
+	dbFactory  *commons.DbFactory
+}
+
+func (s *WebhookRepositorySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("webhook.sqlite3")
+	s.repository = &WebhookRepository{
+		Db: db,
+	}
+	err := s.repository.CreateTables()
+	s.NoError(err)
+}
+
+func (s *WebhookRepositorySuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func TestWebhookRepositorySuite(t *testing.T) {
+	suite.Run(t, new(WebhookRepositorySuite))
+}
+
+func (s *WebhookRepositorySuite) TestEventRolledBackWithTransaction() {
+	ctx, tx, err := StartTransactionContext(context.Background(), s.repository.Db)
+	s.Require().NoError(err)
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	err = s.repository.AddEvent(ctx, model.WEBHOOK_INPUT_EXCEPTION, app, map[string]any{"inputIndex": 1})
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Rollback())
+
+	events, err := s.repository.FindPendingEvents(context.Background(), 10)
+	s.Require().NoError(err)
+	s.Empty(events)
+}
+
+func (s *WebhookRepositorySuite) TestDispatchAndDeliver() {
+	ctx := context.Background()
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	err := s.repository.AddEvent(ctx, model.WEBHOOK_VOUCHER_EXECUTED, app, map[string]any{"outputIndex": 2})
+	s.Require().NoError(err)
+
+	events, err := s.repository.FindPendingEvents(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(app.Hex(), events[0].AppContract)
+	s.JSONEq(`{"outputIndex": 2}`, events[0].Data)
+
+	endpoints := []string{"http://a.example/hook", "http://b.example/hook"}
+	err = s.repository.Dispatch(ctx, events[0], endpoints, 100)
+	s.Require().NoError(err)
+	events, err = s.repository.FindPendingEvents(ctx, 10)
+	s.Require().NoError(err)
+	s.Empty(events)
+
+	due, err := s.repository.FindDueDeliveries(ctx, 99, 10)
+	s.Require().NoError(err)
+	s.Empty(due)
+	due, err = s.repository.FindDueDeliveries(ctx, 100, 10)
+	s.Require().NoError(err)
+	s.Require().Len(due, 2)
+	s.Equal(model.WEBHOOK_VOUCHER_EXECUTED, due[0].EventType)
+
+	delivered := due[0]
+	delivered.Status = model.DELIVERY_DELIVERED
+	delivered.Attempts = 1
+	delivered.ResponseStatus = 200
+	delivered.UpdatedAt = 101
+	s.Require().NoError(s.repository.UpdateDelivery(ctx, delivered))
+
+	status := model.DELIVERY_PENDING
+	res, err := s.repository.FindAllDeliveries(ctx, nil, nil, nil, nil, WebhookDeliveryFilter{Status: &status})
+	s.Require().NoError(err)
+	s.Equal(1, int(res.Total))
+	s.Equal(endpoints[1], res.Rows[0].Endpoint)
+
+	res, err = s.repository.FindAllDeliveries(ctx, nil, nil, nil, nil, WebhookDeliveryFilter{AppContract: &app})
+	s.Require().NoError(err)
+	s.Equal(2, int(res.Total))
+	s.Equal(model.DELIVERY_DELIVERED, res.Rows[0].Status)
+	s.Equal(200, res.Rows[0].ResponseStatus)
+}
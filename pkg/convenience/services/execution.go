@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UNRESOLVED_EXECUTIONS_LIMIT is how many executions ResolveExecutions
+// tries at a time
+const UNRESOLVED_EXECUTIONS_LIMIT = 100
+
+// RESOLVE_EXECUTION_BACKOFF is the wait after the first failed attempt to
+// resolve an execution, it doubles with each attempt up to
+// MAX_RESOLVE_EXECUTION_BACKOFF
+const RESOLVE_EXECUTION_BACKOFF = time.Minute
+const MAX_RESOLVE_EXECUTION_BACKOFF = time.Hour
+
+// ExecutionResolver fills the block and the sender of the voucher execution.
+// The blockHash is the block of the execution transaction when it is already
+// known, otherwise it is read from the transaction receipt.
+type ExecutionResolver interface {
+	Resolve(ctx context.Context, voucher *model.ConvenienceVoucher, blockHash common.Hash) error
+}
+
+// SetVoucherExecuted marks the voucher as executed by its transaction and,
+// when the webhookRepository is set, queues its voucher.executed event in
+// the transaction of the ctx. A voucher already executed is left as it is
+// and no event is queued, so each execution is notified once. It returns
+// whether the voucher was marked.
+func SetVoucherExecuted(
+	ctx context.Context,
+	voucherRepository *repository.VoucherRepository,
+	webhookRepository *repository.WebhookRepository,
+	voucher *model.ConvenienceVoucher,
+) (bool, error) {
+	executed, err := voucherRepository.SetExecuted(ctx, voucher)
+	if err != nil || !executed {
+		return false, err
+	}
+	if webhookRepository == nil {
+		return true, nil
+	}
+	err = webhookRepository.AddEvent(ctx, model.WEBHOOK_VOUCHER_EXECUTED, voucher.AppContract,
+		model.VoucherExecutedEvent{
+			InputIndex:      model.KnownInputIndex(voucher),
+			OutputIndex:     voucher.OutputIndex,
+			TransactionHash: voucher.TransactionHash,
+		})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResolveExecution stores the execution details of the executed voucher.
+// It calls the RPC, so it runs after the transaction that marks the voucher
+// as executed. When the RPC fails the voucher is left without the details,
+// to be resolved again by ResolveExecutions after a backoff.
+func ResolveExecution(
+	ctx context.Context,
+	resolver ExecutionResolver,
+	voucherRepository *repository.VoucherRepository,
+	voucher *model.ConvenienceVoucher,
+	blockHash common.Hash,
+) error {
+	if resolver == nil {
+		return nil
+	}
+	err := resolver.Resolve(ctx, voucher, blockHash)
+	if err != nil {
+		slog.Warn("Could not resolve the voucher execution",
+			"appContract", voucher.AppContract.Hex(),
+			"outputIndex", voucher.OutputIndex,
+			"attempts", voucher.ResolveAttempts+1,
+			"error", err,
+		)
+		now := time.Now()
+		resolveAfter := now.Add(resolveBackoff(voucher.ResolveAttempts))
+		return voucherRepository.SetResolveAttempt(ctx, voucher,
+			uint64(now.Unix()), uint64(resolveAfter.Unix()))
+	}
+	return voucherRepository.SetExecutionDetails(ctx, voucher)
+}
+
+// resolveBackoff is the wait after the failed attempt that follows the
+// previous ones
+func resolveBackoff(attempts uint64) time.Duration {
+	backoff := RESOLVE_EXECUTION_BACKOFF
+	for i := uint64(0); i < attempts && backoff < MAX_RESOLVE_EXECUTION_BACKOFF; i++ {
+		backoff *= 2
+	}
+	return min(backoff, MAX_RESOLVE_EXECUTION_BACKOFF)
+}
+
+// ResolveExecutions retries the executed vouchers without the execution
+// details whose backoff is over
+func ResolveExecutions(
+	ctx context.Context,
+	resolver ExecutionResolver,
+	voucherRepository *repository.VoucherRepository,
+) error {
+	if resolver == nil {
+		return nil
+	}
+	vouchers, err := voucherRepository.FindUnresolvedExecutions(ctx,
+		uint64(time.Now().Unix()), UNRESOLVED_EXECUTIONS_LIMIT)
+	if err != nil {
+		return err
+	}
+	for _, voucher := range vouchers {
+		err := ResolveExecution(ctx, resolver, voucherRepository, voucher, common.Hash{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
@@ -15,6 +16,10 @@ type ConvenienceService struct {
 	NoticeRepository  *repository.NoticeRepository
 	InputRepository   *repository.InputRepository
 	ReportRepository  *repository.ReportRepository
+	// StatsCacheTTL reuses the stats of an application for a while,
+	// they are computed on every request when zero
+	StatsCacheTTL time.Duration
+	statsCache    *statsCache
 }
 
 func NewConvenienceService(
@@ -28,6 +33,7 @@ func NewConvenienceService(
 		NoticeRepository:  noticeRepository,
 		InputRepository:   inputRepository,
 		ReportRepository:  reportRepository,
+		statsCache:        &statsCache{},
 	}
 }
 
@@ -133,6 +139,20 @@ func (c *ConvenienceService) UpdateExecuted(
 	)
 }
 
+// UnsetVoucherExecuted reverts the execution by the transaction, if any
+func (c *ConvenienceService) UnsetVoucherExecuted(
+	ctx context.Context,
+	appContract common.Address,
+	outputIndex uint64,
+	transactionHash common.Hash,
+) error {
+	return c.VoucherRepository.UnsetExecuted(ctx, &model.ConvenienceVoucher{
+		AppContract:     appContract,
+		OutputIndex:     outputIndex,
+		TransactionHash: transactionHash.Hex(),
+	})
+}
+
 func (c *ConvenienceService) FindAllVouchers(
 	ctx context.Context,
 	first *int,
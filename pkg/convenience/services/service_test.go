@@ -4,14 +4,15 @@ import (
 	"context"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -22,11 +23,13 @@ type ConvenienceServiceSuite struct {
 	reportRepository  *repository.ReportRepository
 	inputRepository   *repository.InputRepository
 	service           *ConvenienceService
+	dbFactory         *commons.DbFactory
 }
 
 func (s *ConvenienceServiceSuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
-	db := sqlx.MustConnect("sqlite3", ":memory:")
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("service.sqlite3")
 	outputRepository := repository.OutputRepository{
 		Db: *db,
 	}
@@ -65,6 +68,10 @@ func (s *ConvenienceServiceSuite) SetupTest() {
 	}
 }
 
+func (s *ConvenienceServiceSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
 func TestConvenienceServiceSuite(t *testing.T) {
 	suite.Run(t, new(ConvenienceServiceSuite))
 }
@@ -313,3 +320,75 @@ func (s *ConvenienceServiceSuite) TestCreateInputIdempotencyWithoutAppContract()
 	s.NoError(err)
 	s.Equal(2, int(otherCount))
 }
+
+func (s *ConvenienceServiceSuite) TestStats() {
+	ctx := context.Background()
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	_, err := s.service.CreateReport(ctx, &model.Report{AppContract: app, Payload: "0x01"})
+	s.Require().NoError(err)
+	_, err = s.service.CreateVoucher(ctx, &model.ConvenienceVoucher{AppContract: app, Executed: true})
+	s.Require().NoError(err)
+
+	stats, err := s.service.Stats(ctx, &app)
+	s.Require().NoError(err)
+	s.Equal(1, int(stats.Reports))
+	s.Equal(1, int(stats.Vouchers))
+	s.Equal(1, int(stats.ExecutedVouchers))
+
+	cached := NewConvenienceService(s.voucherRepository, s.noticeRepository,
+		s.inputRepository, s.reportRepository)
+	cached.StatsCacheTTL = time.Minute
+	stats, err = cached.Stats(ctx, &app)
+	s.Require().NoError(err)
+	s.Equal(1, int(stats.Reports))
+
+	_, err = s.service.CreateReport(ctx, &model.Report{AppContract: app, Index: 1, Payload: "0x02"})
+	s.Require().NoError(err)
+	stats, err = cached.Stats(ctx, &app)
+	s.Require().NoError(err)
+	s.Equal(1, int(stats.Reports))
+	stats, err = s.service.Stats(ctx, &app)
+	s.Require().NoError(err)
+	s.Equal(2, int(stats.Reports))
+}
+
+func (s *ConvenienceServiceSuite) TestSetVoucherExecutedQueuesTheEventOnce() {
+	ctx := context.Background()
+	webhookRepository := &repository.WebhookRepository{Db: &s.voucherRepository.Db}
+	s.Require().NoError(webhookRepository.CreateTables())
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	_, err := s.voucherRepository.CreateVoucher(ctx, &model.ConvenienceVoucher{
+		AppContract: app,
+		InputIndex:  1,
+		OutputIndex: 2,
+	})
+	s.Require().NoError(err)
+	voucher := &model.ConvenienceVoucher{
+		AppContract:     app,
+		InputIndex:      1,
+		OutputIndex:     2,
+		TransactionHash: common.HexToHash("0xaa").Hex(),
+	}
+	for i := 0; i < 2; i++ {
+		executed, err := SetVoucherExecuted(ctx, s.voucherRepository, webhookRepository, voucher)
+		s.Require().NoError(err)
+		s.Equal(i == 0, executed)
+	}
+	events, err := webhookRepository.FindPendingEvents(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(model.WEBHOOK_VOUCHER_EXECUTED, events[0].EventType)
+}
+
+func TestResolveBackoff(t *testing.T) {
+	cases := map[uint64]time.Duration{
+		0:   time.Minute,
+		1:   2 * time.Minute,
+		5:   32 * time.Minute,
+		6:   time.Hour,
+		100: time.Hour,
+	}
+	for attempts, backoff := range cases {
+		assert.Equal(t, backoff, resolveBackoff(attempts), "attempts %d", attempts)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type cachedStats struct {
+	stats     *model.AppStats
+	expiresAt time.Time
+}
+
+type statsCache struct {
+	mutex   sync.Mutex
+	entries map[string]cachedStats
+}
+
+// Stats aggregates the inputs and outputs of the application,
+// or of all of them when nil
+func (c *ConvenienceService) Stats(
+	ctx context.Context,
+	appContract *common.Address,
+) (*model.AppStats, error) {
+	key := "*"
+	if appContract != nil {
+		key = appContract.Hex()
+	}
+	cached := c.StatsCacheTTL > 0 && c.statsCache != nil
+	if cached {
+		if stats := c.statsCache.get(key); stats != nil {
+			return stats, nil
+		}
+	}
+	stats, err := c.computeStats(ctx, appContract)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		c.statsCache.put(key, stats, time.Now().Add(c.StatsCacheTTL))
+	}
+	return stats, nil
+}
+
+func (c *ConvenienceService) computeStats(
+	ctx context.Context,
+	appContract *common.Address,
+) (*model.AppStats, error) {
+	inputs, err := c.InputRepository.Stats(ctx, appContract)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := c.VoucherRepository.OutputRepository.CountOutputs(ctx, appContract)
+	if err != nil {
+		return nil, err
+	}
+	filter := []*model.ConvenienceFilter{}
+	if appContract != nil {
+		field := model.APP_CONTRACT
+		value := appContract.Hex()
+		filter = append(filter, &model.ConvenienceFilter{
+			Field: &field,
+			Eq:    &value,
+		})
+	}
+	reports, err := c.ReportRepository.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AppStats{
+		Inputs:      *inputs,
+		OutputStats: *outputs,
+		Reports:     reports,
+	}, nil
+}
+
+func (s *statsCache) get(key string) *model.AppStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry.stats
+}
+
+func (s *statsCache) put(key string, stats *model.AppStats, expiresAt time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.entries == nil {
+		s.entries = map[string]cachedStats{}
+	}
+	for k, entry := range s.entries {
+		if time.Now().After(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = cachedStats{stats: stats, expiresAt: expiresAt}
+}
@@ -0,0 +1,90 @@
+package synchronizerl1
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type indexRangeFunc func(ctx context.Context, from uint64, to uint64) error
+
+// syncBlockRanges calls indexRange from the block after the lastBlock,
+// nil when nothing was indexed yet, up to the latest block with the
+// confirmations, one chunk of blocks at a time. The checkpoints are not
+// rolled back, so the blocks younger than the confirmations, that may
+// still be reorganized, are left for the next sync.
+func syncBlockRanges(
+	ctx context.Context,
+	client L1Client,
+	name string,
+	lastBlock *uint64,
+	fromBlock uint64,
+	chunkSize uint64,
+	confirmations uint64,
+	indexRange indexRangeFunc,
+) error {
+	from := fromBlock
+	if lastBlock != nil {
+		from = max(*lastBlock+1, fromBlock)
+	}
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if head < confirmations {
+		return nil
+	}
+	latest := head - confirmations
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	for from <= latest {
+		to := min(from+chunkSize-1, latest)
+		rangeCtx, span := tracing.Start(ctx, "sync "+name,
+			attribute.Int64("block.from", int64(from)),
+			attribute.Int64("block.to", int64(to)),
+		)
+		err := indexRange(rangeCtx, from, to)
+		tracing.End(span, err)
+		if err != nil {
+			return err
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// indexInTransaction runs the handler and saves the checkpoints
+// of the block range in the same transaction
+func indexInTransaction(
+	ctx context.Context,
+	db sqlx.DB,
+	checkpointRepository *repository.L1CheckpointRepository,
+	checkpointNames []string,
+	to uint64,
+	handler func(ctx context.Context) error,
+) error {
+	txCtx, err := repository.StartTransaction(ctx, &db)
+	if err != nil {
+		return err
+	}
+	err = handler(txCtx)
+	for _, checkpointName := range checkpointNames {
+		if err != nil {
+			break
+		}
+		err = checkpointRepository.SaveLastBlock(txCtx, checkpointName, to)
+	}
+	tx, _ := repository.GetTransaction(txCtx)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			slog.Error("transaction rollback error", "err", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,75 @@
+package synchronizerl1
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+)
+
+// log3EmitterBin deploys a contract that copies the calldata to memory
+// and emits LOG3 with the first three words as topics and the rest as data
+const log3EmitterBin = "0x6017600c60003960176000f3" +
+	"366000600037604051602051600051606036036060a300"
+
+// log1EmitterBin does the same with LOG1 and the first word as topic
+const log1EmitterBin = "0x6011600c60003960116000f3" +
+	"366000600037600051602036036020a100"
+
+// log23EmitterBin emits LOG3 when the first word of the calldata is 3 and
+// LOG2 otherwise, taking the topics from the following words
+const log23EmitterBin = "0x602f600c600039602f6000f3" +
+	"366000600037600051600314601d57604051602051606036036060a200" +
+	"5b606051604051602051608036036080a300"
+
+// testChain is a simulated L1 where the events are emitted by
+// log emitter contracts, since the bindings have no bytecode
+type testChain struct {
+	require *require.Assertions
+	backend *simulated.Backend
+	key     *ecdsa.PrivateKey
+}
+
+func newTestChain(require *require.Assertions) *testChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(1e18)},
+	})
+	return &testChain{require, backend, key}
+}
+
+func (c *testChain) transactor() *bind.TransactOpts {
+	auth, err := bind.NewKeyedTransactorWithChainID(c.key, big.NewInt(1337)) // nolint
+	c.require.NoError(err)
+	return auth
+}
+
+func (c *testChain) deployLogEmitter(bin string) common.Address {
+	address, _, _, err := bind.DeployContract(
+		c.transactor(), abi.ABI{}, common.FromHex(bin), c.backend.Client(),
+	)
+	c.require.NoError(err)
+	c.backend.Commit()
+	return address
+}
+
+func (c *testChain) emit(contract common.Address, topics []common.Hash, data []byte) common.Hash {
+	calldata := []byte{}
+	for _, topic := range topics {
+		calldata = append(calldata, topic.Bytes()...)
+	}
+	calldata = append(calldata, data...)
+	client := c.backend.Client()
+	bound := bind.NewBoundContract(contract, abi.ABI{}, client, client, client)
+	tx, err := bound.RawTransact(c.transactor(), calldata)
+	c.require.NoError(err)
+	c.backend.Commit()
+	return tx.Hash()
+}
@@ -0,0 +1,246 @@
+package synchronizerl1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ClaimCheckpointName identifies the claim indexer in the l1_checkpoints table
+const ClaimCheckpointName = "claim_indexer"
+
+// ClaimIndexer reads the ClaimSubmission and ClaimAcceptance events of the
+// consensus, so the API can tell which epochs have executable proofs
+type ClaimIndexer struct {
+	RpcUrl           string
+	Client           L1Client
+	ConsensusAddress common.Address
+	// ApplicationAddresses restricts the indexed claims. When empty,
+	// the claims of every application of the consensus are indexed.
+	ApplicationAddresses []common.Address
+	// EpochLength is read from the consensus when zero
+	EpochLength uint64
+	FromBlock   uint64
+	ChunkSize   uint64
+	// Confirmations is how many blocks the indexed blocks are behind the latest
+	Confirmations        uint64
+	PollInterval         time.Duration
+	ClaimRepository      *repository.ClaimRepository
+	CheckpointRepository *repository.L1CheckpointRepository
+	consensus            *contracts.IConsensusFilterer
+	claimSubmissionID    common.Hash
+	claimAcceptanceID    common.Hash
+}
+
+func NewClaimIndexer(
+	rpcUrl string,
+	consensusAddress common.Address,
+	applicationAddresses []common.Address,
+	epochLength uint64,
+	fromBlock uint64,
+	claimRepository *repository.ClaimRepository,
+	checkpointRepository *repository.L1CheckpointRepository,
+) (*ClaimIndexer, error) {
+	consensusAbi, err := contracts.IConsensusMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	// the filterer is only used to parse the logs
+	consensus, err := contracts.NewIConsensusFilterer(consensusAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ClaimIndexer{
+		RpcUrl:               rpcUrl,
+		ConsensusAddress:     consensusAddress,
+		ApplicationAddresses: applicationAddresses,
+		EpochLength:          epochLength,
+		FromBlock:            fromBlock,
+		ChunkSize:            DefaultChunkSize,
+		PollInterval:         DefaultPollInterval,
+		ClaimRepository:      claimRepository,
+		CheckpointRepository: checkpointRepository,
+		consensus:            consensus,
+		claimSubmissionID:    consensusAbi.Events["ClaimSubmission"].ID,
+		claimAcceptanceID:    consensusAbi.Events["ClaimAcceptance"].ID,
+	}, nil
+}
+
+// String implements supervisor.Worker.
+func (x *ClaimIndexer) String() string {
+	return "ClaimIndexer"
+}
+
+// Start implements supervisor.Worker.
+func (x *ClaimIndexer) Start(ctx context.Context, ready chan<- struct{}) error {
+	if x.Client == nil {
+		slog.Info("ClaimIndexer connecting to", "rpcUrl", x.RpcUrl)
+		client, err := ethclient.DialContext(ctx, x.RpcUrl)
+		if err != nil {
+			return fmt.Errorf("claim indexer: dial: %w", err)
+		}
+		defer client.Close()
+		x.Client = client
+		if x.EpochLength == 0 {
+			err := x.readEpochLength(ctx, client)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if x.EpochLength == 0 {
+		return fmt.Errorf("claim indexer: missing epoch length")
+	}
+	ready <- struct{}{}
+	for {
+		err := x.Sync(ctx)
+		if err != nil {
+			slog.Error("ClaimIndexer sync error", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(x.PollInterval):
+		}
+	}
+}
+
+func (x *ClaimIndexer) readEpochLength(ctx context.Context, client bind.ContractCaller) error {
+	consensus, err := contracts.NewIConsensusCaller(x.ConsensusAddress, client)
+	if err != nil {
+		return err
+	}
+	epochLength, err := consensus.GetEpochLength(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("claim indexer: epoch length: %w", err)
+	}
+	x.EpochLength = epochLength.Uint64()
+	slog.Info("ClaimIndexer epoch length", "epochLength", x.EpochLength)
+	return nil
+}
+
+// Sync indexes the claims from the last checkpoint up to the latest
+// confirmed block
+func (x *ClaimIndexer) Sync(ctx context.Context) error {
+	lastBlock, err := x.CheckpointRepository.GetLastBlock(ctx, ClaimCheckpointName)
+	if err != nil {
+		return err
+	}
+	return syncBlockRanges(ctx, x.Client, ClaimCheckpointName, lastBlock,
+		x.FromBlock, x.ChunkSize, x.Confirmations, x.IndexRange)
+}
+
+// IndexRange stores the claims of the block range and its checkpoint
+// in the same transaction
+func (x *ClaimIndexer) IndexRange(ctx context.Context, from uint64, to uint64) error {
+	slog.Debug("ClaimIndexer indexing", "from", from, "to", to)
+	logs, err := x.Client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{x.ConsensusAddress},
+		Topics:    [][]common.Hash{{x.claimSubmissionID, x.claimAcceptanceID}},
+	})
+	if err != nil {
+		return err
+	}
+	return indexInTransaction(ctx, x.ClaimRepository.Db, x.CheckpointRepository,
+		[]string{ClaimCheckpointName}, to, func(txCtx context.Context) error {
+			return x.handleLogs(txCtx, logs)
+		})
+}
+
+func (x *ClaimIndexer) handleLogs(ctx context.Context, logs []types.Log) error {
+	for _, vLog := range logs {
+		if len(vLog.Topics) == 0 {
+			continue
+		}
+		var err error
+		switch vLog.Topics[0] {
+		case x.claimSubmissionID:
+			err = x.handleClaimSubmission(ctx, vLog)
+		case x.claimAcceptanceID:
+			err = x.handleClaimAcceptance(ctx, vLog)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *ClaimIndexer) isApplication(address common.Address) bool {
+	if len(x.ApplicationAddresses) == 0 {
+		return true
+	}
+	for _, app := range x.ApplicationAddresses {
+		if app == address {
+			return true
+		}
+	}
+	return false
+}
+
+// newClaim ties the claim to its epoch, which ends at the last processed block
+func (x *ClaimIndexer) newClaim(
+	appContract common.Address,
+	lastProcessedBlockNumber *big.Int,
+	claimHash [32]byte,
+	vLog types.Log,
+) model.ConvenienceClaim {
+	lastBlock := lastProcessedBlockNumber.Uint64()
+	epochIndex := lastBlock / x.EpochLength
+	return model.ConvenienceClaim{
+		AppContract:     appContract.Hex(),
+		EpochIndex:      epochIndex,
+		FirstBlock:      epochIndex * x.EpochLength,
+		LastBlock:       lastBlock,
+		ClaimHash:       common.Hash(claimHash).Hex(),
+		TransactionHash: vLog.TxHash.Hex(),
+		BlockNumber:     vLog.BlockNumber,
+	}
+}
+
+func (x *ClaimIndexer) handleClaimSubmission(ctx context.Context, vLog types.Log) error {
+	event, err := x.consensus.ParseClaimSubmission(vLog)
+	if err != nil {
+		return err
+	}
+	if !x.isApplication(event.AppContract) {
+		return nil
+	}
+	claim := x.newClaim(event.AppContract, event.LastProcessedBlockNumber, event.Claim, vLog)
+	claim.Submitter = event.Submitter.Hex()
+	slog.Debug("ClaimIndexer claim submitted",
+		"appContract", claim.AppContract,
+		"epochIndex", claim.EpochIndex,
+		"submitter", claim.Submitter,
+	)
+	return x.ClaimRepository.Create(ctx, claim)
+}
+
+func (x *ClaimIndexer) handleClaimAcceptance(ctx context.Context, vLog types.Log) error {
+	event, err := x.consensus.ParseClaimAcceptance(vLog)
+	if err != nil {
+		return err
+	}
+	if !x.isApplication(event.AppContract) {
+		return nil
+	}
+	claim := x.newClaim(event.AppContract, event.LastProcessedBlockNumber, event.Claim, vLog)
+	slog.Debug("ClaimIndexer claim accepted",
+		"appContract", claim.AppContract,
+		"epochIndex", claim.EpochIndex,
+	)
+	return x.ClaimRepository.SetAccepted(ctx, claim)
+}
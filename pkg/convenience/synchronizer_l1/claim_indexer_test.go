@@ -0,0 +1,136 @@
+package synchronizerl1
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+const epochLength = 10
+
+var validator = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+
+type ClaimIndexerSuite struct {
+	suite.Suite
+	ctx         context.Context
+	dbFactory   *commons.DbFactory
+	container   *convenience.Container
+	chain       *testChain
+	consensus   common.Address
+	application common.Address
+	indexer     *ClaimIndexer
+}
+
+func TestClaimIndexerSuite(t *testing.T) {
+	suite.Run(t, new(ClaimIndexerSuite))
+}
+
+func (s *ClaimIndexerSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.ctx = context.Background()
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("claimindexer.sqlite3")
+	s.container = convenience.NewContainer(*db, false)
+
+	s.chain = newTestChain(s.Require())
+	s.consensus = s.chain.deployLogEmitter(log23EmitterBin)
+	s.application = common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")
+
+	var err error
+	s.indexer, err = NewClaimIndexer(
+		"",
+		s.consensus,
+		[]common.Address{s.application},
+		epochLength,
+		0,
+		s.container.GetClaimRepository(),
+		s.container.GetL1CheckpointRepository(),
+	)
+	s.Require().NoError(err)
+	s.indexer.Client = s.chain.backend.Client()
+	s.indexer.ChunkSize = 2
+}
+
+func (s *ClaimIndexerSuite) TearDownTest() {
+	s.NoError(s.chain.backend.Close())
+	s.dbFactory.Cleanup()
+}
+
+func (s *ClaimIndexerSuite) emitClaimEvent(name string, topics []common.Hash, lastBlock uint64, claim common.Hash) common.Hash {
+	consensusAbi, err := contracts.IConsensusMetaData.GetAbi()
+	s.Require().NoError(err)
+	event := consensusAbi.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(new(big.Int).SetUint64(lastBlock), claim)
+	s.Require().NoError(err)
+	topics = append([]common.Hash{event.ID}, topics...)
+	// the emitter reads the number of topics from the first word
+	topicCount := common.BigToHash(big.NewInt(int64(len(topics))))
+	return s.chain.emit(s.consensus, append([]common.Hash{topicCount}, topics...), data)
+}
+
+func (s *ClaimIndexerSuite) submitClaim(app common.Address, lastBlock uint64, claim common.Hash) common.Hash {
+	return s.emitClaimEvent("ClaimSubmission", []common.Hash{
+		common.BytesToHash(validator.Bytes()),
+		common.BytesToHash(app.Bytes()),
+	}, lastBlock, claim)
+}
+
+func (s *ClaimIndexerSuite) acceptClaim(app common.Address, lastBlock uint64, claim common.Hash) {
+	s.emitClaimEvent("ClaimAcceptance", []common.Hash{
+		common.BytesToHash(app.Bytes()),
+	}, lastBlock, claim)
+}
+
+func (s *ClaimIndexerSuite) TestIndexClaims() {
+	claim := common.HexToHash("0xaa")
+	txHash := s.submitClaim(s.application, 19, claim)          // nolint
+	s.acceptClaim(s.application, 19, claim)                    // nolint
+	s.submitClaim(s.application, 29, common.HexToHash("0xbb")) // nolint
+
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	repository := s.container.GetClaimRepository()
+	page, err := repository.FindAll(s.ctx, nil, nil, nil, nil, &s.application)
+	s.Require().NoError(err)
+	s.Require().Len(page.Rows, 2)
+	accepted := page.Rows[0]
+	s.Equal(uint64(1), accepted.EpochIndex)
+	s.Equal(uint64(10), accepted.FirstBlock)
+	s.Equal(uint64(19), accepted.LastBlock)
+	s.Equal(claim.Hex(), accepted.ClaimHash)
+	s.Equal(validator.Hex(), accepted.Submitter)
+	s.Equal(txHash.Hex(), accepted.TransactionHash)
+	s.True(accepted.Accepted)
+	s.Equal(uint64(2), page.Rows[1].EpochIndex)
+	s.False(page.Rows[1].Accepted)
+
+	found, err := repository.FindAcceptedByBlock(s.ctx, s.application, 12) // nolint
+	s.Require().NoError(err)
+	s.Require().NotNil(found)
+	found, err = repository.FindAcceptedByBlock(s.ctx, s.application, 25) // nolint
+	s.Require().NoError(err)
+	s.Nil(found)
+
+	latest, err := s.chain.backend.Client().BlockNumber(s.ctx)
+	s.Require().NoError(err)
+	lastBlock, err := s.container.GetL1CheckpointRepository().GetLastBlock(s.ctx, ClaimCheckpointName)
+	s.Require().NoError(err)
+	s.Equal(latest, *lastBlock)
+}
+
+func (s *ClaimIndexerSuite) TestIgnoreOtherApps() {
+	other := common.HexToAddress("0x000028bb862fb57e8a2bcd567a2e929a0be56a5e")
+	s.submitClaim(other, 9, common.HexToHash("0xaa")) // nolint
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	count, err := s.container.GetClaimRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(0), count)
+}
@@ -0,0 +1,443 @@
+// This package indexes the rollups events straight from an Ethereum RPC,
+// so the read API can run without access to the node database.
+package synchronizerl1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
+	synchronizernode "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/synchronizer_node"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// CheckpointName identifies the indexer in the l1_checkpoints table,
+	// followed by the application when the indexer has ApplicationAddresses
+	CheckpointName      = "l1_indexer"
+	DefaultChunkSize    = 1000
+	DefaultPollInterval = 5 * time.Second
+)
+
+// L1Client is the part of the ethclient used by the indexer
+type L1Client interface {
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+type L1Indexer struct {
+	RpcUrl          string
+	Client          L1Client
+	InputBoxAddress common.Address
+	// ApplicationAddresses restricts the indexed inputs and is where the
+	// OutputExecuted events are read from. When empty, every app input is indexed
+	// but the executions are not.
+	ApplicationAddresses []common.Address
+	FromBlock            uint64
+	ChunkSize            uint64
+	// Confirmations is how many blocks the indexed blocks are behind the latest,
+	// so the events removed by a reorg of the recent blocks are never indexed
+	Confirmations        uint64
+	PollInterval         time.Duration
+	InputRepository      *repository.InputRepository
+	VoucherRepository    *repository.VoucherRepository
+	CheckpointRepository *repository.L1CheckpointRepository
+	// ExecutionResolver is optional, without it the vouchers have no execution details
+	ExecutionResolver services.ExecutionResolver
+	// WebhookRepository is optional, without it no voucher.executed event is queued
+	WebhookRepository *repository.WebhookRepository
+	inputBox          *contracts.InputBoxFilterer
+	application       *contracts.ApplicationFilterer
+	inputCreator      *synchronizernode.SynchronizerInputCreator
+	outputDecoder     *synchronizernode.AbiDecoder
+	inputAddedID      common.Hash
+	outputExecutedID  common.Hash
+}
+
+func NewL1Indexer(
+	rpcUrl string,
+	inputBoxAddress common.Address,
+	applicationAddresses []common.Address,
+	fromBlock uint64,
+	inputRepository *repository.InputRepository,
+	voucherRepository *repository.VoucherRepository,
+	checkpointRepository *repository.L1CheckpointRepository,
+) (*L1Indexer, error) {
+	inputBoxAbi, err := contracts.InputBoxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	applicationAbi, err := contracts.ApplicationMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	inputsAbi, err := contracts.InputsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	// the filterers are only used to parse the logs
+	inputBox, err := contracts.NewInputBoxFilterer(inputBoxAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	application, err := contracts.NewApplicationFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1Indexer{
+		RpcUrl:               rpcUrl,
+		InputBoxAddress:      inputBoxAddress,
+		ApplicationAddresses: applicationAddresses,
+		FromBlock:            fromBlock,
+		ChunkSize:            DefaultChunkSize,
+		PollInterval:         DefaultPollInterval,
+		InputRepository:      inputRepository,
+		VoucherRepository:    voucherRepository,
+		CheckpointRepository: checkpointRepository,
+		inputBox:             inputBox,
+		application:          application,
+		inputCreator: &synchronizernode.SynchronizerInputCreator{
+			AbiDecoder: synchronizernode.NewAbiDecoder(inputsAbi),
+		},
+		outputDecoder:    synchronizernode.NewAbiDecoder(outputsAbi),
+		inputAddedID:     inputBoxAbi.Events["InputAdded"].ID,
+		outputExecutedID: applicationAbi.Events["OutputExecuted"].ID,
+	}, nil
+}
+
+// String implements supervisor.Worker.
+func (x *L1Indexer) String() string {
+	return "L1Indexer"
+}
+
+// Start implements supervisor.Worker.
+func (x *L1Indexer) Start(ctx context.Context, ready chan<- struct{}) error {
+	if x.Client == nil {
+		slog.Info("L1Indexer connecting to", "rpcUrl", x.RpcUrl)
+		client, err := ethclient.DialContext(ctx, x.RpcUrl)
+		if err != nil {
+			return fmt.Errorf("l1 indexer: dial: %w", err)
+		}
+		defer client.Close()
+		x.Client = client
+	}
+	ready <- struct{}{}
+	for {
+		err := x.Sync(ctx)
+		if err != nil {
+			slog.Error("L1Indexer sync error", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(x.PollInterval):
+		}
+	}
+}
+
+// Sync indexes from the oldest checkpoint up to the latest confirmed block,
+// one chunk of blocks at a time
+func (x *L1Indexer) Sync(ctx context.Context) error {
+	lastBlocks, err := x.lastBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	// from the oldest checkpoint, or the FromBlock when an application was never indexed
+	var oldest *uint64
+	if len(lastBlocks) == len(x.checkpointNames()) {
+		for _, lastBlock := range lastBlocks {
+			if oldest == nil || *lastBlock < *oldest {
+				oldest = lastBlock
+			}
+		}
+	}
+	err = syncBlockRanges(ctx, x.Client, CheckpointName, oldest,
+		x.FromBlock, x.ChunkSize, x.Confirmations, x.IndexRange)
+	if err != nil {
+		return err
+	}
+	return services.ResolveExecutions(ctx, x.ExecutionResolver, x.VoucherRepository)
+}
+
+func (x *L1Indexer) checkpointName(appContract common.Address) string {
+	return fmt.Sprintf("%s:%s", CheckpointName, appContract.Hex())
+}
+
+// checkpointNames are one per application, so an application added later is
+// indexed from the FromBlock without the others being indexed again
+func (x *L1Indexer) checkpointNames() []string {
+	if len(x.ApplicationAddresses) == 0 {
+		return []string{CheckpointName}
+	}
+	names := make([]string, len(x.ApplicationAddresses))
+	for i, app := range x.ApplicationAddresses {
+		names[i] = x.checkpointName(app)
+	}
+	return names
+}
+
+// lastBlocks returns the last block indexed for each application already
+// indexed. The applications indexed before the checkpoints were per
+// application resume from the shared checkpoint.
+func (x *L1Indexer) lastBlocks(ctx context.Context) (map[common.Address]*uint64, error) {
+	lastBlocks := map[common.Address]*uint64{}
+	shared, err := x.CheckpointRepository.GetLastBlock(ctx, CheckpointName)
+	if err != nil {
+		return nil, err
+	}
+	if len(x.ApplicationAddresses) == 0 {
+		if shared != nil {
+			lastBlocks[common.Address{}] = shared
+		}
+		return lastBlocks, nil
+	}
+	for _, app := range x.ApplicationAddresses {
+		lastBlock, err := x.CheckpointRepository.GetLastBlock(ctx, x.checkpointName(app))
+		if err != nil {
+			return nil, err
+		}
+		if lastBlock == nil {
+			lastBlock = shared
+		}
+		if lastBlock != nil {
+			lastBlocks[app] = lastBlock
+		}
+	}
+	return lastBlocks, nil
+}
+
+// IndexRange stores the events of the block range and the checkpoints
+// in the same transaction. The events of the applications that already
+// indexed the block are skipped. The execution details are resolved
+// after the commit, from the blocks of the logs.
+func (x *L1Indexer) IndexRange(ctx context.Context, from uint64, to uint64) error {
+	slog.Debug("L1Indexer indexing", "from", from, "to", to)
+	lastBlocks, err := x.lastBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	addresses := append([]common.Address{x.InputBoxAddress}, x.ApplicationAddresses...)
+	logs, err := x.Client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: addresses,
+		Topics:    [][]common.Hash{{x.inputAddedID, x.outputExecutedID}},
+	})
+	if err != nil {
+		return err
+	}
+	var executions []execution
+	err = indexInTransaction(ctx, x.InputRepository.Db, x.CheckpointRepository,
+		x.checkpointNames(), to, func(txCtx context.Context) error {
+			executions, err = x.handleLogs(txCtx, logs, lastBlocks)
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	for _, executed := range executions {
+		err = services.ResolveExecution(ctx, x.ExecutionResolver,
+			x.VoucherRepository, executed.voucher, executed.blockHash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execution is a voucher executed by a log of the block
+type execution struct {
+	voucher   *model.ConvenienceVoucher
+	blockHash common.Hash
+}
+
+// handleLogs returns the voucher executions it stored
+func (x *L1Indexer) handleLogs(
+	ctx context.Context, logs []types.Log, lastBlocks map[common.Address]*uint64,
+) ([]execution, error) {
+	indexed := func(appContract common.Address, blockNumber uint64) bool {
+		if len(x.ApplicationAddresses) == 0 {
+			appContract = common.Address{}
+		}
+		lastBlock := lastBlocks[appContract]
+		return lastBlock != nil && blockNumber <= *lastBlock
+	}
+	var executions []execution
+	for _, vLog := range logs {
+		if len(vLog.Topics) == 0 {
+			continue
+		}
+		var err error
+		switch {
+		case vLog.Address == x.InputBoxAddress && vLog.Topics[0] == x.inputAddedID:
+			err = x.handleInputAdded(ctx, vLog, indexed)
+		case vLog.Topics[0] == x.outputExecutedID && x.isApplication(vLog.Address):
+			if indexed(vLog.Address, vLog.BlockNumber) {
+				continue
+			}
+			var executed *model.ConvenienceVoucher
+			executed, err = x.handleOutputExecuted(ctx, vLog)
+			if executed != nil {
+				executions = append(executions, execution{executed, vLog.BlockHash})
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return executions, nil
+}
+
+func (x *L1Indexer) isApplication(address common.Address) bool {
+	for _, app := range x.ApplicationAddresses {
+		if app == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (x *L1Indexer) handleInputAdded(
+	ctx context.Context,
+	vLog types.Log,
+	indexed func(appContract common.Address, blockNumber uint64) bool,
+) error {
+	event, err := x.inputBox.ParseInputAdded(vLog)
+	if err != nil {
+		return err
+	}
+	if len(x.ApplicationAddresses) > 0 && !x.isApplication(event.AppContract) {
+		return nil
+	}
+	if indexed(event.AppContract, vLog.BlockNumber) {
+		return nil
+	}
+	// the event carries the same EvmAdvance encoding the node stores.
+	// The outcome of the input is only known by the node, so its status
+	// is NONE, which is shown as UNPROCESSED, and is never updated.
+	input, err := x.inputCreator.GetAdvanceInputFromMap(synchronizernode.RawInput{
+		Index:         event.Index.Uint64(),
+		RawData:       event.Input,
+		BlockNumber:   vLog.BlockNumber,
+		Status:        "NONE",
+		TransactionId: event.Index.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+	input.TransactionHash = vLog.TxHash.Hex()
+	input.LogIndex = int(vLog.Index)
+	input.BlockHash = vLog.BlockHash.Hex()
+	slog.Debug("L1Indexer input added",
+		"appContract", event.AppContract.Hex(),
+		"index", event.Index,
+	)
+	_, err = x.InputRepository.Create(ctx, *input)
+	return err
+}
+
+// handleOutputExecuted returns the executed voucher, nil when it is ignored
+func (x *L1Indexer) handleOutputExecuted(ctx context.Context, vLog types.Log) (*model.ConvenienceVoucher, error) {
+	event, err := x.application.ParseOutputExecuted(vLog)
+	if err != nil {
+		return nil, err
+	}
+	appContract := vLog.Address
+	voucher, err := x.VoucherRepository.FindVoucherByOutputIndexAndAppContract(
+		ctx, event.OutputIndex, &appContract)
+	if err != nil {
+		return nil, err
+	}
+	if voucher == nil {
+		// without the node the executed voucher is only known by its event,
+		// which has no input index. It is created as not executed yet and
+		// marked below like the synced ones.
+		voucher, err = x.getConvenienceVoucher(appContract, event)
+		if err != nil {
+			slog.Warn("L1Indexer ignoring executed output",
+				"appContract", appContract.Hex(),
+				"outputIndex", event.OutputIndex,
+				"error", err,
+			)
+			return nil, nil
+		}
+		_, err = x.VoucherRepository.CreateVoucher(ctx, voucher)
+		if err != nil {
+			return nil, err
+		}
+	}
+	slog.Debug("L1Indexer output executed",
+		"appContract", appContract.Hex(),
+		"outputIndex", event.OutputIndex,
+	)
+	executed := &model.ConvenienceVoucher{
+		AppContract:       appContract,
+		InputIndex:        voucher.InputIndex,
+		InputIndexUnknown: voucher.InputIndexUnknown,
+		OutputIndex:       event.OutputIndex,
+		TransactionHash:   vLog.TxHash.Hex(),
+		ExecutedBlock:     vLog.BlockNumber,
+	}
+	ok, err := services.SetVoucherExecuted(ctx, x.VoucherRepository, x.WebhookRepository, executed)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		slog.Debug("L1Indexer voucher already executed",
+			"appContract", appContract.Hex(),
+			"outputIndex", event.OutputIndex,
+		)
+		return nil, nil
+	}
+	// the block is known from the log, the time and the sender
+	// are resolved after the commit
+	err = x.VoucherRepository.SetExecutionDetails(ctx, executed)
+	if err != nil {
+		return nil, err
+	}
+	return executed, nil
+}
+
+func (x *L1Indexer) getConvenienceVoucher(
+	appContract common.Address,
+	event *contracts.ApplicationOutputExecuted,
+) (*model.ConvenienceVoucher, error) {
+	if len(event.Output) < 4 { // nolint
+		return nil, fmt.Errorf("output too short")
+	}
+	data, err := x.outputDecoder.GetMapRaw(event.Output)
+	if err != nil {
+		return nil, err
+	}
+	destination, ok := data["destination"].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("destination not found %v", data)
+	}
+	value, ok := data["value"].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("value not found %v", data)
+	}
+	return &model.ConvenienceVoucher{
+		Destination:      destination,
+		Payload:          "0x" + common.Bytes2Hex(event.Output),
+		OutputIndex:      event.OutputIndex,
+		ProofOutputIndex: event.OutputIndex,
+		AppContract:      appContract,
+		Value:            value.String(),
+
+		InputIndexUnknown: true,
+	}, nil
+}
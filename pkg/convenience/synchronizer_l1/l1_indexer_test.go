@@ -0,0 +1,254 @@
+package synchronizerl1
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	synchronizernode "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/synchronizer_node"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+)
+
+var msgSender = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+var destination = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+type L1IndexerSuite struct {
+	suite.Suite
+	ctx         context.Context
+	dbFactory   *commons.DbFactory
+	container   *convenience.Container
+	chain       *testChain
+	inputBox    common.Address
+	application common.Address
+	indexer     *L1Indexer
+}
+
+func TestL1IndexerSuite(t *testing.T) {
+	suite.Run(t, new(L1IndexerSuite))
+}
+
+func (s *L1IndexerSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.ctx = context.Background()
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("l1indexer.sqlite3")
+	s.container = convenience.NewContainer(*db, false)
+
+	s.chain = newTestChain(s.Require())
+	s.inputBox = s.chain.deployLogEmitter(log3EmitterBin)
+	s.application = s.chain.deployLogEmitter(log1EmitterBin)
+
+	var err error
+	s.indexer, err = NewL1Indexer(
+		"",
+		s.inputBox,
+		[]common.Address{s.application},
+		0,
+		s.container.GetInputRepository(),
+		s.container.GetVoucherRepository(),
+		s.container.GetL1CheckpointRepository(),
+	)
+	s.Require().NoError(err)
+	s.indexer.Client = s.chain.backend.Client()
+	s.indexer.ChunkSize = 2
+}
+
+func (s *L1IndexerSuite) TearDownTest() {
+	s.NoError(s.chain.backend.Close())
+	s.dbFactory.Cleanup()
+}
+
+func (s *L1IndexerSuite) addInput(index int64, payload []byte) common.Hash {
+	inputsAbi, err := contracts.InputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	input, err := inputsAbi.Pack("EvmAdvance",
+		big.NewInt(1337), // nolint
+		s.application,
+		msgSender,
+		big.NewInt(1),
+		big.NewInt(1700000000), // nolint
+		big.NewInt(0),
+		big.NewInt(index),
+		payload,
+	)
+	s.Require().NoError(err)
+	inputBoxAbi, err := contracts.InputBoxMetaData.GetAbi()
+	s.Require().NoError(err)
+	event := inputBoxAbi.Events["InputAdded"]
+	data, err := event.Inputs.NonIndexed().Pack(input)
+	s.Require().NoError(err)
+	return s.chain.emit(s.inputBox, []common.Hash{
+		event.ID,
+		common.BytesToHash(s.application.Bytes()),
+		common.BigToHash(big.NewInt(index)),
+	}, data)
+}
+
+func (s *L1IndexerSuite) executeVoucher(outputIndex uint64) common.Hash {
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	output, err := outputsAbi.Pack("Voucher", destination, big.NewInt(10), []byte{0x12, 0x34}) // nolint
+	s.Require().NoError(err)
+	applicationAbi, err := contracts.ApplicationMetaData.GetAbi()
+	s.Require().NoError(err)
+	event := applicationAbi.Events["OutputExecuted"]
+	data, err := event.Inputs.Pack(outputIndex, output)
+	s.Require().NoError(err)
+	return s.chain.emit(s.application, []common.Hash{event.ID}, data)
+}
+
+func (s *L1IndexerSuite) TestIndexInputsAndExecutions() {
+	s.addInput(0, []byte{0xde, 0xad})
+	s.addInput(1, []byte{0xbe, 0xef})
+	txHash := s.executeVoucher(3) // nolint
+
+	err := s.indexer.Sync(s.ctx)
+	s.Require().NoError(err)
+
+	count, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+	input, err := s.container.GetInputRepository().FindByIndexAndAppContract(s.ctx, 1, &s.application)
+	s.Require().NoError(err)
+	s.Equal("0xbeef", input.Payload)
+	s.Equal(msgSender, input.MsgSender)
+	s.Equal(model.CompletionStatusUnprocessed, input.Status)
+
+	voucher, err := s.container.GetVoucherRepository().FindVoucherByOutputIndexAndAppContract(s.ctx, 3, &s.application) // nolint
+	s.Require().NoError(err)
+	s.Require().NotNil(voucher)
+	s.True(voucher.Executed)
+	s.Equal(destination, voucher.Destination)
+	s.Equal("10", voucher.Value)
+	s.Equal(txHash.Hex(), voucher.TransactionHash)
+	s.True(voucher.InputIndexUnknown)
+
+	latest, err := s.chain.backend.Client().BlockNumber(s.ctx)
+	s.Require().NoError(err)
+	lastBlock, err := s.container.GetL1CheckpointRepository().GetLastBlock(s.ctx, s.indexer.checkpointName(s.application))
+	s.Require().NoError(err)
+	s.Equal(latest, *lastBlock)
+}
+
+func (s *L1IndexerSuite) TestInputTransaction() {
+	txHash := s.addInput(0, []byte{0xde, 0xad})
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	receipt, err := s.chain.backend.Client().TransactionReceipt(s.ctx, txHash)
+	s.Require().NoError(err)
+	input, err := s.container.GetInputRepository().FindByIndexAndAppContract(s.ctx, 0, &s.application)
+	s.Require().NoError(err)
+	s.Equal(txHash.Hex(), input.TransactionHash)
+	s.Equal(receipt.BlockHash.Hex(), input.BlockHash)
+	s.Equal(int(receipt.Logs[0].Index), input.LogIndex)
+}
+
+func (s *L1IndexerSuite) TestExecutionDetails() {
+	s.indexer.ExecutionResolver = synchronizernode.NewExecutionResolver(s.chain.backend.Client())
+	txHash := s.executeVoucher(3) // nolint
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	receipt, err := s.chain.backend.Client().TransactionReceipt(s.ctx, txHash)
+	s.Require().NoError(err)
+	header, err := s.chain.backend.Client().HeaderByNumber(s.ctx, receipt.BlockNumber)
+	s.Require().NoError(err)
+	voucher, err := s.container.GetVoucherRepository().FindVoucherByOutputIndexAndAppContract(s.ctx, 3, &s.application) // nolint
+	s.Require().NoError(err)
+	s.Equal(receipt.BlockNumber.Uint64(), voucher.ExecutedBlock)
+	s.Equal(header.Time, voucher.ExecutedAt)
+	s.Equal(crypto.PubkeyToAddress(s.chain.key.PublicKey).Hex(), voucher.ExecutedBy)
+}
+
+func (s *L1IndexerSuite) TestResumeFromCheckpoint() {
+	s.addInput(0, []byte{0x01})
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	s.addInput(1, []byte{0x02})
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	count, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+}
+
+func (s *L1IndexerSuite) TestIgnoreOtherApps() {
+	other := common.HexToAddress("0x000028bb862fb57e8a2bcd567a2e929a0be56a5e")
+	s.indexer.ApplicationAddresses = []common.Address{other}
+	s.addInput(0, []byte{0x01})
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	count, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(0), count)
+}
+
+func (s *L1IndexerSuite) TestCheckpointPerApplication() {
+	other := common.HexToAddress("0x000028bb862fb57e8a2bcd567a2e929a0be56a5e")
+	s.indexer.ApplicationAddresses = []common.Address{other}
+	s.addInput(0, []byte{0x01})
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	// the new application is indexed from the FromBlock
+	s.indexer.ApplicationAddresses = []common.Address{other, s.application}
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+	s.addInput(1, []byte{0x02})
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	count, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+	latest, err := s.chain.backend.Client().BlockNumber(s.ctx)
+	s.Require().NoError(err)
+	for _, app := range s.indexer.ApplicationAddresses {
+		lastBlock, err := s.container.GetL1CheckpointRepository().GetLastBlock(s.ctx, s.indexer.checkpointName(app))
+		s.Require().NoError(err)
+		s.Equal(latest, *lastBlock)
+	}
+}
+
+func (s *L1IndexerSuite) TestResumeFromSharedCheckpoint() {
+	s.addInput(0, []byte{0x01})
+	latest, err := s.chain.backend.Client().BlockNumber(s.ctx)
+	s.Require().NoError(err)
+	// saved by the versions with a single checkpoint
+	s.Require().NoError(s.container.GetL1CheckpointRepository().SaveLastBlock(s.ctx, CheckpointName, latest))
+	s.Require().NoError(s.indexer.Sync(s.ctx))
+
+	count, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(0), count)
+}
+
+func (s *L1IndexerSuite) TestConfirmations() {
+	s.addInput(0, []byte{0x01})
+	s.addInput(1, []byte{0x02})
+	s.indexer.Confirmations = 1
+
+	err := s.indexer.Sync(s.ctx)
+	s.Require().NoError(err)
+
+	// the last input is in the latest block, which is not confirmed yet
+	count, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count)
+	latest, err := s.chain.backend.Client().BlockNumber(s.ctx)
+	s.Require().NoError(err)
+	lastBlock, err := s.container.GetL1CheckpointRepository().GetLastBlock(s.ctx, s.indexer.checkpointName(s.application))
+	s.Require().NoError(err)
+	s.Equal(latest-1, *lastBlock)
+
+	s.chain.backend.Commit()
+	err = s.indexer.Sync(s.ctx)
+	s.Require().NoError(err)
+	count, err = s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+}
@@ -0,0 +1,67 @@
+package synchronizernode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ExecutionClient is the part of the ethclient used to resolve the executions
+type ExecutionClient interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// ExecutionResolver reads the block and the sender of the voucher execution
+// transactions, which the node database does not keep
+type ExecutionResolver struct {
+	Client ExecutionClient
+}
+
+func NewExecutionResolver(client ExecutionClient) *ExecutionResolver {
+	return &ExecutionResolver{Client: client}
+}
+
+func NewExecutionResolverFromUrl(ctx context.Context, rpcUrl string) (*ExecutionResolver, error) {
+	client, err := ethclient.DialContext(ctx, rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("dial to %v: %w", rpcUrl, err)
+	}
+	return NewExecutionResolver(client), nil
+}
+
+// Resolve implements services.ExecutionResolver. Without the blockHash
+// it is read from the receipt of the voucher transaction.
+func (r *ExecutionResolver) Resolve(
+	ctx context.Context, voucher *model.ConvenienceVoucher, blockHash common.Hash,
+) error {
+	txHash := common.HexToHash(voucher.TransactionHash)
+	if blockHash == (common.Hash{}) {
+		receipt, err := r.Client.TransactionReceipt(ctx, txHash)
+		if err != nil {
+			return fmt.Errorf("execution receipt %s: %w", txHash.Hex(), err)
+		}
+		blockHash = receipt.BlockHash
+	}
+	header, err := r.Client.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return fmt.Errorf("execution block %s: %w", blockHash.Hex(), err)
+	}
+	tx, _, err := r.Client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("execution transaction %s: %w", txHash.Hex(), err)
+	}
+	executor, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("execution sender %s: %w", txHash.Hex(), err)
+	}
+	voucher.ExecutedBlock = header.Number.Uint64()
+	voucher.ExecutedAt = header.Time
+	voucher.ExecutedBy = executor.Hex()
+	return nil
+}
@@ -0,0 +1,69 @@
+package synchronizernode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// InputLogResolver finds the InputAdded log of the inputs synced from the
+// node database, which only keeps their block number
+type InputLogResolver struct {
+	Client          ethereum.LogFilterer
+	InputBoxAddress common.Address
+	inputAddedID    common.Hash
+}
+
+func NewInputLogResolver(client ethereum.LogFilterer, inputBoxAddress common.Address) (*InputLogResolver, error) {
+	inputBoxAbi, err := contracts.InputBoxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &InputLogResolver{
+		Client:          client,
+		InputBoxAddress: inputBoxAddress,
+		inputAddedID:    inputBoxAbi.Events["InputAdded"].ID,
+	}, nil
+}
+
+func NewInputLogResolverFromUrl(
+	ctx context.Context, rpcUrl string, inputBoxAddress common.Address,
+) (*InputLogResolver, error) {
+	client, err := ethclient.DialContext(ctx, rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("dial to %v: %w", rpcUrl, err)
+	}
+	return NewInputLogResolver(client, inputBoxAddress)
+}
+
+// Resolve fills the L1 transaction, log index and block hash of the input
+func (r *InputLogResolver) Resolve(ctx context.Context, input *model.AdvanceInput) error {
+	blockNumber := new(big.Int).SetUint64(input.BlockNumber)
+	logs, err := r.Client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: blockNumber,
+		ToBlock:   blockNumber,
+		Addresses: []common.Address{r.InputBoxAddress},
+		Topics: [][]common.Hash{
+			{r.inputAddedID},
+			{common.BytesToHash(input.AppContract.Bytes())},
+			{common.BigToHash(big.NewInt(int64(input.InputBoxIndex)))},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		return fmt.Errorf("InputAdded log not found: app_contract %s; index %d; block %d",
+			input.AppContract.Hex(), input.InputBoxIndex, input.BlockNumber)
+	}
+	input.TransactionHash = logs[0].TxHash.Hex()
+	input.LogIndex = int(logs[0].Index)
+	input.BlockHash = logs[0].BlockHash.Hex()
+	return nil
+}
@@ -0,0 +1,119 @@
+package synchronizernode
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeLogFilterer struct {
+	logs      []types.Log
+	err       error
+	lastQuery ethereum.FilterQuery
+}
+
+func (f *fakeLogFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.lastQuery = q
+	return f.logs, f.err
+}
+
+func (f *fakeLogFilterer) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, nil
+}
+
+type InputLogResolverSuite struct {
+	suite.Suite
+	client   *fakeLogFilterer
+	resolver *InputLogResolver
+}
+
+func TestInputLogResolverSuite(t *testing.T) {
+	suite.Run(t, new(InputLogResolverSuite))
+}
+
+func (s *InputLogResolverSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.client = &fakeLogFilterer{}
+	var err error
+	s.resolver, err = NewInputLogResolver(s.client, common.HexToAddress("0x593E5BCf894D6829Dd26D0810DA7F064406aebB6"))
+	s.Require().NoError(err)
+}
+
+func (s *InputLogResolverSuite) TestResolve() {
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	s.client.logs = []types.Log{{
+		TxHash:    common.HexToHash("0xaa"),
+		BlockHash: common.HexToHash("0xbb"),
+		Index:     3,
+	}}
+	input := &model.AdvanceInput{AppContract: app, InputBoxIndex: 7, BlockNumber: 100}
+	err := s.resolver.Resolve(context.Background(), input)
+	s.Require().NoError(err)
+	s.Equal(common.HexToHash("0xaa").Hex(), input.TransactionHash)
+	s.Equal(common.HexToHash("0xbb").Hex(), input.BlockHash)
+	s.Equal(3, input.LogIndex)
+
+	s.Equal(big.NewInt(100), s.client.lastQuery.FromBlock)
+	s.Equal(big.NewInt(100), s.client.lastQuery.ToBlock)
+	s.Equal(common.BytesToHash(app.Bytes()), s.client.lastQuery.Topics[1][0])
+	s.Equal(common.BigToHash(big.NewInt(7)), s.client.lastQuery.Topics[2][0])
+}
+
+func (s *InputLogResolverSuite) TestLogNotFound() {
+	input := &model.AdvanceInput{InputBoxIndex: 7, BlockNumber: 100}
+	err := s.resolver.Resolve(context.Background(), input)
+	s.Error(err)
+	s.Equal("", input.TransactionHash)
+}
+
+func (s *InputLogResolverSuite) TestBackfillTransactions() {
+	ctx := context.Background()
+	dbFactory := commons.NewDbFactory()
+	defer dbFactory.Cleanup()
+	db := dbFactory.CreateDb("backfill.sqlite3")
+	inputRepository := convenience.NewContainer(*db, false).GetInputRepository()
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	creator := &SynchronizerInputCreator{
+		InputRepository:  inputRepository,
+		InputLogResolver: s.resolver,
+	}
+
+	// the RPC fails, the input is created without its transaction
+	s.client.err = errors.New("rpc unavailable")
+	input := &model.AdvanceInput{ID: "0", AppContract: app, BlockNumber: 100}
+	creator.setTransaction(ctx, input)
+	_, err := inputRepository.Create(ctx, *input)
+	s.Require().NoError(err)
+	err = creator.BackfillTransactions(ctx)
+	s.Require().NoError(err)
+	saved, err := inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Empty(saved.TransactionHash)
+
+	s.client.err = nil
+	s.client.logs = []types.Log{{
+		TxHash:    common.HexToHash("0xaa"),
+		BlockHash: common.HexToHash("0xbb"),
+		Index:     3,
+	}}
+	err = creator.BackfillTransactions(ctx)
+	s.Require().NoError(err)
+	saved, err = inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Equal(common.HexToHash("0xaa").Hex(), saved.TransactionHash)
+	s.Equal(3, saved.LogIndex)
+
+	inputs, err := inputRepository.FindInputsWithoutTransaction(ctx, nil, BACKFILL_LIMIT)
+	s.Require().NoError(err)
+	s.Empty(inputs)
+}
@@ -0,0 +1,307 @@
+package synchronizernode
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture describes the node data used to feed a MemoryRawSource.
+//
+// Example (YAML):
+//
+//	chainId: 31337
+//	apps:
+//	  - address: "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+//	    inputs:
+//	      - msgSender: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
+//	        payload: "0xdeadbeef"
+//	        notices:
+//	          - payload: "0x1122"
+//	            proof: ["0x01...", "0x02..."]
+//	        vouchers:
+//	          - destination: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"
+//	            value: "1"
+//	            payload: "0x3344"
+//	            transactionHash: "0x62d1...tx hash"
+//	        reports:
+//	          - payload: "0x5566"
+type Fixture struct {
+	ChainID uint64       `json:"chainId" yaml:"chainId"`
+	Apps    []FixtureApp `json:"apps" yaml:"apps"`
+}
+
+type FixtureApp struct {
+	Address string         `json:"address" yaml:"address"`
+	Inputs  []FixtureInput `json:"inputs" yaml:"inputs"`
+}
+
+type FixtureInput struct {
+	MsgSender      string `json:"msgSender" yaml:"msgSender"`
+	Payload        string `json:"payload" yaml:"payload"`
+	BlockNumber    uint64 `json:"blockNumber" yaml:"blockNumber"`
+	BlockTimestamp int64  `json:"blockTimestamp" yaml:"blockTimestamp"`
+	// Status defaults to ACCEPTED
+	Status        string           `json:"status" yaml:"status"`
+	TransactionID string           `json:"transactionId" yaml:"transactionId"`
+	Vouchers      []FixtureVoucher `json:"vouchers" yaml:"vouchers"`
+	Notices       []FixtureNotice  `json:"notices" yaml:"notices"`
+	Reports       []FixtureReport  `json:"reports" yaml:"reports"`
+}
+
+type FixtureVoucher struct {
+	Destination string   `json:"destination" yaml:"destination"`
+	Value       string   `json:"value" yaml:"value"`
+	Payload     string   `json:"payload" yaml:"payload"`
+	Proof       []string `json:"proof" yaml:"proof"`
+	// TransactionHash is the hash of the transaction that executed the voucher,
+	// like the transactionHash of the API
+	TransactionHash string `json:"transactionHash" yaml:"transactionHash"`
+}
+
+type FixtureNotice struct {
+	Payload string   `json:"payload" yaml:"payload"`
+	Proof   []string `json:"proof" yaml:"proof"`
+}
+
+type FixtureReport struct {
+	Payload string `json:"payload" yaml:"payload"`
+}
+
+const defaultFixtureChainID = 31337
+
+// LoadFixtureFile reads a JSON or YAML fixture, based on the file extension
+func LoadFixtureFile(path string) (*Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture Fixture
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &fixture)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &fixture)
+	default:
+		return nil, fmt.Errorf("unsupported fixture format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// NewMemoryRawSourceFromFile creates a raw source fed by the fixture file
+func NewMemoryRawSourceFromFile(path string) (*MemoryRawSource, error) {
+	fixture, err := LoadFixtureFile(path)
+	if err != nil {
+		return nil, err
+	}
+	source := NewMemoryRawSource()
+	err = source.Load(*fixture)
+	if err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// Load encodes the fixture the same way the node stores its data
+func (m *MemoryRawSource) Load(fixture Fixture) error {
+	inputsAbi, err := contracts.InputsMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	chainID := fixture.ChainID
+	if chainID == 0 {
+		chainID = defaultFixtureChainID
+	}
+	for _, app := range fixture.Apps {
+		if !common.IsHexAddress(app.Address) {
+			return fmt.Errorf("invalid app address: %s", app.Address)
+		}
+		appContract := common.HexToAddress(app.Address)
+		outputIndex := uint64(0)
+		reportIndex := uint64(0)
+		for inputIndex, fInput := range app.Inputs {
+			payload, err := decodeFixtureHex(fInput.Payload)
+			if err != nil {
+				return err
+			}
+			blockNumber := fInput.BlockNumber
+			if blockNumber == 0 {
+				blockNumber = uint64(inputIndex + 1)
+			}
+			blockTimestamp := fInput.BlockTimestamp
+			if blockTimestamp == 0 {
+				blockTimestamp = time.Now().Unix()
+			}
+			rawData, err := inputsAbi.Pack("EvmAdvance",
+				new(big.Int).SetUint64(chainID),
+				appContract,
+				common.HexToAddress(fInput.MsgSender),
+				new(big.Int).SetUint64(blockNumber),
+				big.NewInt(blockTimestamp),
+				big.NewInt(0),
+				big.NewInt(int64(inputIndex)),
+				payload,
+			)
+			if err != nil {
+				return err
+			}
+			status := fInput.Status
+			if status == "" {
+				status = "ACCEPTED"
+			}
+			transactionID := big.NewInt(int64(inputIndex)).Bytes()
+			if fInput.TransactionID != "" {
+				transactionID, err = decodeFixtureHex(fInput.TransactionID)
+				if err != nil {
+					return err
+				}
+			}
+			input := m.AddInput(RawInput{
+				Index:              uint64(inputIndex),
+				RawData:            rawData,
+				BlockNumber:        blockNumber,
+				Status:             status,
+				ApplicationAddress: appContract.Bytes(),
+				TransactionId:      transactionID,
+			})
+
+			for _, fNotice := range fInput.Notices {
+				noticePayload, err := decodeFixtureHex(fNotice.Payload)
+				if err != nil {
+					return err
+				}
+				rawNotice, err := outputsAbi.Pack("Notice", noticePayload)
+				if err != nil {
+					return err
+				}
+				err = m.loadOutput(input.ID, outputIndex, rawNotice, fNotice.Proof, "")
+				if err != nil {
+					return err
+				}
+				outputIndex++
+			}
+			for _, fVoucher := range fInput.Vouchers {
+				voucherPayload, err := decodeFixtureHex(fVoucher.Payload)
+				if err != nil {
+					return err
+				}
+				value := big.NewInt(0)
+				if fVoucher.Value != "" {
+					var ok bool
+					value, ok = new(big.Int).SetString(fVoucher.Value, 10) // nolint
+					if !ok {
+						return fmt.Errorf("invalid voucher value: %s", fVoucher.Value)
+					}
+				}
+				rawVoucher, err := outputsAbi.Pack("Voucher",
+					common.HexToAddress(fVoucher.Destination),
+					value,
+					voucherPayload,
+				)
+				if err != nil {
+					return err
+				}
+				err = m.loadOutput(input.ID, outputIndex, rawVoucher, fVoucher.Proof, fVoucher.TransactionHash)
+				if err != nil {
+					return err
+				}
+				outputIndex++
+			}
+			for _, fReport := range fInput.Reports {
+				reportPayload, err := decodeFixtureHex(fReport.Payload)
+				if err != nil {
+					return err
+				}
+				_, err = m.AddReport(Report{
+					Index:   strconv.FormatUint(reportIndex, 10),
+					RawData: reportPayload,
+					InputID: int64(input.ID),
+				})
+				if err != nil {
+					return err
+				}
+				reportIndex++
+			}
+		}
+	}
+	slog.Info("Raw fixture loaded",
+		"inputs", len(m.inputs),
+		"outputs", len(m.outputs),
+		"reports", len(m.reports),
+	)
+	return nil
+}
+
+func (m *MemoryRawSource) loadOutput(
+	inputID uint64,
+	outputIndex uint64,
+	rawData []byte,
+	proof []string,
+	transactionHash string,
+) error {
+	output, err := m.AddOutput(Output{
+		Index:   strconv.FormatUint(outputIndex, 10),
+		RawData: rawData,
+		InputID: inputID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(proof) > 0 {
+		siblings := make([]common.Hash, len(proof))
+		for i, sibling := range proof {
+			value, err := decodeFixtureHex(sibling)
+			if err != nil {
+				return err
+			}
+			siblings[i] = common.BytesToHash(value)
+		}
+		err = m.SetOutputProof(output.ID, siblings)
+		if err != nil {
+			return err
+		}
+	}
+	if transactionHash != "" {
+		txHash, err := decodeFixtureHex(transactionHash)
+		if err != nil {
+			return err
+		}
+		err = m.SetOutputExecuted(output.ID, common.BytesToHash(txHash))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeFixtureHex(value string) ([]byte, error) {
+	if value == "" || value == "0x" {
+		return []byte{}, nil
+	}
+	if !strings.HasPrefix(value, "0x") {
+		value = "0x" + value
+	}
+	decoded, err := hexutil.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex %q: %w", value, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,206 @@
+package synchronizernode
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+const fixtureAppContract = "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+
+const fixtureYaml = `
+chainId: 31337
+apps:
+  - address: "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+    inputs:
+      - msgSender: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
+        payload: "0xdeadbeef"
+        notices:
+          - payload: "0x1122"
+            proof:
+              - "0x0000000000000000000000000000000000000000000000000000000000000001"
+              - "0x0000000000000000000000000000000000000000000000000000000000000002"
+        vouchers:
+          - destination: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"
+            value: "1"
+            payload: "0x3344"
+            proof:
+              - "0x0000000000000000000000000000000000000000000000000000000000000003"
+            transactionHash: "0x00000000000000000000000000000000000000000000000000000000000000aa"
+        reports:
+          - payload: "0x5566"
+      - msgSender: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
+        payload: "0xcafe"
+        status: NONE
+`
+
+type RawFixtureSuite struct {
+	suite.Suite
+	ctx          context.Context
+	dbFactory    *commons.DbFactory
+	container    *convenience.Container
+	rawSource    *MemoryRawSource
+	inputCreator SynchronizerInputCreator
+	update       SynchronizerUpdate
+	report       *SynchronizerReport
+	outputCreate *SynchronizerOutputCreate
+	outputUpdate *SynchronizerOutputUpdate
+	executed     *SynchronizerOutputExecuted
+}
+
+func TestRawFixtureSuite(t *testing.T) {
+	suite.Run(t, new(RawFixtureSuite))
+}
+
+func (s *RawFixtureSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.ctx = context.Background()
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("fixture.sqlite3")
+	s.container = convenience.NewContainer(*db, false)
+
+	fixturePath := filepath.Join(s.dbFactory.TempDir, "fixture.yaml")
+	err := os.WriteFile(fixturePath, []byte(fixtureYaml), 0644) // nolint
+	s.Require().NoError(err)
+	s.rawSource, err = NewMemoryRawSourceFromFile(fixturePath)
+	s.Require().NoError(err)
+
+	outputAbi, err := contracts.OutputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	inputAbi, err := contracts.InputsMetaData.GetAbi()
+	s.Require().NoError(err)
+
+	s.inputCreator = *NewSynchronizerInputCreator(
+		s.container.GetInputRepository(),
+		s.container.GetRawInputRepository(),
+		s.rawSource,
+		NewAbiDecoder(inputAbi),
+	)
+	s.update = NewSynchronizerUpdate(
+		s.container.GetRawInputRepository(),
+		s.rawSource,
+		s.container.GetInputRepository(),
+	)
+	s.report = NewSynchronizerReport(
+		s.container.GetReportRepository(),
+		s.rawSource,
+	)
+	s.outputCreate = NewSynchronizerOutputCreate(
+		s.container.GetVoucherRepository(),
+		s.container.GetNoticeRepository(),
+		s.rawSource,
+		s.container.GetRawOutputRefRepository(),
+		NewAbiDecoder(outputAbi),
+	)
+	s.outputUpdate = NewSynchronizerOutputUpdate(
+		s.container.GetVoucherRepository(),
+		s.container.GetNoticeRepository(),
+		s.rawSource,
+		s.container.GetRawOutputRefRepository(),
+	)
+	s.executed = NewSynchronizerOutputExecuted(
+		s.container.GetVoucherRepository(),
+		s.container.GetNoticeRepository(),
+		s.rawSource,
+		s.container.GetRawOutputRefRepository(),
+	)
+}
+
+func (s *RawFixtureSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func (s *RawFixtureSuite) sync() {
+	s.Require().NoError(s.inputCreator.SyncInputs(s.ctx))
+	s.Require().NoError(s.update.SyncInputStatus(s.ctx))
+	s.Require().NoError(s.report.SyncReports(s.ctx))
+	s.Require().NoError(s.outputCreate.SyncOutputs(s.ctx))
+	s.Require().NoError(s.outputUpdate.SyncOutputs(s.ctx))
+	s.Require().NoError(s.executed.SyncOutputsExecution(s.ctx))
+}
+
+func (s *RawFixtureSuite) TestLoadFixture() {
+	inputs, err := s.rawSource.FindAllInputsByFilter(s.ctx, FilterInput{IDgt: 1}, nil)
+	s.Require().NoError(err)
+	s.Len(inputs, 2)
+	s.Equal(uint64(1), inputs[1].Index)
+	s.Equal("NONE", inputs[1].Status)
+
+	outputs, err := s.rawSource.FindAllOutputsByFilter(s.ctx, FilterID{IDgt: 0})
+	s.Require().NoError(err)
+	s.Len(outputs, 2)
+	s.Equal("0", outputs[0].InputIndex)
+	s.Equal(common.HexToAddress(fixtureAppContract).Bytes(), outputs[0].AppContract)
+
+	withProof, err := s.rawSource.FindAllOutputsWithProof(s.ctx, FilterID{IDgt: 1})
+	s.Require().NoError(err)
+	s.Len(withProof, 2)
+	hashes, err := parseAndDecode(string(withProof[0].OutputHashesSiblings))
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"0x0000000000000000000000000000000000000000000000000000000000000001",
+		"0x0000000000000000000000000000000000000000000000000000000000000002",
+	}, hashes)
+}
+
+func (s *RawFixtureSuite) TestUnsupportedFixtureFormat() {
+	_, err := LoadFixtureFile(filepath.Join(s.dbFactory.TempDir, "fixture.txt"))
+	s.Error(err)
+}
+
+func (s *RawFixtureSuite) TestSyncFixture() {
+	s.sync()
+	s.sync()
+
+	appContract := common.HexToAddress(fixtureAppContract)
+	inputCount, err := s.container.GetInputRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), inputCount)
+
+	input, err := s.container.GetInputRepository().FindByIndexAndAppContract(s.ctx, 0, &appContract)
+	s.Require().NoError(err)
+	s.Equal("0xdeadbeef", input.Payload)
+	s.Equal(model.CompletionStatusAccepted, input.Status)
+
+	notice, err := s.container.GetNoticeRepository().FindNoticeByOutputIndexAndAppContract(s.ctx, 0, &appContract)
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(notice.Payload, "0xc258d6e5"))
+	s.Contains(notice.Payload, "1122")
+	s.Contains(notice.OutputHashesSiblings, "0x0000000000000000000000000000000000000000000000000000000000000002")
+
+	voucher, err := s.container.GetVoucherRepository().FindVoucherByOutputIndexAndAppContract(s.ctx, 1, &appContract)
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(voucher.Payload, "0x237a816f"))
+	s.Equal(common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8"), voucher.Destination)
+	s.True(voucher.Executed)
+	s.Equal("0x00000000000000000000000000000000000000000000000000000000000000aa", voucher.TransactionHash)
+
+	reportCount, err := s.container.GetReportRepository().Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), reportCount)
+}
+
+func (s *RawFixtureSuite) TestSyncStatusChange() {
+	s.sync()
+	appContract := common.HexToAddress(fixtureAppContract)
+	input, err := s.container.GetInputRepository().FindByIndexAndAppContract(s.ctx, 1, &appContract)
+	s.Require().NoError(err)
+	s.Equal(model.CompletionStatusUnprocessed, input.Status)
+
+	s.Require().NoError(s.rawSource.SetInputStatus(2, "REJECTED"))
+	s.sync()
+
+	input, err = s.container.GetInputRepository().FindByIndexAndAppContract(s.ctx, 1, &appContract)
+	s.Require().NoError(err)
+	s.Equal(model.CompletionStatusRejected, input.Status)
+}
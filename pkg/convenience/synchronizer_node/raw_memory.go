@@ -0,0 +1,248 @@
+package synchronizernode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MemoryRawSource simulates the node v2 database in memory.
+// The filters follow the same semantics of the RawRepository queries.
+type MemoryRawSource struct {
+	mutex   sync.RWMutex
+	inputs  []RawInput
+	outputs []Output
+	reports []Report
+}
+
+var _ RawSource = (*MemoryRawSource)(nil)
+
+func NewMemoryRawSource() *MemoryRawSource {
+	return &MemoryRawSource{}
+}
+
+// AddInput stores the input with the next raw id
+func (m *MemoryRawSource) AddInput(input RawInput) RawInput {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	input.ID = uint64(len(m.inputs) + 1)
+	if input.UpdatedAt.IsZero() {
+		input.UpdatedAt = time.Now()
+	}
+	m.inputs = append(m.inputs, input)
+	return input
+}
+
+// AddOutput stores the output of an existing input with the next raw id
+func (m *MemoryRawSource) AddOutput(output Output) (Output, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	input := m.findInput(output.InputID)
+	if input == nil {
+		return Output{}, fmt.Errorf("input %d not found", output.InputID)
+	}
+	output.ID = uint64(len(m.outputs) + 1)
+	output.AppContract = input.ApplicationAddress
+	output.InputIndex = strconv.FormatUint(input.Index, 10)
+	if output.UpdatedAt.IsZero() {
+		output.UpdatedAt = time.Now()
+	}
+	m.outputs = append(m.outputs, output)
+	return output, nil
+}
+
+// AddReport stores the report of an existing input with the next raw id
+func (m *MemoryRawSource) AddReport(report Report) (Report, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	input := m.findInput(uint64(report.InputID))
+	if input == nil {
+		return Report{}, fmt.Errorf("input %d not found", report.InputID)
+	}
+	report.ID = int64(len(m.reports) + 1)
+	report.AppContract = input.ApplicationAddress
+	report.InputIndex = strconv.FormatUint(input.Index, 10)
+	m.reports = append(m.reports, report)
+	return report, nil
+}
+
+// SetInputStatus simulates the node processing the input
+func (m *MemoryRawSource) SetInputStatus(inputID uint64, status string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	input := m.findInput(inputID)
+	if input == nil {
+		return fmt.Errorf("input %d not found", inputID)
+	}
+	input.Status = status
+	input.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetOutputProof simulates the node storing the proof after the epoch is closed
+func (m *MemoryRawSource) SetOutputProof(outputID uint64, siblings []common.Hash) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	output := m.findOutput(outputID)
+	if output == nil {
+		return fmt.Errorf("output %d not found", outputID)
+	}
+	output.OutputHashesSiblings = formatByteaArray(siblings)
+	output.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetOutputExecuted simulates the node detecting the voucher execution
+func (m *MemoryRawSource) SetOutputExecuted(outputID uint64, transactionHash common.Hash) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	output := m.findOutput(outputID)
+	if output == nil {
+		return fmt.Errorf("output %d not found", outputID)
+	}
+	output.TransactionHash = transactionHash.Bytes()
+	output.UpdatedAt = time.Now()
+	return nil
+}
+
+// FindAllInputsByFilter implements RawSource.
+func (m *MemoryRawSource) FindAllInputsByFilter(ctx context.Context, filter FilterInput, pag *Pagination) ([]RawInput, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	limit := LIMIT
+	if pag != nil {
+		limit = pag.Limit
+	}
+	inputs := []RawInput{}
+	for _, input := range m.inputs {
+		if uint64(len(inputs)) >= limit {
+			break
+		}
+		if input.ID < filter.IDgt {
+			continue
+		}
+		if filter.IsStatusNone && input.Status != "NONE" {
+			continue
+		}
+		if filter.Status != "" && input.Status != filter.Status {
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// FindAllReportsByFilter implements RawSource.
+func (m *MemoryRawSource) FindAllReportsByFilter(ctx context.Context, filter FilterID) ([]Report, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	reports := []Report{}
+	for _, report := range m.reports {
+		if uint64(len(reports)) >= LIMIT {
+			break
+		}
+		if report.ID >= int64(filter.IDgt) {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// FindInputByOutput implements RawSource.
+func (m *MemoryRawSource) FindInputByOutput(ctx context.Context, filter FilterID) (*RawInput, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	input := m.findInput(filter.IDgt)
+	if input == nil {
+		return nil, nil
+	}
+	found := *input
+	return &found, nil
+}
+
+// FindAllOutputsByFilter implements RawSource.
+func (m *MemoryRawSource) FindAllOutputsByFilter(ctx context.Context, filter FilterID) ([]Output, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	outputs := []Output{}
+	for _, output := range m.outputs {
+		if uint64(len(outputs)) >= LIMIT {
+			break
+		}
+		if output.ID > filter.IDgt {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+// FindAllOutputsWithProof implements RawSource.
+func (m *MemoryRawSource) FindAllOutputsWithProof(ctx context.Context, filter FilterID) ([]Output, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	outputs := []Output{}
+	for _, output := range m.outputs {
+		if uint64(len(outputs)) >= LIMIT {
+			break
+		}
+		if output.ID >= filter.IDgt && output.OutputHashesSiblings != nil {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+// FindAllOutputsExecutedAfter implements RawSource.
+func (m *MemoryRawSource) FindAllOutputsExecutedAfter(ctx context.Context, afterUpdatedAt time.Time, rawId uint64) ([]Output, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	outputs := []Output{}
+	for _, output := range m.outputs {
+		if output.TransactionHash == nil {
+			continue
+		}
+		if output.UpdatedAt.After(afterUpdatedAt) ||
+			(output.UpdatedAt.Equal(afterUpdatedAt) && output.ID > rawId) {
+			outputs = append(outputs, output)
+		}
+	}
+	sort.SliceStable(outputs, func(i, j int) bool {
+		if outputs[i].UpdatedAt.Equal(outputs[j].UpdatedAt) {
+			return outputs[i].ID < outputs[j].ID
+		}
+		return outputs[i].UpdatedAt.Before(outputs[j].UpdatedAt)
+	})
+	if uint64(len(outputs)) > LIMIT {
+		outputs = outputs[:LIMIT]
+	}
+	return outputs, nil
+}
+
+func (m *MemoryRawSource) findInput(id uint64) *RawInput {
+	if id == 0 || id > uint64(len(m.inputs)) {
+		return nil
+	}
+	return &m.inputs[id-1]
+}
+
+func (m *MemoryRawSource) findOutput(id uint64) *Output {
+	if id == 0 || id > uint64(len(m.outputs)) {
+		return nil
+	}
+	return &m.outputs[id-1]
+}
+
+// formatByteaArray writes the hashes the way Postgres returns a bytea[] column
+func formatByteaArray(hashes []common.Hash) []byte {
+	parts := make([]string, len(hashes))
+	for i, hash := range hashes {
+		parts[i] = fmt.Sprintf(`"\\x%x"`, hash.Bytes())
+	}
+	return []byte("{" + strings.Join(parts, ",") + "}")
+}
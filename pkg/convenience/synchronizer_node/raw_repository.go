@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 )
@@ -95,13 +96,13 @@ func (s *RawRepository) FindAllInputsByFilter(ctx context.Context, filter Filter
 	additionalFilter := ""
 
 	if filter.IsStatusNone {
-		additionalFilter = fmt.Sprintf(" AND status = \"$%d\"", bindVarIdx)
+		additionalFilter += fmt.Sprintf(" AND status = $%d", bindVarIdx)
 		bindVarIdx++
 		args = append(args, "NONE")
 	}
 
 	if filter.Status != "" {
-		additionalFilter = fmt.Sprintf(" AND status = $%d", bindVarIdx)
+		additionalFilter += fmt.Sprintf(" AND status = $%d", bindVarIdx)
 		bindVarIdx++
 		args = append(args, filter.Status)
 	}
@@ -172,7 +173,7 @@ func (s *RawRepository) FindAllReportsByFilter(ctx context.Context, filter Filte
 
 func (s *RawRepository) FindInputByOutput(ctx context.Context, filter FilterID) (*RawInput, error) {
 	query := `SELECT * FROM input WHERE input.id = $1 LIMIT 1`
-	stmt, err := s.Db.Preparex(query)
+	stmt, err := s.Db.PreparexContext(ctx, query)
 	if err != nil {
 		slog.Error("Failed to prepare statement in FindInputByOutput", "query", query, "error", err)
 		return nil, err
@@ -283,3 +284,23 @@ func (s *RawRepository) FindAllOutputsExecutedAfter(ctx context.Context, afterUp
 
 	return outputs, nil
 }
+
+// NextNonce reads the nonce the node espresso reader expects in the next
+// transaction of the sender, it is zero before the first one
+func (s *RawRepository) NextNonce(ctx context.Context, appContract common.Address, msgSender common.Address) (uint64, error) {
+	var nonce uint64
+	err := s.Db.GetContext(ctx, &nonce, `
+		SELECT nonce
+		FROM espresso_nonce
+		WHERE application_address = $1 AND sender_address = $2`,
+		appContract.Bytes(), msgSender.Bytes(),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		slog.Error("Failed to query the espresso nonce", "error", err)
+		return 0, err
+	}
+	return nonce, nil
+}
@@ -191,3 +191,83 @@ func (s *RawNodeSuite) TestSynchronizerNodeFindInputByOutput() {
 	s.NoError(err)
 	s.Equal(uint64(1), input.ID)
 }
+
+// RawInputFilterSuite runs the input filters against an embedded database
+// with the columns of the node input table, so it does not need Postgres
+type RawInputFilterSuite struct {
+	suite.Suite
+	rawRepository *RawRepository
+	dbFactory     *commons.DbFactory
+}
+
+func TestRawInputFilterSuite(t *testing.T) {
+	suite.Run(t, new(RawInputFilterSuite))
+}
+
+func (s *RawInputFilterSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("raw_input.sqlite3")
+	_, err := db.Exec(`CREATE TABLE input (
+		id                  integer NOT NULL PRIMARY KEY,
+		"index"             integer NOT NULL,
+		raw_data            bytea,
+		block_number        integer,
+		status              text,
+		machine_hash        bytea,
+		outputs_hash        bytea,
+		application_address bytea,
+		epoch_id            integer,
+		transaction_id      bytea,
+		updated_at          timestamp)`)
+	s.Require().NoError(err)
+	for i, status := range []string{"NONE", "ACCEPTED", "NONE", "REJECTED"} {
+		_, err = db.Exec(`INSERT INTO input
+			(id, "index", block_number, status, epoch_id, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			i+1, i, i, status, 0, time.Now())
+		s.Require().NoError(err)
+	}
+	s.rawRepository = NewRawRepository("", db)
+}
+
+func (s *RawInputFilterSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func (s *RawInputFilterSuite) TestFindAllInputsByStatusNone() {
+	inputs, err := s.rawRepository.FindAllInputsByFilter(context.Background(),
+		FilterInput{IDgt: 1, IsStatusNone: true}, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(2, len(inputs))
+	s.Equal(1, int(inputs[0].ID))
+	s.Equal(3, int(inputs[1].ID))
+}
+
+func (s *RawInputFilterSuite) TestFindAllInputsByStatus() {
+	inputs, err := s.rawRepository.FindAllInputsByFilter(context.Background(),
+		FilterInput{IDgt: 1, Status: "ACCEPTED"}, &Pagination{Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Equal(1, len(inputs))
+	s.Equal(2, int(inputs[0].ID))
+}
+
+func (s *RawNodeSuite) TestNextNonce() {
+	ctx := context.Background()
+	app := common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	sender := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	nonce, err := s.rawRepository.NextNonce(ctx, app, sender)
+	s.Require().NoError(err)
+	s.Equal(uint64(0), nonce)
+
+	_, err = s.rawRepository.Db.ExecContext(ctx, `INSERT INTO espresso_nonce
+		(sender_address, application_address, nonce) VALUES ($1, $2, 7)`, sender.Bytes(), app.Bytes())
+	s.Require().NoError(err)
+	defer func() {
+		_, err := s.rawRepository.Db.ExecContext(ctx, `DELETE FROM espresso_nonce WHERE sender_address = $1`, sender.Bytes())
+		s.NoError(err)
+	}()
+	nonce, err = s.rawRepository.NextNonce(ctx, app, sender)
+	s.Require().NoError(err)
+	s.Equal(uint64(7), nonce)
+}
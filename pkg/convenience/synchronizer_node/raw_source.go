@@ -0,0 +1,20 @@
+package synchronizernode
+
+import (
+	"context"
+	"time"
+)
+
+// RawSource is where the synchronizers read the node v2 data from.
+// RawRepository reads it from the node Postgres database, while
+// MemoryRawSource keeps it in memory so the sync can run offline.
+type RawSource interface {
+	FindAllInputsByFilter(ctx context.Context, filter FilterInput, pag *Pagination) ([]RawInput, error)
+	FindAllReportsByFilter(ctx context.Context, filter FilterID) ([]Report, error)
+	FindInputByOutput(ctx context.Context, filter FilterID) (*RawInput, error)
+	FindAllOutputsByFilter(ctx context.Context, filter FilterID) ([]Output, error)
+	FindAllOutputsWithProof(ctx context.Context, filter FilterID) ([]Output, error)
+	FindAllOutputsExecutedAfter(ctx context.Context, afterUpdatedAt time.Time, rawId uint64) ([]Output, error)
+}
+
+var _ RawSource = (*RawRepository)(nil)
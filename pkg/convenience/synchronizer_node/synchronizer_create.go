@@ -10,6 +10,7 @@ import (
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/decoder"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/supervisor"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/tracing"
 	"github.com/ethereum/go-ethereum/common"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
@@ -21,7 +22,7 @@ type SynchronizerCreateWorker struct {
 	outputRefRepository        *repository.RawOutputRefRepository
 	SynchronizerReport         *SynchronizerReport
 	DbRawUrl                   string
-	RawRepository              *RawRepository
+	RawRepository              RawSource
 	SynchronizerUpdate         *SynchronizerUpdate
 	Decoder                    *decoder.OutputDecoder
 	SynchronizerOutputUpdate   *SynchronizerOutputUpdate
@@ -64,40 +65,48 @@ func (s SynchronizerCreateWorker) WatchNewInputs(stdCtx context.Context) error {
 					errCh <- ctx.Err()
 					return
 				default:
-					err := s.SynchronizerCreateInput.SyncInputs(ctx)
+					err := s.syncStep(ctx, "inputs", s.SynchronizerCreateInput.SyncInputs)
 					if err != nil {
 						errCh <- err
 						return
 					}
-					err = s.SynchronizerUpdate.SyncInputStatus(ctx)
+					err = s.syncStep(ctx, "inputTransactions", s.SynchronizerCreateInput.BackfillTransactions)
 					if err != nil {
 						errCh <- err
 						return
 					}
-					err = s.SynchronizerReport.SyncReports(ctx)
+					err = s.syncStep(ctx, "inputStatus", s.SynchronizerUpdate.SyncInputStatus)
 					if err != nil {
 						errCh <- err
 						return
 					}
-
-					err = s.SynchronizerOutputCreate.SyncOutputs(ctx)
+					err = s.syncStep(ctx, "reports", s.SynchronizerReport.SyncReports)
 					if err != nil {
 						errCh <- err
 						return
 					}
 
-					err = s.SynchronizerOutputUpdate.SyncOutputs(ctx)
+					err = s.syncStep(ctx, "outputs", s.SynchronizerOutputCreate.SyncOutputs)
 					if err != nil {
 						errCh <- err
 						return
 					}
 
-					err = s.SynchronizerOutputExecuted.SyncOutputsExecution(ctx)
+					err = s.syncStep(ctx, "outputProofs", s.SynchronizerOutputUpdate.SyncOutputs)
 					if err != nil {
 						errCh <- err
 						return
 					}
 
+					// nil when the executions come from the L1 events
+					if s.SynchronizerOutputExecuted != nil {
+						err = s.syncStep(ctx, "outputsExecution", s.SynchronizerOutputExecuted.SyncOutputsExecution)
+						if err != nil {
+							errCh <- err
+							return
+						}
+					}
+
 					<-time.After(DEFAULT_DELAY)
 				}
 			}
@@ -113,6 +122,18 @@ func (s SynchronizerCreateWorker) WatchNewInputs(stdCtx context.Context) error {
 	}
 }
 
+// syncStep runs one step of the sync in its own span
+func (s SynchronizerCreateWorker) syncStep(
+	ctx context.Context,
+	name string,
+	step func(ctx context.Context) error,
+) error {
+	ctx, span := tracing.Start(ctx, "sync "+name)
+	err := step(ctx)
+	tracing.End(span, err)
+	return err
+}
+
 // String implements supervisor.Worker.
 func (s SynchronizerCreateWorker) String() string {
 	return "SynchronizerCreateWorker"
@@ -122,7 +143,7 @@ func NewSynchronizerCreateWorker(
 	inputRepository *repository.InputRepository,
 	inputRefRepository *repository.RawInputRefRepository,
 	dbRawUrl string,
-	rawRepository *RawRepository,
+	rawRepository RawSource,
 	synchronizerUpdate *SynchronizerUpdate,
 	decoder *decoder.OutputDecoder,
 	synchronizerReport *SynchronizerReport,
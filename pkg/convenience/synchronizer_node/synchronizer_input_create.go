@@ -16,14 +16,24 @@ import (
 type SynchronizerInputCreator struct {
 	InputRepository       *repository.InputRepository
 	RawInputRefRepository *repository.RawInputRefRepository
-	RawNodeV2Repository   *RawRepository
+	RawNodeV2Repository   RawSource
 	AbiDecoder            *AbiDecoder
+	// InputLogResolver is optional, without it the inputs have no L1 transaction.
+	// The transaction id of the node is the InputBox index or the sequencer
+	// transaction id, not the L1 transaction hash
+	InputLogResolver *InputLogResolver
+	// backfillAfter is the last input tried by BackfillTransactions,
+	// nil to start over from the first one
+	backfillAfter *model.AdvanceInput
 }
 
+// BACKFILL_LIMIT is how many inputs BackfillTransactions tries at a time
+const BACKFILL_LIMIT = 100
+
 func NewSynchronizerInputCreator(
 	inputRepository *repository.InputRepository,
 	rawInputRefRepository *repository.RawInputRefRepository,
-	rawRepository *RawRepository,
+	rawRepository RawSource,
 	abiDecoder *AbiDecoder,
 ) *SynchronizerInputCreator {
 	return &SynchronizerInputCreator{
@@ -108,6 +118,7 @@ func (s *SynchronizerInputCreator) CreateInput(ctx context.Context, rawInput Raw
 	if err != nil {
 		return err
 	}
+	s.setTransaction(ctx, advanceInput)
 
 	inputBox, err := s.InputRepository.Create(ctx, *advanceInput)
 	if err != nil {
@@ -130,6 +141,61 @@ func (s *SynchronizerInputCreator) CreateInput(ctx context.Context, rawInput Raw
 	return nil
 }
 
+// setTransaction does not stop the sync when the RPC fails,
+// the input is still created without its transaction, to be
+// resolved again by BackfillTransactions
+func (s *SynchronizerInputCreator) setTransaction(
+	ctx context.Context,
+	input *model.AdvanceInput,
+) {
+	if s.InputLogResolver == nil {
+		return
+	}
+	err := s.InputLogResolver.Resolve(ctx, input)
+	if err != nil {
+		slog.Warn("Could not resolve the input transaction",
+			"appContract", input.AppContract.Hex(),
+			"index", input.Index,
+			"error", err,
+		)
+	}
+}
+
+// BackfillTransactions retries the inputs created without their
+// transaction. It goes through them in batches, a batch per call,
+// so the ones whose log is missing do not hold back the others.
+// It runs outside a transaction, as it waits for the RPC.
+func (s *SynchronizerInputCreator) BackfillTransactions(ctx context.Context) error {
+	if s.InputLogResolver == nil {
+		return nil
+	}
+	inputs, err := s.InputRepository.FindInputsWithoutTransaction(ctx, s.backfillAfter, BACKFILL_LIMIT)
+	if err != nil {
+		return err
+	}
+	s.backfillAfter = nil
+	if len(inputs) == BACKFILL_LIMIT {
+		s.backfillAfter = inputs[len(inputs)-1]
+	}
+	for _, input := range inputs {
+		err := s.InputLogResolver.Resolve(ctx, input)
+		if err != nil {
+			slog.Warn("Could not resolve the input transaction",
+				"appContract", input.AppContract.Hex(),
+				"index", input.Index,
+				"error", err,
+			)
+			continue
+		}
+		err = s.InputRepository.SetTransaction(ctx, input.AppContract,
+			uint64(input.Index), input.TransactionHash, input.LogIndex, input.BlockHash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *SynchronizerInputCreator) GetAdvanceInputFromMap(rawInput RawInput) (*model.AdvanceInput, error) {
 	decodedData, err := s.AbiDecoder.GetMapRaw(rawInput.RawData)
 	if err != nil {
@@ -1,6 +1,7 @@
 package synchronizernode
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
@@ -9,21 +10,24 @@ import (
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/merkle"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type SynchronizerOutputCreate struct {
 	VoucherRepository      *repository.VoucherRepository
 	NoticeRepository       *repository.NoticeRepository
-	RawNodeV2Repository    *RawRepository
+	RawNodeV2Repository    RawSource
 	RawOutputRefRepository *repository.RawOutputRefRepository
 	AbiDecoder             *AbiDecoder
+	// SearchRepository is optional, without it the notices are not searchable
+	SearchRepository *repository.SearchRepository
 }
 
 func NewSynchronizerOutputCreate(
 	voucherRepository *repository.VoucherRepository,
 	noticeRepository *repository.NoticeRepository,
-	rawRepository *RawRepository,
+	rawRepository RawSource,
 	rawOutputRefRepository *repository.RawOutputRefRepository,
 	abiDecoder *AbiDecoder,
 ) *SynchronizerOutputCreate {
@@ -81,6 +85,7 @@ func (s *SynchronizerOutputCreate) syncOutputs(ctx context.Context) error {
 }
 
 func (s *SynchronizerOutputCreate) CreateOutput(ctx context.Context, rawOutputRef *repository.RawOutputRef, rawOutput Output) error {
+	checkOutputHash(rawOutput)
 	if rawOutputRef.Type == repository.RAW_VOUCHER_TYPE {
 		cVoucher, err := s.GetConvenienceVoucher(rawOutput)
 		if err != nil {
@@ -95,7 +100,11 @@ func (s *SynchronizerOutputCreate) CreateOutput(ctx context.Context, rawOutputRe
 		if err != nil {
 			return err
 		}
-		_, err = s.NoticeRepository.Create(ctx, cNotice)
+		notice, err := s.NoticeRepository.Create(ctx, cNotice)
+		if err != nil {
+			return err
+		}
+		err = s.indexNotice(ctx, notice)
 		if err != nil {
 			return err
 		}
@@ -105,6 +114,19 @@ func (s *SynchronizerOutputCreate) CreateOutput(ctx context.Context, rawOutputRe
 	return nil
 }
 
+func (s *SynchronizerOutputCreate) indexNotice(ctx context.Context, notice *model.ConvenienceNotice) error {
+	if s.SearchRepository == nil || notice.PayloadText == nil {
+		return nil
+	}
+	return s.SearchRepository.Index(ctx, model.SearchDocument{
+		Kind:        model.SEARCH_NOTICE,
+		AppContract: notice.AppContract,
+		InputIndex:  notice.InputIndex,
+		OutputIndex: notice.OutputIndex,
+		Content:     *notice.PayloadText,
+	})
+}
+
 func (s *SynchronizerOutputCreate) GetConvenienceVoucher(rawOutput Output) (*model.ConvenienceVoucher, error) {
 	data, err := s.AbiDecoder.GetMapRaw(rawOutput.RawData)
 	if err != nil {
@@ -185,6 +207,23 @@ func (s *SynchronizerOutputCreate) GetRawOutputRef(rawOutput Output) (*repositor
 	}, nil
 }
 
+// checkOutputHash warns when the hash kept by the node is not the
+// keccak256 of the output, since its proof would never be consistent
+func checkOutputHash(rawOutput Output) {
+	if len(rawOutput.Hash) != common.HashLength {
+		return
+	}
+	computed := merkle.OutputHash(rawOutput.RawData)
+	if !bytes.Equal(computed.Bytes(), rawOutput.Hash) {
+		slog.Warn("output hash mismatch",
+			"app_contract", common.BytesToAddress(rawOutput.AppContract).Hex(),
+			"output_index", rawOutput.Index,
+			"hash", common.BytesToHash(rawOutput.Hash).Hex(),
+			"computed", computed.Hex(),
+		)
+	}
+}
+
 func getOutputType(rawData []byte) (string, error) {
 	var strPayload = "0x" + common.Bytes2Hex(rawData)
 	if strPayload[2:10] == model.VOUCHER_SELECTOR {
@@ -3,8 +3,6 @@ package synchronizernode
 import (
 	"context"
 	"log/slog"
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
@@ -19,7 +17,7 @@ type SynchronizerOutputCreateSuite struct {
 	suite.Suite
 	ctx                        context.Context
 	dockerComposeStartedByTest bool
-	tempDir                    string
+	dbFactory                  *commons.DbFactory
 	container                  *convenience.Container
 	synchronizerOutputCreate   *SynchronizerOutputCreate
 	rawNodeV2Repository        *RawRepository
@@ -38,15 +36,9 @@ func (s *SynchronizerOutputCreateSuite) SetupTest() {
 	s.ctx = context.Background()
 	commons.ConfigureLog(slog.LevelDebug)
 
-	// Temp
-	tempDir, err := os.MkdirTemp("", "")
-	s.NoError(err)
-	s.tempDir = tempDir
-
 	// Database
-	sqliteFileName := filepath.Join(tempDir, "output.sqlite3")
-
-	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("output.sqlite3")
 	s.container = convenience.NewContainer(*db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -74,7 +66,7 @@ func (s *SynchronizerOutputCreateSuite) TearDownSuite() {
 }
 
 func (s *SynchronizerOutputCreateSuite) TearDownTest() {
-	defer os.RemoveAll(s.tempDir)
+	s.dbFactory.Cleanup()
 }
 
 func TestSynchronizerOutputCreateSuiteSuite(t *testing.T) {
@@ -8,20 +8,25 @@ import (
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type SynchronizerOutputExecuted struct {
 	VoucherRepository      *repository.VoucherRepository
 	NoticeRepository       *repository.NoticeRepository
-	RawNodeV2Repository    *RawRepository
+	RawNodeV2Repository    RawSource
 	RawOutputRefRepository *repository.RawOutputRefRepository
+	// ExecutionResolver is optional, without it the vouchers have no execution details
+	ExecutionResolver services.ExecutionResolver
+	// WebhookRepository is optional, without it no voucher.executed event is queued
+	WebhookRepository *repository.WebhookRepository
 }
 
 func NewSynchronizerOutputExecuted(
 	voucherRepository *repository.VoucherRepository,
 	noticeRepository *repository.NoticeRepository,
-	rawRepository *RawRepository,
+	rawRepository RawSource,
 	rawOutputRefRepository *repository.RawOutputRefRepository,
 ) *SynchronizerOutputExecuted {
 	return &SynchronizerOutputExecuted{
@@ -46,7 +51,8 @@ func (s *SynchronizerOutputExecuted) SyncOutputsExecution(ctx context.Context) e
 	if err != nil {
 		return err
 	}
-	return nil
+	// outside the transaction, as it waits for the RPC
+	return services.ResolveExecutions(ctx, s.ExecutionResolver, s.VoucherRepository)
 }
 
 func (s *SynchronizerOutputExecuted) syncOutputs(ctx context.Context) error {
@@ -88,12 +94,13 @@ func (s *SynchronizerOutputExecuted) UpdateExecutionData(
 	}
 	appContract := common.HexToAddress(ref.AppContract)
 	if ref.Type == repository.RAW_VOUCHER_TYPE {
-		err = s.VoucherRepository.SetExecuted(ctx,
-			&model.ConvenienceVoucher{
-				AppContract:     appContract,
-				OutputIndex:     ref.OutputIndex,
-				TransactionHash: "0x" + common.Bytes2Hex(rawOutput.TransactionHash),
-			})
+		voucher := &model.ConvenienceVoucher{
+			AppContract:     appContract,
+			InputIndex:      ref.InputIndex,
+			OutputIndex:     ref.OutputIndex,
+			TransactionHash: "0x" + common.Bytes2Hex(rawOutput.TransactionHash),
+		}
+		_, err = services.SetVoucherExecuted(ctx, s.VoucherRepository, s.WebhookRepository, voucher)
 		if err != nil {
 			return err
 		}
@@ -3,8 +3,6 @@ package synchronizernode
 import (
 	"context"
 	"log/slog"
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
@@ -20,7 +18,7 @@ type SynchronizerOutputExecutedSuite struct {
 	suite.Suite
 	ctx                        context.Context
 	dockerComposeStartedByTest bool
-	tempDir                    string
+	dbFactory                  *commons.DbFactory
 	container                  *convenience.Container
 	synchronizerOutputExecuted *SynchronizerOutputExecuted
 	rawNodeV2Repository        *RawRepository
@@ -41,15 +39,9 @@ func (s *SynchronizerOutputExecutedSuite) SetupTest() {
 	s.ctx = context.Background()
 	commons.ConfigureLog(slog.LevelDebug)
 
-	// Temp
-	tempDir, err := os.MkdirTemp("", "")
-	s.NoError(err)
-	s.tempDir = tempDir
-
 	// Database
-	sqliteFileName := filepath.Join(tempDir, "output.sqlite3")
-
-	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("output.sqlite3")
 	s.container = convenience.NewContainer(*db, false)
 
 	s.dbNodeV2 = sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -84,7 +76,7 @@ func (s *SynchronizerOutputExecutedSuite) TearDownSuite() {
 }
 
 func (s *SynchronizerOutputExecutedSuite) TearDownTest() {
-	defer os.RemoveAll(s.tempDir)
+	s.dbFactory.Cleanup()
 }
 
 func TestSynchronizerOutputExecutedSuite(t *testing.T) {
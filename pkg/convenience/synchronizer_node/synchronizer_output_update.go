@@ -16,14 +16,16 @@ import (
 type SynchronizerOutputUpdate struct {
 	VoucherRepository      *repository.VoucherRepository
 	NoticeRepository       *repository.NoticeRepository
-	RawNodeV2Repository    *RawRepository
+	RawNodeV2Repository    RawSource
 	RawOutputRefRepository *repository.RawOutputRefRepository
+	// WebhookRepository is optional, without it no voucher.proof event is queued
+	WebhookRepository *repository.WebhookRepository
 }
 
 func NewSynchronizerOutputUpdate(
 	voucherRepository *repository.VoucherRepository,
 	noticeRepository *repository.NoticeRepository,
-	rawRepository *RawRepository,
+	rawRepository RawSource,
 	rawOutputRefRepository *repository.RawOutputRefRepository,
 ) *SynchronizerOutputUpdate {
 	return &SynchronizerOutputUpdate{
@@ -111,6 +113,18 @@ func (s *SynchronizerOutputUpdate) UpdateProof(
 		if err != nil {
 			return err
 		}
+		if s.WebhookRepository != nil {
+			err = s.WebhookRepository.AddEvent(ctx, model.WEBHOOK_VOUCHER_PROOF,
+				common.HexToAddress(ref.AppContract),
+				model.VoucherProofEvent{
+					InputIndex:           ref.InputIndex,
+					OutputIndex:          ref.OutputIndex,
+					OutputHashesSiblings: hashes,
+				})
+			if err != nil {
+				return err
+			}
+		}
 	} else if ref.Type == repository.RAW_NOTICE_TYPE {
 		err = s.NoticeRepository.SetProof(ctx,
 			&model.ConvenienceNotice{
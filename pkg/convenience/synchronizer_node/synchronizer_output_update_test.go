@@ -3,8 +3,6 @@ package synchronizernode
 import (
 	"context"
 	"log/slog"
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
@@ -21,7 +19,7 @@ type SynchronizerOutputUpdateSuite struct {
 	suite.Suite
 	ctx                        context.Context
 	dockerComposeStartedByTest bool
-	tempDir                    string
+	dbFactory                  *commons.DbFactory
 	container                  *convenience.Container
 	synchronizerOutputUpdate   *SynchronizerOutputUpdate
 	rawNodeV2Repository        *RawRepository
@@ -40,15 +38,9 @@ func (s *SynchronizerOutputUpdateSuite) SetupTest() {
 	s.ctx = context.Background()
 	commons.ConfigureLog(slog.LevelDebug)
 
-	// Temp
-	tempDir, err := os.MkdirTemp("", "")
-	s.NoError(err)
-	s.tempDir = tempDir
-
 	// Database
-	sqliteFileName := filepath.Join(tempDir, "output.sqlite3")
-
-	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("output.sqlite3")
 	s.container = convenience.NewContainer(*db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -70,7 +62,7 @@ func (s *SynchronizerOutputUpdateSuite) TearDownSuite() {
 }
 
 func (s *SynchronizerOutputUpdateSuite) TearDownTest() {
-	defer os.RemoveAll(s.tempDir)
+	s.dbFactory.Cleanup()
 }
 
 func TestSynchronizerOutputUpdateSuiteSuite(t *testing.T) {
@@ -12,12 +12,14 @@ import (
 
 type SynchronizerReport struct {
 	ReportRepository *repository.ReportRepository
-	RawRepository    *RawRepository
+	RawRepository    RawSource
+	// SearchRepository is optional, without it the reports are not searchable
+	SearchRepository *repository.SearchRepository
 }
 
 func NewSynchronizerReport(
 	reportRepository *repository.ReportRepository,
-	rawRepository *RawRepository,
+	rawRepository RawSource,
 ) *SynchronizerReport {
 	return &SynchronizerReport{
 		ReportRepository: reportRepository,
@@ -66,7 +68,7 @@ func (s *SynchronizerReport) syncReports(ctx context.Context) error {
 			slog.Error("fail to parse input index to int", "value", rawReport.InputIndex)
 			return err
 		}
-		_, err = s.ReportRepository.CreateReport(ctx, model.Report{
+		report, err := s.ReportRepository.CreateReport(ctx, model.Report{
 			AppContract: appContract,
 			Index:       int(index),
 			InputIndex:  int(inputIndex),
@@ -77,10 +79,28 @@ func (s *SynchronizerReport) syncReports(ctx context.Context) error {
 			slog.Error("fail to create report", "err", err)
 			return err
 		}
+		err = s.indexReport(ctx, report)
+		if err != nil {
+			slog.Error("fail to index report", "err", err)
+			return err
+		}
 	}
 	return nil
 }
 
+func (s *SynchronizerReport) indexReport(ctx context.Context, report model.Report) error {
+	if s.SearchRepository == nil || report.PayloadText == nil {
+		return nil
+	}
+	return s.SearchRepository.Index(ctx, model.SearchDocument{
+		Kind:        model.SEARCH_REPORT,
+		AppContract: report.AppContract.Hex(),
+		InputIndex:  uint64(report.InputIndex),
+		OutputIndex: uint64(report.Index),
+		Content:     *report.PayloadText,
+	})
+}
+
 func (s *SynchronizerReport) startTransaction(ctx context.Context) (context.Context, error) {
 	db := s.ReportRepository.Db
 	ctxWithTx, err := repository.StartTransaction(ctx, db)
@@ -3,8 +3,6 @@ package synchronizernode
 import (
 	"context"
 	"log/slog"
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
@@ -18,7 +16,7 @@ type SynchronizerReportSuite struct {
 	suite.Suite
 	ctx                        context.Context
 	dockerComposeStartedByTest bool
-	tempDir                    string
+	dbFactory                  *commons.DbFactory
 	container                  *convenience.Container
 	synchronizerReport         *SynchronizerReport
 	rawNode                    *RawRepository
@@ -36,15 +34,9 @@ func (s *SynchronizerReportSuite) SetupSuite() {
 func (s *SynchronizerReportSuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
 
-	// Temp
-	tempDir, err := os.MkdirTemp("", "")
-	s.NoError(err)
-	s.tempDir = tempDir
-
 	// Database
-	sqliteFileName := filepath.Join(tempDir, "report.sqlite3")
-
-	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("report.sqlite3")
 	s.container = convenience.NewContainer(*db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -63,7 +55,7 @@ func (s *SynchronizerReportSuite) TearDownSuite() {
 }
 
 func (s *SynchronizerReportSuite) TearDownTest() {
-	defer os.RemoveAll(s.tempDir)
+	s.dbFactory.Cleanup()
 }
 
 func TestSynchronizerReportSuiteSuite(t *testing.T) {
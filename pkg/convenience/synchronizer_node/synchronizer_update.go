@@ -14,15 +14,17 @@ const DefaultBatchSize = 50
 
 type SynchronizerUpdate struct {
 	DbRawUrl              string
-	RawNode               *RawRepository
+	RawNode               RawSource
 	RawInputRefRepository *repository.RawInputRefRepository
 	InputRepository       *repository.InputRepository
 	BatchSize             int
+	// WebhookRepository is optional, without it no input.exception event is queued
+	WebhookRepository *repository.WebhookRepository
 }
 
 func NewSynchronizerUpdate(
 	rawInputRefRepository *repository.RawInputRefRepository,
-	rawNode *RawRepository,
+	rawNode RawSource,
 	inputRepository *repository.InputRepository,
 ) SynchronizerUpdate {
 	return SynchronizerUpdate{
@@ -133,6 +135,13 @@ func (s *SynchronizerUpdate) updateStatus(ctx context.Context, rawInputs []RawIn
 		if err != nil {
 			return err
 		}
+		if status == model.CompletionStatusException && s.WebhookRepository != nil {
+			err = s.WebhookRepository.AddEvent(ctx, model.WEBHOOK_INPUT_EXCEPTION, appContract,
+				model.InputExceptionEvent{InputIndex: rawInput.Index})
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
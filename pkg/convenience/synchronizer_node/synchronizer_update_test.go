@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
-	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -24,7 +22,7 @@ type SynchronizerUpdateNodeSuite struct {
 	suite.Suite
 	ctx                        context.Context
 	dockerComposeStartedByTest bool
-	tempDir                    string
+	dbFactory                  *commons.DbFactory
 	container                  *convenience.Container
 	synchronizerUpdate         SynchronizerUpdate
 	rawNode                    *RawRepository
@@ -42,15 +40,9 @@ func (s *SynchronizerUpdateNodeSuite) SetupSuite() {
 func (s *SynchronizerUpdateNodeSuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
 
-	// Temp
-	tempDir, err := os.MkdirTemp("", "")
-	s.NoError(err)
-	s.tempDir = tempDir
-
 	// Database
-	sqliteFileName := filepath.Join(tempDir, "update_input.sqlite3")
-	slog.Debug("SetupTest", "sqliteFileName", sqliteFileName)
-	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("update_input.sqlite3")
 	s.container = convenience.NewContainer(*db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -71,7 +63,7 @@ func (s *SynchronizerUpdateNodeSuite) TearDownSuite() {
 }
 
 func (s *SynchronizerUpdateNodeSuite) TearDownTest() {
-	defer os.RemoveAll(s.tempDir)
+	s.dbFactory.Cleanup()
 }
 
 func TestSynchronizerUpdateNodeSuiteSuite(t *testing.T) {
@@ -0,0 +1,176 @@
+// Package export streams the inputs and outputs of an application
+// as CSV, JSON Lines or Parquet, reading the rows in batches.
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	EntityInputs   = "inputs"
+	EntityVouchers = "vouchers"
+	EntityNotices  = "notices"
+	EntityReports  = "reports"
+
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+)
+
+var (
+	Entities = []string{EntityInputs, EntityVouchers, EntityNotices, EntityReports}
+	Formats  = []string{FormatCSV, FormatJSONL, FormatParquet}
+)
+
+// Repositories the entries are read from
+type Repositories struct {
+	Inputs   *repository.InputRepository
+	Vouchers *repository.VoucherRepository
+	Notices  *repository.NoticeRepository
+	Reports  *repository.ReportRepository
+}
+
+func NewRepositories(container *convenience.Container) Repositories {
+	return Repositories{
+		Inputs:   container.GetInputRepository(),
+		Vouchers: container.GetVoucherRepository(),
+		Notices:  container.GetNoticeRepository(),
+		Reports:  container.GetReportRepository(),
+	}
+}
+
+// NewReadRepositories reads the tables as they are, without creating or
+// migrating them, for the exports of a database owned by another process.
+// The readDb is optional, without it the entries are read from the db.
+func NewReadRepositories(db *sqlx.DB, readDb *sqlx.DB) Repositories {
+	outputRepository := repository.OutputRepository{Db: *db, ReadDb: readDb}
+	return Repositories{
+		Inputs: &repository.InputRepository{Db: *db, ReadDb: readDb},
+		Vouchers: &repository.VoucherRepository{
+			Db: *db, ReadDb: readDb, OutputRepository: outputRepository,
+		},
+		Notices: &repository.NoticeRepository{
+			Db: *db, ReadDb: readDb, OutputRepository: outputRepository,
+		},
+		Reports: &repository.ReportRepository{Db: db, ReadDb: readDb},
+	}
+}
+
+// Options of an export. The indexes are the input index of the inputs,
+// the output index of the vouchers and notices and the report index.
+type Options struct {
+	AppContract common.Address
+	Entity      string
+	Format      string
+	FromIndex   uint64
+	// ToIndex is inclusive, the export goes to the last entry without it
+	ToIndex *uint64
+}
+
+func (opts Options) Validate() error {
+	if !slices.Contains(Entities, opts.Entity) {
+		return fmt.Errorf("invalid entity %q, must be one of %v", opts.Entity, Entities)
+	}
+	if !slices.Contains(Formats, opts.Format) {
+		return fmt.Errorf("invalid format %q, must be one of %v", opts.Format, Formats)
+	}
+	if opts.ToIndex != nil && *opts.ToIndex < opts.FromIndex {
+		return fmt.Errorf("to-index %d is lower than from-index %d", *opts.ToIndex, opts.FromIndex)
+	}
+	if opts.FromIndex > math.MaxInt64 {
+		return fmt.Errorf("from-index %d is too big", opts.FromIndex)
+	}
+	if opts.ToIndex != nil && *opts.ToIndex > math.MaxInt64 {
+		return fmt.Errorf("to-index %d is too big", *opts.ToIndex)
+	}
+	return nil
+}
+
+// FileName is the suggested name of the exported file
+func (opts Options) FileName() string {
+	return fmt.Sprintf("%s-%s.%s", opts.AppContract.Hex(), opts.Entity, opts.Format)
+}
+
+// ContentType is the media type of the format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatJSONL:
+		return "application/jsonl"
+	}
+	return "application/octet-stream"
+}
+
+// Export writes the entries to w as they are read from the database
+func Export(ctx context.Context, repos Repositories, opts Options, w io.Writer) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	toIndex := uint64(math.MaxInt64)
+	if opts.ToIndex != nil {
+		toIndex = *opts.ToIndex
+	}
+	buffered := bufio.NewWriter(w)
+	var err error
+	switch opts.Entity {
+	case EntityInputs:
+		err = stream(opts.Format, buffered, func(write func(InputRow) error) error {
+			return repos.Inputs.StreamByIndex(ctx, opts.AppContract, opts.FromIndex, toIndex,
+				func(input *model.AdvanceInput) error {
+					row, err := NewInputRow(input)
+					if err != nil {
+						return err
+					}
+					return write(row)
+				})
+		})
+	case EntityVouchers:
+		err = stream(opts.Format, buffered, func(write func(VoucherRow) error) error {
+			return repos.Vouchers.StreamByIndex(ctx, opts.AppContract, opts.FromIndex, toIndex,
+				func(voucher *model.ConvenienceVoucher) error {
+					return write(NewVoucherRow(voucher))
+				})
+		})
+	case EntityNotices:
+		err = stream(opts.Format, buffered, func(write func(NoticeRow) error) error {
+			return repos.Notices.StreamByIndex(ctx, opts.AppContract, opts.FromIndex, toIndex,
+				func(notice *model.ConvenienceNotice) error {
+					return write(NewNoticeRow(notice))
+				})
+		})
+	case EntityReports:
+		err = stream(opts.Format, buffered, func(write func(ReportRow) error) error {
+			return repos.Reports.StreamByIndex(ctx, opts.AppContract, opts.FromIndex, toIndex,
+				func(report *model.Report) error {
+					return write(NewReportRow(report))
+				})
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+func stream[T any](format string, w io.Writer, source func(write func(T) error) error) error {
+	writer, err := newRowWriter[T](format, w)
+	if err != nil {
+		return err
+	}
+	if err := source(writer.Write); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,289 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	exportApp    = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	otherApp     = common.HexToAddress("0x75135d8ADb7180640d29d822D9AD59E83E8695b2")
+	exportSender = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+)
+
+const (
+	exportInputs = 5
+	adminToken   = "secret"
+)
+
+type ExportSuite struct {
+	suite.Suite
+	dbFactory *commons.DbFactory
+	repos     Repositories
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+func (s *ExportSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("export.sqlite3")
+	s.repos = NewRepositories(convenience.NewContainer(*db, false))
+	s.seed()
+}
+
+func (s *ExportSuite) TearDownTest() {
+	s.dbFactory.Cleanup()
+}
+
+func (s *ExportSuite) seed() {
+	ctx := context.Background()
+	for _, app := range []common.Address{exportApp, otherApp} {
+		for i := 0; i < exportInputs; i++ {
+			_, err := s.repos.Inputs.Create(ctx, model.AdvanceInput{
+				ID:              app.Hex() + strconv.Itoa(i),
+				Index:           i,
+				Status:          model.CompletionStatusAccepted,
+				MsgSender:       exportSender,
+				Payload:         "0x00",
+				BlockNumber:     uint64(100 + i),
+				BlockTimestamp:  time.Unix(int64(1700000000+i), 0),
+				AppContract:     app,
+				TransactionHash: common.HexToHash(strconv.Itoa(i + 1)).Hex(),
+			})
+			s.Require().NoError(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		_, err := s.repos.Vouchers.CreateVoucher(ctx, &model.ConvenienceVoucher{
+			Destination: exportSender,
+			Payload:     "0xcafe",
+			InputIndex:  uint64(i),
+			OutputIndex: uint64(i),
+			Executed:    i == 0,
+			Value:       "0",
+			AppContract: exportApp,
+		})
+		s.Require().NoError(err)
+	}
+	text := "hello"
+	_, err := s.repos.Notices.Create(ctx, &model.ConvenienceNotice{
+		AppContract: exportApp.Hex(),
+		Payload:     "0x68656c6c6f",
+		InputIndex:  1,
+		OutputIndex: 3,
+		PayloadText: &text,
+	})
+	s.Require().NoError(err)
+	_, err = s.repos.Reports.CreateReport(ctx, model.Report{
+		Index:       0,
+		InputIndex:  2,
+		Payload:     "0xff",
+		AppContract: exportApp,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ExportSuite) export(opts Options) string {
+	var buf bytes.Buffer
+	s.Require().NoError(Export(context.Background(), s.repos, opts, &buf))
+	return buf.String()
+}
+
+func (s *ExportSuite) TestInputsAsCsv() {
+	out := s.export(Options{AppContract: exportApp, Entity: EntityInputs, Format: FormatCSV})
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, exportInputs+1)
+	s.Equal([]string{
+		"index", "id", "status", "msg_sender", "payload", "block_number", "block_timestamp",
+		"input_box_index", "type", "transaction_hash", "block_hash",
+	}, records[0])
+	s.Equal("0", records[1][0])
+	s.Equal("ACCEPTED", records[1][2])
+	s.Equal(exportSender.Hex(), records[1][3])
+	s.Equal("1700000000", records[1][6])
+	s.Equal(common.HexToHash("1").Hex(), records[1][9])
+	s.Equal("", records[1][10])
+	s.Equal("4", records[exportInputs][0])
+}
+
+func (s *ExportSuite) TestIndexRange() {
+	toIndex := uint64(3)
+	out := s.export(Options{
+		AppContract: exportApp, Entity: EntityInputs, Format: FormatJSONL, FromIndex: 1, ToIndex: &toIndex,
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	s.Require().Len(lines, 3)
+	for i, line := range lines {
+		var row InputRow
+		s.Require().NoError(json.Unmarshal([]byte(line), &row))
+		s.Equal(i+1, row.Index)
+	}
+}
+
+func (s *ExportSuite) TestVouchersAsJsonl() {
+	out := s.export(Options{AppContract: exportApp, Entity: EntityVouchers, Format: FormatJSONL})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	s.Require().Len(lines, 3)
+	var row VoucherRow
+	s.Require().NoError(json.Unmarshal([]byte(lines[0]), &row))
+	s.Equal(uint64(0), row.OutputIndex)
+	s.Equal(exportSender.Hex(), row.Destination)
+	s.True(row.Executed)
+	s.Nil(row.ExecutedBy)
+	s.Contains(lines[1], `"executed_at":null`)
+}
+
+func (s *ExportSuite) TestNoticesAndReports() {
+	out := s.export(Options{AppContract: exportApp, Entity: EntityNotices, Format: FormatJSONL})
+	s.JSONEq(`{"output_index":3,"input_index":1,"payload":"0x68656c6c6f","payload_text":"hello"}`, out)
+
+	out = s.export(Options{AppContract: exportApp, Entity: EntityReports, Format: FormatCSV})
+	s.Equal("index,input_index,payload,payload_text\n0,2,0xff,\n", out)
+
+	out = s.export(Options{AppContract: otherApp, Entity: EntityReports, Format: FormatJSONL})
+	s.Empty(out)
+}
+
+func (s *ExportSuite) TestVouchersAsParquet() {
+	out := s.export(Options{AppContract: exportApp, Entity: EntityVouchers, Format: FormatParquet})
+	rows, err := parquet.Read[VoucherRow](bytes.NewReader([]byte(out)), int64(len(out)))
+	s.Require().NoError(err)
+	s.Require().Len(rows, 3)
+	s.Equal(uint64(2), rows[2].OutputIndex)
+	s.Equal(exportSender.Hex(), rows[2].Destination)
+	s.False(rows[2].Executed)
+	s.Nil(rows[2].TransactionHash)
+}
+
+func (s *ExportSuite) TestMoreThanOneBatch() {
+	ctx := context.Background()
+	total := repository.STREAM_BATCH_SIZE*2 + 1
+	for i := 0; i < total; i++ {
+		_, err := s.repos.Vouchers.CreateVoucher(ctx, &model.ConvenienceVoucher{
+			Destination: exportSender,
+			Payload:     "0xcafe",
+			OutputIndex: uint64(i),
+			Value:       "0",
+			AppContract: otherApp,
+		})
+		s.Require().NoError(err)
+	}
+	toIndex := uint64(repository.STREAM_BATCH_SIZE)
+	for _, opts := range []struct {
+		fromIndex uint64
+		toIndex   *uint64
+		count     int
+	}{
+		{0, nil, total},
+		{1, nil, total - 1},
+		{1, &toIndex, repository.STREAM_BATCH_SIZE},
+	} {
+		out := s.export(Options{
+			AppContract: otherApp, Entity: EntityVouchers, Format: FormatJSONL,
+			FromIndex: opts.fromIndex, ToIndex: opts.toIndex,
+		})
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		s.Require().Len(lines, opts.count)
+		for i, line := range lines {
+			var row VoucherRow
+			s.Require().NoError(json.Unmarshal([]byte(line), &row))
+			s.Equal(opts.fromIndex+uint64(i), row.OutputIndex)
+		}
+	}
+}
+
+func (s *ExportSuite) TestInvalidOptions() {
+	toIndex := uint64(1)
+	for _, opts := range []Options{
+		{Entity: "claims", Format: FormatCSV},
+		{Entity: EntityInputs, Format: "xml"},
+		{Entity: EntityInputs, Format: FormatCSV, FromIndex: 2, ToIndex: &toIndex},
+		{Entity: EntityInputs, Format: FormatCSV, FromIndex: math.MaxInt64 + 1},
+	} {
+		s.Error(Export(context.Background(), s.repos, opts, &bytes.Buffer{}))
+	}
+}
+
+func (s *ExportSuite) TestReadRepositories() {
+	// the tables created by the container are read as they are
+	db := s.repos.Inputs.Db
+	repos := NewReadRepositories(&db, nil)
+	var out bytes.Buffer
+	err := Export(context.Background(), repos, Options{
+		AppContract: exportApp, Entity: EntityInputs, Format: FormatJSONL,
+	}, &out)
+	s.Require().NoError(err)
+	s.Equal(exportInputs, strings.Count(out.String(), "\n"))
+
+	// an empty database is not exported as an empty file
+	empty := s.dbFactory.CreateDb("empty.sqlite3")
+	err = Export(context.Background(), NewReadRepositories(empty, nil), Options{
+		AppContract: exportApp, Entity: EntityInputs, Format: FormatJSONL,
+	}, &out)
+	s.Error(err)
+	var count int
+	s.Require().NoError(empty.Get(&count, "SELECT count(*) FROM sqlite_master"))
+	s.Zero(count)
+}
+
+func (s *ExportSuite) TestHttpEndpoint() {
+	e := echo.New()
+	Register(e, s.repos, adminToken)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	get := func(path string, token string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		s.Require().NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := server.Client().Do(req)
+		s.Require().NoError(err)
+		defer res.Body.Close()
+		var body bytes.Buffer
+		_, err = body.ReadFrom(res.Body)
+		s.Require().NoError(err)
+		return res, body.String()
+	}
+
+	res, _ := get("/export/"+exportApp.Hex(), "")
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+	res, _ = get("/export/"+exportApp.Hex(), "wrong")
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+	res, _ = get("/export/not-an-address", adminToken)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+	res, _ = get("/export/"+exportApp.Hex()+"?to_index=-1", adminToken)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+	res, _ = get("/export/"+exportApp.Hex()+"?format=xml", adminToken)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res, body := get("/export/"+exportApp.Hex()+"?entity=vouchers&format=jsonl&from_index=1", adminToken)
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("application/jsonl", res.Header.Get(echo.HeaderContentType))
+	s.Equal(`attachment; filename="`+exportApp.Hex()+`-vouchers.jsonl"`, res.Header.Get(echo.HeaderContentDisposition))
+	s.Len(strings.Split(strings.TrimSpace(body), "\n"), 2)
+}
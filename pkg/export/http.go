@@ -0,0 +1,82 @@
+package export
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+// PathPrefix of the export endpoint, it is streamed so it must not be buffered
+const PathPrefix = "/export/"
+
+// Register adds the endpoint
+//
+//	GET /export/:appContract?entity=inputs&format=csv&from_index=0&to_index=100
+//
+// that streams the same files of the export command to the admins.
+func Register(e *echo.Echo, repos Repositories, adminToken string) {
+	e.GET(PathPrefix+":appContract", func(c echo.Context) error {
+		token, _ := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			return c.String(http.StatusUnauthorized, "invalid admin token")
+		}
+		opts, err := parseOptions(c)
+		if err != nil {
+			return c.String(http.StatusBadRequest, err.Error())
+		}
+		if err := opts.Validate(); err != nil {
+			return c.String(http.StatusBadRequest, err.Error())
+		}
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, ContentType(opts.Format))
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", opts.FileName()))
+		res.WriteHeader(http.StatusOK)
+		ctx := repository.WithReadReplica(c.Request().Context())
+		if err := Export(ctx, repos, opts, res); err != nil {
+			// the status was sent, aborting tells the client the file is incomplete
+			slog.Error("export failed", "app_contract", opts.AppContract, "entity", opts.Entity, "err", err)
+			panic(http.ErrAbortHandler)
+		}
+		return nil
+	})
+}
+
+func parseOptions(c echo.Context) (Options, error) {
+	appContract := c.Param("appContract")
+	if !common.IsHexAddress(appContract) {
+		return Options{}, fmt.Errorf("invalid address: %s", appContract)
+	}
+	opts := Options{
+		AppContract: common.HexToAddress(appContract),
+		Entity:      c.QueryParam("entity"),
+		Format:      c.QueryParam("format"),
+	}
+	if opts.Entity == "" {
+		opts.Entity = EntityInputs
+	}
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+	if value := c.QueryParam("from_index"); value != "" {
+		fromIndex, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid from_index: %s", value)
+		}
+		opts.FromIndex = fromIndex
+	}
+	if value := c.QueryParam("to_index"); value != "" {
+		toIndex, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid to_index: %s", value)
+		}
+		opts.ToIndex = &toIndex
+	}
+	return opts, nil
+}
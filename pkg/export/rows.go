@@ -0,0 +1,118 @@
+package export
+
+import (
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	graphql "github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/model"
+)
+
+// The rows are flat, the json tags are also the CSV header.
+// The timestamps are in seconds since the Unix epoch.
+
+type InputRow struct {
+	Index           int     `json:"index" parquet:"index"`
+	Id              string  `json:"id" parquet:"id"`
+	Status          string  `json:"status" parquet:"status"`
+	MsgSender       string  `json:"msg_sender" parquet:"msg_sender"`
+	Payload         string  `json:"payload" parquet:"payload"`
+	BlockNumber     uint64  `json:"block_number" parquet:"block_number"`
+	BlockTimestamp  int64   `json:"block_timestamp" parquet:"block_timestamp"`
+	InputBoxIndex   int     `json:"input_box_index" parquet:"input_box_index"`
+	Type            string  `json:"type" parquet:"type"`
+	TransactionHash *string `json:"transaction_hash" parquet:"transaction_hash,optional"`
+	BlockHash       *string `json:"block_hash" parquet:"block_hash,optional"`
+}
+
+type VoucherRow struct {
+	OutputIndex     uint64  `json:"output_index" parquet:"output_index"`
+	InputIndex      uint64  `json:"input_index" parquet:"input_index"`
+	Destination     string  `json:"destination" parquet:"destination"`
+	Payload         string  `json:"payload" parquet:"payload"`
+	Value           string  `json:"value" parquet:"value"`
+	Executed        bool    `json:"executed" parquet:"executed"`
+	TransactionHash *string `json:"transaction_hash" parquet:"transaction_hash,optional"`
+	ExecutedBlock   *uint64 `json:"executed_block" parquet:"executed_block,optional"`
+	ExecutedAt      *uint64 `json:"executed_at" parquet:"executed_at,optional"`
+	ExecutedBy      *string `json:"executed_by" parquet:"executed_by,optional"`
+}
+
+type NoticeRow struct {
+	OutputIndex uint64  `json:"output_index" parquet:"output_index"`
+	InputIndex  uint64  `json:"input_index" parquet:"input_index"`
+	Payload     string  `json:"payload" parquet:"payload"`
+	PayloadText *string `json:"payload_text" parquet:"payload_text,optional"`
+}
+
+type ReportRow struct {
+	Index       int     `json:"index" parquet:"index"`
+	InputIndex  int     `json:"input_index" parquet:"input_index"`
+	Payload     string  `json:"payload" parquet:"payload"`
+	PayloadText *string `json:"payload_text" parquet:"payload_text,optional"`
+}
+
+func NewInputRow(input *model.AdvanceInput) (InputRow, error) {
+	status, err := graphql.ConvertCompletionStatus(input.Status)
+	if err != nil {
+		return InputRow{}, err
+	}
+	return InputRow{
+		Index:           input.Index,
+		Id:              input.ID,
+		Status:          status.String(),
+		MsgSender:       input.MsgSender.Hex(),
+		Payload:         input.Payload,
+		BlockNumber:     input.BlockNumber,
+		BlockTimestamp:  input.BlockTimestamp.Unix(),
+		InputBoxIndex:   input.InputBoxIndex,
+		Type:            input.Type,
+		TransactionHash: optionalString(input.TransactionHash),
+		BlockHash:       optionalString(input.BlockHash),
+	}, nil
+}
+
+func NewVoucherRow(voucher *model.ConvenienceVoucher) VoucherRow {
+	return VoucherRow{
+		OutputIndex:     voucher.OutputIndex,
+		InputIndex:      voucher.InputIndex,
+		Destination:     voucher.Destination.Hex(),
+		Payload:         voucher.Payload,
+		Value:           voucher.Value,
+		Executed:        voucher.Executed,
+		TransactionHash: optionalString(voucher.TransactionHash),
+		ExecutedBlock:   optionalUint(voucher.ExecutedBlock),
+		ExecutedAt:      optionalUint(voucher.ExecutedAt),
+		ExecutedBy:      optionalString(voucher.ExecutedBy),
+	}
+}
+
+func NewNoticeRow(notice *model.ConvenienceNotice) NoticeRow {
+	return NoticeRow{
+		OutputIndex: notice.OutputIndex,
+		InputIndex:  notice.InputIndex,
+		Payload:     notice.Payload,
+		PayloadText: notice.PayloadText,
+	}
+}
+
+func NewReportRow(report *model.Report) ReportRow {
+	return ReportRow{
+		Index:       report.Index,
+		InputIndex:  report.InputIndex,
+		Payload:     report.Payload,
+		PayloadText: report.PayloadText,
+	}
+}
+
+// the zero values are unknown, they are exported as null
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalUint(value uint64) *uint64 {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
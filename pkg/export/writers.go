@@ -0,0 +1,115 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// rows kept in memory before they are written as a parquet row group
+const parquetRowGroupSize = 10_000
+
+type rowWriter[T any] interface {
+	Write(row T) error
+	// Close writes the remaining rows, it does not close the underlying writer
+	Close() error
+}
+
+func newRowWriter[T any](format string, w io.Writer) (rowWriter[T], error) {
+	switch format {
+	case FormatCSV:
+		return newCsvWriter[T](w)
+	case FormatJSONL:
+		return &jsonlWriter[T]{encoder: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return &parquetWriter[T]{
+			writer: parquet.NewGenericWriter[T](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize)),
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid format %q", format)
+}
+
+type jsonlWriter[T any] struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter[T]) Write(row T) error {
+	return j.encoder.Encode(row)
+}
+
+func (j *jsonlWriter[T]) Close() error {
+	return nil
+}
+
+type parquetWriter[T any] struct {
+	writer *parquet.GenericWriter[T]
+}
+
+func (p *parquetWriter[T]) Write(row T) error {
+	_, err := p.writer.Write([]T{row})
+	return err
+}
+
+func (p *parquetWriter[T]) Close() error {
+	return p.writer.Close()
+}
+
+// csvWriter writes the fields of the rows in the order they are declared,
+// the nil values are empty cells
+type csvWriter[T any] struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newCsvWriter[T any](w io.Writer) (*csvWriter[T], error) {
+	rowType := reflect.TypeFor[T]()
+	header := make([]string, rowType.NumField())
+	for i := range header {
+		name, _, _ := strings.Cut(rowType.Field(i).Tag.Get("json"), ",")
+		header[i] = name
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter[T]{writer: writer, record: make([]string, len(header))}, nil
+}
+
+func (c *csvWriter[T]) Write(row T) error {
+	value := reflect.ValueOf(row)
+	for i := range c.record {
+		c.record[i] = formatCell(value.Field(i))
+	}
+	return c.writer.Write(c.record)
+}
+
+func (c *csvWriter[T]) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func formatCell(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	}
+	return fmt.Sprint(value.Interface())
+}
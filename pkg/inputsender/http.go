@@ -0,0 +1,53 @@
+package inputsender
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SubmitResponse is returned once the message is queued
+type SubmitResponse struct {
+	Id          string `json:"id"`
+	AppContract string `json:"appContract"`
+	MsgSender   string `json:"msgSender"`
+	Nonce       uint64 `json:"nonce"`
+}
+
+// maxSubmitSize fits the payload of the largest input,
+// hex encoded inside the base64 typed data
+const maxSubmitSize = 3 * MaxPayloadSize
+
+// RegisterRelay registers the /submit endpoint to echo. The body is the
+// JSON with the EIP-712 signature and the base64 typed data
+func RegisterRelay(e *echo.Echo, relay *Relay) {
+	e.POST("/submit", func(c echo.Context) error {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxSubmitSize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.String(http.StatusRequestEntityTooLarge, "body too large")
+		}
+		if err != nil {
+			return c.String(http.StatusBadRequest, "invalid body")
+		}
+		message, err := relay.Submit(c.Request().Context(), string(body))
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrInvalidNonce):
+				return c.String(http.StatusBadRequest, err.Error())
+			default:
+				slog.Error("Relay submit failed", "err", err)
+				return c.String(http.StatusInternalServerError, "internal server error")
+			}
+		}
+		return c.JSON(http.StatusCreated, SubmitResponse{
+			Id:          message.Id.Hex(),
+			AppContract: message.AppContract.Hex(),
+			MsgSender:   message.MsgSender.Hex(),
+			Nonce:       message.Nonce,
+		})
+	})
+}
@@ -0,0 +1,525 @@
+package inputsender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	DefaultRelayInterval       = 2 * time.Second
+	DefaultRelayBatchSize      = 20
+	DefaultRelayReceiptTimeout = 30 * time.Second
+)
+
+// ErrInvalidNonce means the message nonce is not the next one of the signer
+var ErrInvalidNonce = errors.New("invalid nonce")
+
+// errNoInput means the transaction of the message did not add an input,
+// so the signer can submit the message again
+var errNoInput = errors.New("no input added")
+
+// RelayMessage is a signed message waiting to be sent to the InputBox
+type RelayMessage struct {
+	// Id is the hash of the signature, kept as the cartesi transaction id
+	Id          common.Hash
+	AppContract common.Address
+	MsgSender   common.Address
+	Nonce       uint64
+	// MaxGasPrice is the highest gas price, in wei, the signer accepts
+	MaxGasPrice *big.Int
+	Payload     []byte
+	// TransactionHash is set once the message is sent, until it is mined
+	TransactionHash *common.Hash
+	// waiting means AddSignedInput is waiting for the message to be mined
+	waiting bool
+}
+
+// Relay queues the EIP-712 messages signed by the users and sends
+// them in batches to the InputBox, paying for the transactions.
+// The application receives the payload from the relay address;
+// the original signer is kept in the relayed input.
+//
+// The queue is kept in memory, the messages not sent yet are lost on
+// restart and the sent ones are synced without their signer.
+// The nonces and the ids of the relayed messages are stored with their
+// inputs, so a message is never relayed twice.
+type Relay struct {
+	Sender          *LocalSender
+	InputRepository *repository.InputRepository
+	Interval        time.Duration
+	BatchSize       int
+	// ReceiptTimeout is how long a flush waits for a sent message to be mined,
+	// the next flush checks it again
+	ReceiptTimeout time.Duration
+	mutex          sync.Mutex
+	queue          []RelayMessage
+}
+
+func NewRelay(sender *LocalSender, inputRepository *repository.InputRepository) *Relay {
+	return &Relay{
+		Sender:          sender,
+		InputRepository: inputRepository,
+		Interval:        DefaultRelayInterval,
+		BatchSize:       DefaultRelayBatchSize,
+		ReceiptTimeout:  DefaultRelayReceiptTimeout,
+	}
+}
+
+func (r *Relay) String() string {
+	return "relay"
+}
+
+// Submit validates the signed message and queues it
+func (r *Relay) Submit(ctx context.Context, sigAndData string) (*RelayMessage, error) {
+	message, err := r.Sender.readSignedMessage(ctx, sigAndData)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.enqueue(ctx, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// AddInput implements Sender.
+func (r *Relay) AddInput(ctx context.Context, appContract common.Address, payload []byte) (*Result, error) {
+	return r.Sender.AddInput(ctx, appContract, payload)
+}
+
+// AddSignedInput implements Sender. The message takes the nonce of the signer
+// and is sent at once; when it is not mined within the wait timeout of the
+// sender, the next flushes store it.
+func (r *Relay) AddSignedInput(
+	ctx context.Context,
+	appContract common.Address,
+	payload []byte,
+	sigAndData string,
+) (*Result, error) {
+	message, err := r.Sender.readSignedMessage(ctx, sigAndData)
+	if err != nil {
+		return nil, err
+	}
+	if message.AppContract != appContract {
+		return nil, fmt.Errorf("%w: the message app is not %s", ErrInvalidSignature, appContract.Hex())
+	}
+	if !bytes.Equal(message.Payload, payload) {
+		return nil, fmt.Errorf("%w: the message data is not the payload", ErrInvalidSignature)
+	}
+	message.waiting = true
+	if err := r.enqueue(ctx, message); err != nil {
+		return nil, err
+	}
+	tx, err := r.Sender.transact(ctx, appContract, payload, message.MaxGasPrice)
+	if err != nil {
+		r.dequeue(message.Id)
+		return nil, err
+	}
+	txHash := tx.Hash()
+	message.TransactionHash = &txHash
+	r.update(message.Id, func(queued *RelayMessage) {
+		queued.TransactionHash = &txHash
+	})
+	slog.Info("Relay message sent",
+		"id", message.Id.Hex(),
+		"transactionHash", txHash.Hex(),
+	)
+	result := &Result{TransactionHash: txHash, MsgSender: message.MsgSender}
+	waitCtx, cancel := context.WithTimeout(ctx, r.Sender.WaitTimeout)
+	defer cancel()
+	receipt, err := r.Sender.waitReceipt(waitCtx, txHash)
+	if err != nil {
+		// the message was sent, the next flushes wait for it
+		r.update(message.Id, func(queued *RelayMessage) {
+			queued.waiting = false
+		})
+		if waitCtx.Err() != nil && ctx.Err() == nil {
+			slog.Warn("Input not mined yet", "transactionHash", txHash.Hex())
+			return result, nil
+		}
+		return nil, err
+	}
+	if err := r.storeAndDequeue(ctx, *message, receipt); err != nil {
+		// the next flushes store it
+		r.update(message.Id, func(queued *RelayMessage) {
+			queued.waiting = false
+		})
+		return nil, err
+	}
+	result.InputIndex, err = r.Sender.inputIndexFromReceipt(receipt)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// enqueue takes the next nonce of the signer for the message
+func (r *Relay) enqueue(ctx context.Context, message *RelayMessage) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	relayed, err := r.InputRepository.IsMessageRelayed(ctx, message.Id.Hex())
+	if err != nil {
+		return err
+	}
+	for _, queued := range r.queue {
+		relayed = relayed || queued.Id == message.Id
+	}
+	if relayed {
+		return fmt.Errorf("%w: %v", ErrInvalidNonce, repository.ErrMessageRelayed)
+	}
+	expected, err := r.nextNonce(ctx, message.AppContract, message.MsgSender)
+	if err != nil {
+		return err
+	}
+	if message.Nonce != expected {
+		return fmt.Errorf("%w: expected %d, got %d", ErrInvalidNonce, expected, message.Nonce)
+	}
+	r.queue = append(r.queue, *message)
+	slog.Debug("Relay message queued",
+		"id", message.Id.Hex(),
+		"appContract", message.AppContract.Hex(),
+		"msgSender", message.MsgSender.Hex(),
+		"nonce", message.Nonce,
+	)
+	return nil
+}
+
+// NextNonce returns the nonce the next message of the signer must have
+func (r *Relay) NextNonce(ctx context.Context, appContract common.Address, msgSender common.Address) (uint64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.nextNonce(ctx, appContract, msgSender)
+}
+
+// nextNonce counts the stored inputs of the signer and the queued ones,
+// never going back to a nonce already relayed
+func (r *Relay) nextNonce(ctx context.Context, appContract common.Address, msgSender common.Address) (uint64, error) {
+	nonce, err := r.InputRepository.GetNonce(ctx, appContract, msgSender)
+	if err != nil {
+		return 0, err
+	}
+	relayedNonce, err := r.InputRepository.GetRelayedNonce(ctx, appContract, msgSender)
+	if err != nil {
+		return 0, err
+	}
+	nonce = max(nonce, relayedNonce)
+	for _, queued := range r.queue {
+		if queued.AppContract == appContract && queued.MsgSender == msgSender {
+			nonce++
+		}
+	}
+	return nonce, nil
+}
+
+// readSignedMessage extracts the signed message, checking it was signed
+// for this chain and InputBox
+func (s *LocalSender) readSignedMessage(ctx context.Context, sigAndData string) (*RelayMessage, error) {
+	signer, typedData, signature, err := commons.ExtractSigAndData(sigAndData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	chainId, err := s.Client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %w", err)
+	}
+	if err := commons.CheckCartesiTypedData(typedData, chainId, s.InputBoxAddress); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	app, ok := typedData.Message["app"].(string)
+	if !ok || !common.IsHexAddress(app) {
+		return nil, fmt.Errorf("%w: invalid message app", ErrInvalidSignature)
+	}
+	data, ok := typedData.Message["data"].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing message data", ErrInvalidSignature)
+	}
+	payload, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid message data", ErrInvalidSignature)
+	}
+	nonce, err := messageUint(typedData.Message["nonce"], "nonce")
+	if err != nil || !nonce.IsUint64() {
+		return nil, fmt.Errorf("%w: invalid message nonce", ErrInvalidNonce)
+	}
+	maxGasPrice, err := messageUint(typedData.Message["max_gas_price"], "max_gas_price")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return &RelayMessage{
+		Id:          crypto.Keccak256Hash(signature),
+		AppContract: common.HexToAddress(app),
+		MsgSender:   signer,
+		Nonce:       nonce.Uint64(),
+		MaxGasPrice: maxGasPrice,
+		Payload:     payload,
+	}, nil
+}
+
+// messageUint reads the value the way the typed data encodes the uints
+func messageUint(value any, name string) (*big.Int, error) {
+	switch number := value.(type) {
+	case string:
+		parsed, ok := new(big.Int).SetString(number, 0)
+		if !ok || parsed.Sign() < 0 {
+			return nil, fmt.Errorf("invalid %s %q", name, number)
+		}
+		return parsed, nil
+	case float64:
+		if number < 0 {
+			return nil, fmt.Errorf("invalid %s %v", name, number)
+		}
+		parsed, _ := big.NewFloat(number).Int(nil)
+		return parsed, nil
+	default:
+		return nil, fmt.Errorf("missing %s", name)
+	}
+}
+
+// Start implements supervisor.Worker.
+func (r *Relay) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	slog.Info("Relay started", "interval", r.Interval, "batchSize", r.BatchSize)
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// the messages stay queued, the next tick retries them
+			if err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Relay flush failed", "err", err)
+			}
+		}
+	}
+}
+
+// Flush sends the next batch of queued messages and stores them as
+// relayed inputs once mined. The messages not mined within the receipt
+// timeout, or waiting for a lower gas price, stay queued.
+func (r *Relay) Flush(ctx context.Context) error {
+	batch := r.nextBatch()
+	for i, message := range batch {
+		if message.TransactionHash != nil || message.waiting {
+			continue
+		}
+		tx, err := r.Sender.transact(ctx, message.AppContract, message.Payload, message.MaxGasPrice)
+		if errors.Is(err, ErrGasPriceTooHigh) {
+			slog.Warn("Relay message waiting for the gas price", "id", message.Id.Hex(), "err", err)
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// the signer can submit the message again with the same nonce
+			slog.Error("Relay failed to send the message", "id", message.Id.Hex(), "err", err)
+			r.dequeue(message.Id)
+			continue
+		}
+		slog.Info("Relay message sent",
+			"id", message.Id.Hex(),
+			"transactionHash", tx.Hash().Hex(),
+		)
+		txHash := tx.Hash()
+		batch[i].TransactionHash = &txHash
+		r.update(message.Id, func(queued *RelayMessage) {
+			queued.TransactionHash = &txHash
+		})
+	}
+	for _, message := range batch {
+		if message.TransactionHash == nil || message.waiting {
+			continue
+		}
+		receipt, err := r.waitReceipt(ctx, *message.TransactionHash)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Warn("Relay message not mined yet",
+				"id", message.Id.Hex(),
+				"transactionHash", message.TransactionHash.Hex(),
+				"err", err,
+			)
+			continue
+		}
+		if err := r.storeAndDequeue(ctx, message, receipt); err != nil {
+			slog.Error("Relay failed to store the input",
+				"id", message.Id.Hex(),
+				"transactionHash", message.TransactionHash.Hex(),
+				"err", err,
+			)
+		}
+	}
+	return nil
+}
+
+func (r *Relay) waitReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, r.ReceiptTimeout)
+	defer cancel()
+	return r.Sender.waitReceipt(waitCtx, txHash)
+}
+
+func (r *Relay) nextBatch() []RelayMessage {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	size := min(len(r.queue), r.BatchSize)
+	return append([]RelayMessage{}, r.queue[:size]...)
+}
+
+func (r *Relay) update(id common.Hash, apply func(queued *RelayMessage)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i := range r.queue {
+		if r.queue[i].Id == id {
+			apply(&r.queue[i])
+			return
+		}
+	}
+}
+
+func (r *Relay) dequeue(id common.Hash) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.remove(id)
+}
+
+// storeAndDequeue holds the queue lock while storing the input,
+// so the nonce of the signer does not go back meanwhile. The message
+// stays queued when the input is not stored, for the next flush.
+func (r *Relay) storeAndDequeue(ctx context.Context, message RelayMessage, receipt *types.Receipt) error {
+	input, err := r.relayedInput(ctx, message, receipt)
+	if errors.Is(err, errNoInput) {
+		r.dequeue(message.Id)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	err = r.storeInput(ctx, message, input)
+	if err != nil && !errors.Is(err, repository.ErrMessageRelayed) {
+		return err
+	}
+	r.remove(message.Id)
+	return err
+}
+
+// remove must be called holding the queue lock
+func (r *Relay) remove(id common.Hash) {
+	for i, queued := range r.queue {
+		if queued.Id == id {
+			r.queue = append(r.queue[:i], r.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// Pending returns the number of queued messages
+func (r *Relay) Pending() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.queue)
+}
+
+// relayedInput reads the input the message added from the L1
+func (r *Relay) relayedInput(
+	ctx context.Context, message RelayMessage, receipt *types.Receipt,
+) (*model.AdvanceInput, error) {
+	event, err := r.Sender.inputAddedFromReceipt(receipt)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("%w: transaction %s", errNoInput, receipt.TxHash.Hex())
+	}
+	header, err := r.Sender.Client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get header: %w", err)
+	}
+	chainId, err := r.Sender.Client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %w", err)
+	}
+	index := event.Index.Uint64()
+	return &model.AdvanceInput{
+		ID:                     strconv.FormatUint(index, 10),
+		Index:                  int(index),
+		Status:                 model.CompletionStatusUnprocessed,
+		MsgSender:              message.MsgSender,
+		Payload:                hexutil.Encode(message.Payload),
+		BlockNumber:            receipt.BlockNumber.Uint64(),
+		BlockTimestamp:         time.Unix(int64(header.Time), 0),
+		PrevRandao:             header.MixDigest.Hex(),
+		ChainId:                chainId.String(),
+		AppContract:            message.AppContract,
+		EspressoBlockNumber:    -1,
+		EspressoBlockTimestamp: time.Unix(-1, 0),
+		InputBoxIndex:          int(index),
+		AvailBlockNumber:       -1,
+		AvailBlockTimestamp:    time.Unix(-1, 0),
+		Type:                   repository.INPUT_TYPE_RELAY,
+		CartesiTransactionId:   message.Id.Hex(),
+		TransactionHash:        receipt.TxHash.Hex(),
+		LogIndex:               int(event.Raw.Index),
+		BlockHash:              receipt.BlockHash.Hex(),
+	}, nil
+}
+
+// storeInput creates the relayed input, or marks the one already created
+// by the synchronizer, and takes the nonce of the message in the same
+// transaction
+func (r *Relay) storeInput(ctx context.Context, message RelayMessage, input *model.AdvanceInput) error {
+	txCtx, tx, err := repository.StartTransactionContext(ctx, &r.InputRepository.Db)
+	if err != nil {
+		return err
+	}
+	err = r.storeInputInTransaction(txCtx, message, input)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			slog.Error("transaction rollback error", "err", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *Relay) storeInputInTransaction(ctx context.Context, message RelayMessage, input *model.AdvanceInput) error {
+	err := r.InputRepository.SaveRelayedMessage(
+		ctx, message.AppContract, message.MsgSender, message.Nonce, message.Id.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = r.InputRepository.Create(ctx, *input)
+	if err != nil {
+		return err
+	}
+	index := uint64(input.Index)
+	err = r.InputRepository.SetRelayed(
+		ctx, message.AppContract, index, message.MsgSender, message.Id.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	// the input may have been synced before, without its transaction
+	return r.InputRepository.SetTransaction(
+		ctx, message.AppContract, index,
+		input.TransactionHash, input.LogIndex, input.BlockHash,
+	)
+}
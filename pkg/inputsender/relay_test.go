@@ -0,0 +1,305 @@
+package inputsender
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/labstack/echo/v4"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelaySuite struct {
+	suite.Suite
+	chain           *testChain
+	dbFactory       *commons.DbFactory
+	inputRepository *repository.InputRepository
+	relay           *Relay
+	user            *ecdsa.PrivateKey
+}
+
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelaySuite))
+}
+
+func (s *RelaySuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.chain = newTestChain(s.Require())
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("relay.sqlite3")
+	s.inputRepository = &repository.InputRepository{Db: *db}
+	s.Require().NoError(s.inputRepository.CreateTables())
+	s.relay = NewRelay(s.chain.sender, s.inputRepository)
+	var err error
+	s.user, err = crypto.GenerateKey()
+	s.Require().NoError(err)
+}
+
+func (s *RelaySuite) TearDownTest() {
+	s.NoError(s.chain.close())
+	s.dbFactory.Cleanup()
+}
+
+func (s *RelaySuite) signed(nonce uint64, payload string) string {
+	return s.signedWithGasPrice(nonce, payload, maxGasPrice)
+}
+
+func (s *RelaySuite) signedWithGasPrice(nonce uint64, payload string, gasPrice uint64) string {
+	typedData := commons.NewCartesiTypedData(
+		math.NewHexOrDecimal256(1337), s.chain.sender.InputBoxAddress, app, nonce, gasPrice, []byte(payload), // nolint
+	)
+	sigAndData, err := commons.EncodeSigAndData(typedData, s.user)
+	s.Require().NoError(err)
+	return sigAndData
+}
+
+func (s *RelaySuite) TestSubmitAndFlush() {
+	ctx := context.Background()
+	_, err := s.relay.Submit(ctx, s.signed(0, "hello"))
+	s.Require().NoError(err)
+	_, err = s.relay.Submit(ctx, s.signed(1, "world"))
+	s.Require().NoError(err)
+	s.Equal(2, s.relay.Pending())
+
+	s.Require().NoError(s.relay.Flush(ctx))
+	s.Equal(0, s.relay.Pending())
+
+	user := crypto.PubkeyToAddress(s.user.PublicKey)
+	for i, payload := range []string{"hello", "world"} {
+		input, err := s.inputRepository.FindByIndexAndAppContract(ctx, i, &app)
+		s.Require().NoError(err)
+		s.Require().NotNil(input)
+		s.Equal(repository.INPUT_TYPE_RELAY, input.Type)
+		s.Equal(user, input.MsgSender)
+		s.Equal(common.Bytes2Hex([]byte(payload)), strings.TrimPrefix(input.Payload, "0x"))
+		s.Equal(i, input.InputBoxIndex)
+		receipt, err := s.chain.backend.Client().TransactionReceipt(ctx, common.HexToHash(input.TransactionHash))
+		s.Require().NoError(err)
+		s.Equal(receipt.BlockHash.Hex(), input.BlockHash)
+	}
+	nonce, err := s.inputRepository.GetNonce(ctx, app, user)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), nonce)
+	_, err = s.relay.Submit(ctx, s.signed(2, "again"))
+	s.NoError(err)
+}
+
+func (s *RelaySuite) TestInvalidNonce() {
+	ctx := context.Background()
+	_, err := s.relay.Submit(ctx, s.signed(1, "hello"))
+	s.ErrorIs(err, ErrInvalidNonce)
+	_, err = s.relay.Submit(ctx, s.signed(0, "hello"))
+	s.Require().NoError(err)
+	// the queued message already took the nonce
+	_, err = s.relay.Submit(ctx, s.signed(0, "hello again"))
+	s.ErrorIs(err, ErrInvalidNonce)
+	nonce, err := s.relay.NextNonce(ctx, app, crypto.PubkeyToAddress(s.user.PublicKey))
+	s.Require().NoError(err)
+	s.Equal(uint64(1), nonce)
+}
+
+func (s *RelaySuite) TestInvalidDomain() {
+	ctx := context.Background()
+	for _, typedData := range []apitypes.TypedData{
+		commons.NewCartesiTypedData(math.NewHexOrDecimal256(1), s.chain.sender.InputBoxAddress, app, 0, maxGasPrice, nil),
+		commons.NewCartesiTypedData(math.NewHexOrDecimal256(1337), app, app, 0, maxGasPrice, nil), // nolint
+	} {
+		sigAndData, err := commons.EncodeSigAndData(typedData, s.user)
+		s.Require().NoError(err)
+		_, err = s.relay.Submit(ctx, sigAndData)
+		s.ErrorIs(err, ErrInvalidSignature)
+	}
+	s.Equal(0, s.relay.Pending())
+}
+
+func (s *RelaySuite) TestGasPriceTooHigh() {
+	ctx := context.Background()
+	_, err := s.relay.Submit(ctx, s.signedWithGasPrice(0, "hello", 1))
+	s.Require().NoError(err)
+	s.Require().NoError(s.relay.Flush(ctx))
+	// it waits for the gas price to go down
+	s.Equal(1, s.relay.Pending())
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Nil(input)
+}
+
+func (s *RelaySuite) TestNotMinedInTime() {
+	ctx := context.Background()
+	s.chain.stopMining()
+	s.relay.ReceiptTimeout = 50 * time.Millisecond
+	_, err := s.relay.Submit(ctx, s.signed(0, "hello"))
+	s.Require().NoError(err)
+	s.Require().NoError(s.relay.Flush(ctx))
+	s.Equal(1, s.relay.Pending())
+
+	// the next flush checks the sent transaction instead of sending it again
+	s.chain.backend.Commit()
+	s.Require().NoError(s.relay.Flush(ctx))
+	s.Equal(0, s.relay.Pending())
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Require().NotNil(input)
+	s.Equal(0, input.InputBoxIndex)
+}
+
+func (s *RelaySuite) TestAddSignedInput() {
+	ctx := context.Background()
+	sigAndData := s.signed(0, "hello")
+	result, err := s.relay.AddSignedInput(ctx, app, []byte("hello"), sigAndData)
+	s.Require().NoError(err)
+	user := crypto.PubkeyToAddress(s.user.PublicKey)
+	s.Equal(user, result.MsgSender)
+	s.Require().NotNil(result.InputIndex)
+	s.Equal(uint64(0), *result.InputIndex)
+	s.Equal(0, s.relay.Pending())
+
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Require().NotNil(input)
+	s.Equal(repository.INPUT_TYPE_RELAY, input.Type)
+	s.Equal(user, input.MsgSender)
+	s.Equal(result.TransactionHash.Hex(), input.TransactionHash)
+
+	// the signature cannot be replayed
+	_, err = s.relay.AddSignedInput(ctx, app, []byte("hello"), sigAndData)
+	s.ErrorIs(err, ErrInvalidNonce)
+	_, err = s.relay.Submit(ctx, sigAndData)
+	s.ErrorIs(err, ErrInvalidNonce)
+}
+
+func (s *RelaySuite) TestAddSignedInputMismatch() {
+	ctx := context.Background()
+	sigAndData := s.signed(0, "hello")
+	_, err := s.relay.AddSignedInput(ctx, app, []byte("bye"), sigAndData)
+	s.ErrorIs(err, ErrInvalidSignature)
+	other := common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+	_, err = s.relay.AddSignedInput(ctx, other, []byte("hello"), sigAndData)
+	s.ErrorIs(err, ErrInvalidSignature)
+	_, err = s.relay.AddSignedInput(ctx, app, []byte("hello"), "{}")
+	s.ErrorIs(err, ErrInvalidSignature)
+	s.Equal(0, s.relay.Pending())
+}
+
+func (s *RelaySuite) TestAddSignedInputNotMinedInTime() {
+	ctx := context.Background()
+	s.chain.stopMining()
+	s.chain.sender.WaitTimeout = 50 * time.Millisecond
+	result, err := s.relay.AddSignedInput(ctx, app, []byte("hello"), s.signed(0, "hello"))
+	s.Require().NoError(err)
+	s.Nil(result.InputIndex)
+	// the nonce stays taken while the message is queued
+	_, err = s.relay.Submit(ctx, s.signed(0, "again"))
+	s.ErrorIs(err, ErrInvalidNonce)
+
+	s.chain.backend.Commit()
+	s.Require().NoError(s.relay.Flush(ctx))
+	s.Equal(0, s.relay.Pending())
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Require().NotNil(input)
+	s.Equal(result.TransactionHash.Hex(), input.TransactionHash)
+}
+
+func (s *RelaySuite) TestInputAlreadySynced() {
+	ctx := context.Background()
+	// the synchronizer may store the input before the relay
+	_, err := s.inputRepository.Create(ctx, model.AdvanceInput{
+		ID:             "0",
+		Index:          0,
+		MsgSender:      s.chain.sender.InputBoxAddress,
+		Payload:        "0x68656c6c6f",
+		AppContract:    app,
+		BlockTimestamp: time.Now(),
+	})
+	s.Require().NoError(err)
+	_, err = s.relay.Submit(ctx, s.signed(0, "hello"))
+	s.Require().NoError(err)
+	s.Require().NoError(s.relay.Flush(ctx))
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Equal(repository.INPUT_TYPE_RELAY, input.Type)
+	s.Equal(crypto.PubkeyToAddress(s.user.PublicKey), input.MsgSender)
+	s.NotEmpty(input.TransactionHash)
+}
+
+func (s *RelaySuite) TestReplayAfterTheInputIsSyncedAgain() {
+	ctx := context.Background()
+	sigAndData := s.signed(0, "hello")
+	_, err := s.relay.Submit(ctx, sigAndData)
+	s.Require().NoError(err)
+	s.Require().NoError(s.relay.Flush(ctx))
+
+	// the input synced again from the node has the relay as sender
+	_, err = s.inputRepository.Db.Exec(`UPDATE convenience_inputs SET msg_sender = $1`,
+		s.chain.sender.InputBoxAddress.Hex())
+	s.Require().NoError(err)
+	_, err = s.relay.Submit(ctx, sigAndData)
+	s.ErrorIs(err, ErrInvalidNonce)
+	_, err = s.relay.Submit(ctx, s.signed(0, "again"))
+	s.ErrorIs(err, ErrInvalidNonce)
+	nonce, err := s.relay.NextNonce(ctx, app, crypto.PubkeyToAddress(s.user.PublicKey))
+	s.Require().NoError(err)
+	s.Equal(uint64(1), nonce)
+}
+
+func (s *RelaySuite) TestKeepTheMessageWhenTheStoreFails() {
+	ctx := context.Background()
+	_, err := s.relay.Submit(ctx, s.signed(0, "hello"))
+	s.Require().NoError(err)
+	_, err = s.inputRepository.Db.Exec(`ALTER TABLE convenience_relayed_messages RENAME TO relayed_messages_moved`)
+	s.Require().NoError(err)
+	s.Require().NoError(s.relay.Flush(ctx))
+	s.Equal(1, s.relay.Pending())
+	input, err := s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.Nil(input)
+
+	_, err = s.inputRepository.Db.Exec(`ALTER TABLE relayed_messages_moved RENAME TO convenience_relayed_messages`)
+	s.Require().NoError(err)
+	s.Require().NoError(s.relay.Flush(ctx))
+	s.Equal(0, s.relay.Pending())
+	input, err = s.inputRepository.FindByIndexAndAppContract(ctx, 0, &app)
+	s.Require().NoError(err)
+	s.NotNil(input)
+}
+
+func (s *RelaySuite) TestSubmitEndpoint() {
+	e := echo.New()
+	RegisterRelay(e, s.relay)
+
+	req := httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(s.signed(0, "hello")))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Contains(rec.Body.String(), crypto.PubkeyToAddress(s.user.PublicKey).Hex())
+
+	req = httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(s.signed(0, "hello")))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	s.Equal(http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader("{}"))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	s.Equal(http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(strings.Repeat("a", maxSubmitSize+1)))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+}
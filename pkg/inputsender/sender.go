@@ -0,0 +1,262 @@
+// This package sends inputs to the InputBox, so the dev tooling can post
+// inputs through the API without a separate wallet.
+package inputsender
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// PrivateKeyEnv is the environment variable with the key that pays for the inputs
+const PrivateKeyEnv = "SENDER_PRIVATE_KEY"
+
+const DefaultWaitTimeout = 10 * time.Second
+
+// MaxPayloadSize is the largest input payload the InputBox accepts
+const MaxPayloadSize = 2 * 1024 * 1024
+
+var (
+	// ErrInvalidSignature means the signed message does not match the input
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrNoSigner means there is no key configured to send the inputs
+	ErrNoSigner = errors.New("input sender is disabled")
+	// ErrNoRelay means the signed inputs are disabled, as there is no relay
+	ErrNoRelay = errors.New("signed inputs require the relay")
+	// ErrGasPriceTooHigh means the gas price is above the one the signer accepts
+	ErrGasPriceTooHigh = errors.New("gas price too high")
+)
+
+// Result of an input sent to the InputBox
+type Result struct {
+	TransactionHash common.Hash
+	// InputIndex is nil when the transaction was not mined in time
+	InputIndex *uint64
+	// MsgSender is the address that signed the input. The signed inputs are
+	// stored as relay inputs, the application receives them from the relay address
+	MsgSender common.Address
+}
+
+type Sender interface {
+	// AddInput sends the payload signed by the local key
+	AddInput(ctx context.Context, appContract common.Address, payload []byte) (*Result, error)
+	// AddSignedInput relays the payload of an EIP-712 message signed by the user,
+	// as encoded by commons.ExtractSigAndData, taking the nonce of the signer
+	AddSignedInput(ctx context.Context, appContract common.Address, payload []byte, sigAndData string) (*Result, error)
+}
+
+// Client is the part of the ethclient used by the sender
+type Client interface {
+	bind.ContractBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// LocalSender sends the inputs with a local key
+type LocalSender struct {
+	Client          Client
+	InputBoxAddress common.Address
+	PrivateKey      *ecdsa.PrivateKey
+	// WaitTimeout is how long to wait for the input index
+	WaitTimeout time.Duration
+	inputBox    *contracts.InputBoxFilterer
+}
+
+func NewLocalSender(
+	client Client,
+	inputBoxAddress common.Address,
+	privateKey *ecdsa.PrivateKey,
+) (*LocalSender, error) {
+	// the filterer is only used to parse the logs
+	inputBox, err := contracts.NewInputBoxFilterer(inputBoxAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalSender{
+		Client:          client,
+		InputBoxAddress: inputBoxAddress,
+		PrivateKey:      privateKey,
+		WaitTimeout:     DefaultWaitTimeout,
+		inputBox:        inputBox,
+	}, nil
+}
+
+// NewLocalSenderFromEnv dials the rpc and reads the key from SENDER_PRIVATE_KEY
+func NewLocalSenderFromEnv(
+	ctx context.Context,
+	rpcUrl string,
+	inputBoxAddress common.Address,
+) (*LocalSender, error) {
+	envPrivateKey, ok := os.LookupEnv(PrivateKeyEnv)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrNoSigner, PrivateKeyEnv)
+	}
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(envPrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("create private key: %w", err)
+	}
+	client, err := ethclient.DialContext(ctx, rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("dial to %v: %w", rpcUrl, err)
+	}
+	return NewLocalSender(client, inputBoxAddress, privateKey)
+}
+
+// AddInput implements Sender.
+func (s *LocalSender) AddInput(ctx context.Context, appContract common.Address, payload []byte) (*Result, error) {
+	return s.send(ctx, appContract, payload, crypto.PubkeyToAddress(s.PrivateKey.PublicKey))
+}
+
+// AddSignedInput implements Sender. Without the nonces kept by the relay,
+// the signatures could be replayed, so the signed inputs are refused.
+func (s *LocalSender) AddSignedInput(
+	ctx context.Context,
+	appContract common.Address,
+	payload []byte,
+	sigAndData string,
+) (*Result, error) {
+	return nil, ErrNoRelay
+}
+
+func (s *LocalSender) send(
+	ctx context.Context,
+	appContract common.Address,
+	payload []byte,
+	msgSender common.Address,
+) (*Result, error) {
+	tx, err := s.transact(ctx, appContract, payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Input sent",
+		"appContract", appContract.Hex(),
+		"msgSender", msgSender.Hex(),
+		"transactionHash", tx.Hash().Hex(),
+	)
+	result := &Result{
+		TransactionHash: tx.Hash(),
+		MsgSender:       msgSender,
+	}
+	result.InputIndex, err = s.waitInputIndex(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// transact sends the addInput transaction without waiting for it.
+// The maxGasPrice is optional, with it the transaction pays the suggested
+// gas price only when it is not above the max.
+func (s *LocalSender) transact(
+	ctx context.Context,
+	appContract common.Address,
+	payload []byte,
+	maxGasPrice *big.Int,
+) (*types.Transaction, error) {
+	chainId, err := s.Client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %w", err)
+	}
+	txOpts, err := bind.NewKeyedTransactorWithChainID(s.PrivateKey, chainId)
+	if err != nil {
+		return nil, fmt.Errorf("create transactor: %w", err)
+	}
+	txOpts.Context = ctx
+	if maxGasPrice != nil {
+		gasPrice, err := s.Client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("suggest gas price: %w", err)
+		}
+		if gasPrice.Cmp(maxGasPrice) > 0 {
+			return nil, fmt.Errorf("%w: %s above %s", ErrGasPriceTooHigh, gasPrice, maxGasPrice)
+		}
+		// a legacy transaction never pays more than its gas price
+		txOpts.GasPrice = gasPrice
+	}
+	inputBox, err := contracts.NewInputBoxTransactor(s.InputBoxAddress, s.Client)
+	if err != nil {
+		return nil, fmt.Errorf("bind input box: %w", err)
+	}
+	tx, err := inputBox.AddInput(txOpts, appContract, payload)
+	if err != nil {
+		return nil, fmt.Errorf("add input: %w", err)
+	}
+	return tx, nil
+}
+
+// waitInputIndex returns the index of the input added by the transaction
+// or nil when it was not mined within the wait timeout
+func (s *LocalSender) waitInputIndex(ctx context.Context, txHash common.Hash) (*uint64, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, s.WaitTimeout)
+	defer cancel()
+	receipt, err := s.waitReceipt(waitCtx, txHash)
+	if err != nil {
+		if waitCtx.Err() != nil && ctx.Err() == nil {
+			slog.Warn("Input not mined yet", "transactionHash", txHash.Hex())
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s.inputIndexFromReceipt(receipt)
+}
+
+// waitReceipt polls the receipt until the transaction is mined
+func (s *LocalSender) waitReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	const pollFrequency = 100 * time.Millisecond
+	for {
+		receipt, err := s.Client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) && ctx.Err() == nil {
+			return nil, fmt.Errorf("receipt retrieval failed: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollFrequency):
+		}
+	}
+}
+
+func (s *LocalSender) inputIndexFromReceipt(receipt *types.Receipt) (*uint64, error) {
+	event, err := s.inputAddedFromReceipt(receipt)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	index := event.Index.Uint64()
+	return &index, nil
+}
+
+// inputAddedFromReceipt returns the InputAdded event of the receipt, or nil
+// when the InputBox did not emit it
+func (s *LocalSender) inputAddedFromReceipt(receipt *types.Receipt) (*contracts.InputBoxInputAdded, error) {
+	if receipt.Status == types.ReceiptStatusFailed {
+		return nil, fmt.Errorf("transaction %s was reverted", receipt.TxHash.Hex())
+	}
+	for _, vLog := range receipt.Logs {
+		if vLog.Address != s.InputBoxAddress {
+			continue
+		}
+		event, err := s.inputBox.ParseInputAdded(*vLog)
+		if err != nil {
+			continue
+		}
+		return event, nil
+	}
+	return nil, nil
+}
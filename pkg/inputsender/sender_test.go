@@ -0,0 +1,192 @@
+package inputsender
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"log/slog"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var app = common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")
+
+// maxGasPrice signed by the users, above the simulated gas price
+const maxGasPrice = 1_000_000_000_000
+
+// inputBoxBin deploys a stub of the InputBox. On any call it emits
+// InputAdded(calldata[4:36], counter, calldata[68:]) and increments the counter
+func inputBoxBin(inputAddedID common.Hash) string {
+	return "0x6044600c60003960446000f3" + // deploy the 0x44 bytes below
+		"604436036044602037" + // copy the payload length and data to memory 0x20
+		"6020600052" + // store the bytes offset at memory 0
+		"60005480600101600055" + // load and increment the counter
+		"600435" + // load the app
+		"7f" + inputAddedID.Hex()[2:] +
+		"602436036000a300" // log3 the abi encoded bytes
+}
+
+// testChain mines the simulated chain in the background
+// and sends the inputs to the InputBox stub
+type testChain struct {
+	backend  *simulated.Backend
+	sender   *LocalSender
+	mining   chan struct{}
+	stopOnce sync.Once
+}
+
+func newTestChain(require *require.Assertions) *testChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(1e18)},
+	})
+	txOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337)) // nolint
+	require.NoError(err)
+	inputBoxAbi, err := contracts.InputBoxMetaData.GetAbi()
+	require.NoError(err)
+	inputBox, _, _, err := bind.DeployContract(
+		txOpts, abi.ABI{}, common.FromHex(inputBoxBin(inputBoxAbi.Events["InputAdded"].ID)), backend.Client(),
+	)
+	require.NoError(err)
+	backend.Commit()
+	sender, err := NewLocalSender(backend.Client(), inputBox, key)
+	require.NoError(err)
+	sender.WaitTimeout = time.Second
+	chain := &testChain{backend: backend, sender: sender, mining: make(chan struct{})}
+	go func() {
+		for {
+			select {
+			case <-chain.mining:
+				return
+			case <-time.After(10 * time.Millisecond): // nolint
+				backend.Commit()
+			}
+		}
+	}()
+	return chain
+}
+
+// stopMining leaves the new transactions pending until the next Commit
+func (c *testChain) stopMining() {
+	c.stopOnce.Do(func() { close(c.mining) })
+}
+
+func (c *testChain) close() error {
+	c.stopMining()
+	return c.backend.Close()
+}
+
+type SenderSuite struct {
+	suite.Suite
+	chain   *testChain
+	backend *simulated.Backend
+	sender  *LocalSender
+}
+
+func TestSenderSuite(t *testing.T) {
+	suite.Run(t, new(SenderSuite))
+}
+
+func (s *SenderSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.chain = newTestChain(s.Require())
+	s.backend = s.chain.backend
+	s.sender = s.chain.sender
+}
+
+func (s *SenderSuite) TearDownTest() {
+	s.NoError(s.chain.close())
+}
+
+// sentInput decodes the addInput call of the transaction
+func (s *SenderSuite) sentInput(txHash common.Hash) (common.Address, []byte) {
+	tx, _, err := s.backend.Client().TransactionByHash(context.Background(), txHash)
+	s.Require().NoError(err)
+	inputBoxAbi, err := contracts.InputBoxMetaData.GetAbi()
+	s.Require().NoError(err)
+	method, err := inputBoxAbi.MethodById(tx.Data()[:4])
+	s.Require().NoError(err)
+	s.Equal("addInput", method.Name)
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	s.Require().NoError(err)
+	return args[0].(common.Address), args[1].([]byte)
+}
+
+func (s *SenderSuite) signedInput(key *ecdsa.PrivateKey, appContract common.Address, payload []byte) string {
+	typedData := commons.NewCartesiTypedData(
+		math.NewHexOrDecimal256(1337), s.sender.InputBoxAddress, appContract, 0, maxGasPrice, payload, // nolint
+	)
+	sigAndData, err := commons.EncodeSigAndData(typedData, key)
+	s.Require().NoError(err)
+	return sigAndData
+}
+
+func (s *SenderSuite) TestAddInput() {
+	ctx := context.Background()
+	result, err := s.sender.AddInput(ctx, app, []byte("hello"))
+	s.Require().NoError(err)
+	s.Equal(crypto.PubkeyToAddress(s.sender.PrivateKey.PublicKey), result.MsgSender)
+	s.Require().NotNil(result.InputIndex)
+	s.Equal(uint64(0), *result.InputIndex)
+	appContract, payload := s.sentInput(result.TransactionHash)
+	s.Equal(app, appContract)
+	s.Equal([]byte("hello"), payload)
+
+	result, err = s.sender.AddInput(ctx, app, []byte("world"))
+	s.Require().NoError(err)
+	s.Require().NotNil(result.InputIndex)
+	s.Equal(uint64(1), *result.InputIndex)
+}
+
+func (s *SenderSuite) TestAddSignedInputWithoutRelay() {
+	ctx := context.Background()
+	user, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	sigAndData := s.signedInput(user, app, []byte("hello"))
+	_, err = s.sender.AddSignedInput(ctx, app, []byte("hello"), sigAndData)
+	s.ErrorIs(err, ErrNoRelay)
+}
+
+func (s *SenderSuite) TestInputIndexFromReceipt() {
+	inputBoxAbi, err := contracts.InputBoxMetaData.GetAbi()
+	s.Require().NoError(err)
+	event := inputBoxAbi.Events["InputAdded"]
+	data, err := event.Inputs.NonIndexed().Pack([]byte("hello"))
+	s.Require().NoError(err)
+	inputAdded := &types.Log{
+		Address: s.sender.InputBoxAddress,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(app.Bytes()),
+			common.BigToHash(big.NewInt(7)), // nolint
+		},
+		Data: data,
+	}
+	other := &types.Log{Address: app, Topics: []common.Hash{event.ID}}
+	receipt := &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{other, inputAdded},
+	}
+	index, err := s.sender.inputIndexFromReceipt(receipt)
+	s.Require().NoError(err)
+	s.Require().NotNil(index)
+	s.Equal(uint64(7), *index)
+
+	receipt.Status = types.ReceiptStatusFailed
+	_, err = s.sender.inputIndexFromReceipt(receipt)
+	s.Error(err)
+}
@@ -0,0 +1,53 @@
+package inspect
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+// Register the inspect API to echo, following api/inspect.yaml
+func Register(e *echo.Echo, inspector Inspector) {
+	e.GET("/inspect/:app/*", func(c echo.Context) error {
+		payload, err := url.PathUnescape(c.Param("*"))
+		if err != nil {
+			return c.String(http.StatusBadRequest, "invalid payload")
+		}
+		return handle(c, inspector, []byte(payload))
+	})
+	e.POST("/inspect/:app", func(c echo.Context) error {
+		body := http.MaxBytesReader(c.Response(), c.Request().Body, MaxPayloadSize)
+		payload, err := io.ReadAll(body)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.String(http.StatusRequestEntityTooLarge, "payload too large")
+		}
+		if err != nil {
+			return c.String(http.StatusBadRequest, "invalid payload")
+		}
+		return handle(c, inspector, payload)
+	})
+}
+
+func handle(c echo.Context, inspector Inspector, payload []byte) error {
+	app := c.Param("app")
+	if !common.IsHexAddress(app) {
+		return c.String(http.StatusBadRequest, "invalid app address")
+	}
+	result, err := inspector.Inspect(c.Request().Context(), common.HexToAddress(app), payload)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTimeout):
+			return c.String(http.StatusGatewayTimeout, err.Error())
+		case errors.Is(err, ErrUpstream):
+			return c.String(http.StatusBadGateway, err.Error())
+		default:
+			return c.String(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,123 @@
+// This package forwards the inspect-state requests to the node,
+// so the frontends can use the same server for queries and inspects.
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MaxPayloadSize is the largest inspect payload, the size of the
+// buffer the machine of the node reads it from
+const MaxPayloadSize = 2 * 1024 * 1024
+
+var (
+	// ErrTimeout means the node did not answer within the inspect timeout
+	ErrTimeout = errors.New("inspect timed out")
+	// ErrUpstream means the node answered with an error or an invalid result
+	ErrUpstream = errors.New("inspect failed")
+	// ErrDisabled means there is no node to forward the inspect to
+	ErrDisabled = errors.New("inspect is disabled")
+)
+
+// Report generated by the application while inspecting its state
+type Report struct {
+	// Report data in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+}
+
+// Result of an inspect-state request, as described in api/inspect.yaml
+type Result struct {
+	// Whether the inspection completed or not, and why not
+	Status string `json:"status"`
+	// Exception payload in Ethereum hex binary format, when the status is Exception
+	ExceptionPayload *string `json:"exception_payload"`
+	// Reports generated by the application
+	Reports []Report `json:"reports"`
+	// Number of processed inputs since genesis
+	ProcessedInputCount int `json:"processed_input_count"`
+}
+
+type Inspector interface {
+	Inspect(ctx context.Context, appContract common.Address, payload []byte) (*Result, error)
+}
+
+// Proxy sends the inspect requests to the node inspect endpoint
+type Proxy struct {
+	// Url of the node inspect endpoint, e.g. http://localhost:10012/inspect
+	Url     string
+	Timeout time.Duration
+	Client  *http.Client
+}
+
+func NewProxy(url string, timeout time.Duration) *Proxy {
+	return &Proxy{
+		Url:     strings.TrimSuffix(url, "/"),
+		Timeout: timeout,
+		Client:  http.DefaultClient,
+	}
+}
+
+// Inspect implements Inspector.
+func (p *Proxy) Inspect(ctx context.Context, appContract common.Address, payload []byte) (*Result, error) {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	url := fmt.Sprintf("%s/%s", p.Url, appContract.Hex())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	slog.Debug("Forwarding inspect", "url", url, "payloadLength", len(payload))
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrTimeout
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUpstream, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrTimeout
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUpstream, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s: %s", ErrUpstream, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return decodeResult(body)
+}
+
+// decodeResult parses the node response and checks the report payloads
+func decodeResult(body []byte) (*Result, error) {
+	var result Result
+	err := json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid result: %v", ErrUpstream, err)
+	}
+	if result.Reports == nil {
+		result.Reports = []Report{}
+	}
+	for i, report := range result.Reports {
+		if _, err := hexutil.Decode(report.Payload); err != nil {
+			return nil, fmt.Errorf("%w: invalid report %d payload: %v", ErrUpstream, i, err)
+		}
+	}
+	return &result, nil
+}
@@ -0,0 +1,114 @@
+package inspect
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+const app = "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+
+type InspectSuite struct {
+	suite.Suite
+	node     *httptest.Server
+	response string
+	status   int
+	delay    time.Duration
+	received []byte
+	path     string
+	echo     *echo.Echo
+}
+
+func TestInspectSuite(t *testing.T) {
+	suite.Run(t, new(InspectSuite))
+}
+
+func (s *InspectSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.status = http.StatusOK
+	s.response = `{"status":"Accepted","exception_payload":null,"reports":[{"payload":"0xdeadbeef"}],"processed_input_count":2}`
+	s.delay = 0
+	s.node = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.path = r.URL.Path
+		s.received, _ = io.ReadAll(r.Body)
+		time.Sleep(s.delay)
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.response))
+	}))
+	s.echo = echo.New()
+	Register(s.echo, NewProxy(s.node.URL+"/inspect/", 100*time.Millisecond)) // nolint
+}
+
+func (s *InspectSuite) TearDownTest() {
+	s.node.Close()
+}
+
+func (s *InspectSuite) request(method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *InspectSuite) TestPost() {
+	rec := s.request(http.MethodPost, "/inspect/"+app, "hello")
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Equal("hello", string(s.received))
+	s.Equal("/inspect/0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb", s.path)
+
+	var result Result
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.Equal("Accepted", result.Status)
+	s.Nil(result.ExceptionPayload)
+	s.Equal(2, result.ProcessedInputCount)
+	s.Require().Len(result.Reports, 1)
+	s.Equal("0xdeadbeef", result.Reports[0].Payload)
+}
+
+func (s *InspectSuite) TestGetDecodesThePayload() {
+	rec := s.request(http.MethodGet, "/inspect/"+app+"/hello%20world", "")
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Equal("hello world", string(s.received))
+}
+
+func (s *InspectSuite) TestPayloadTooLarge() {
+	rec := s.request(http.MethodPost, "/inspect/"+app, strings.Repeat("a", MaxPayloadSize+1))
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+	s.Empty(s.received)
+}
+
+func (s *InspectSuite) TestInvalidApp() {
+	rec := s.request(http.MethodPost, "/inspect/0x1234", "")
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *InspectSuite) TestTimeout() {
+	s.delay = 300 * time.Millisecond // nolint
+	rec := s.request(http.MethodPost, "/inspect/"+app, "")
+	s.Equal(http.StatusGatewayTimeout, rec.Code)
+	s.Equal(ErrTimeout.Error(), rec.Body.String())
+}
+
+func (s *InspectSuite) TestUpstreamError() {
+	s.status = http.StatusInternalServerError
+	s.response = "machine halted"
+	rec := s.request(http.MethodPost, "/inspect/"+app, "")
+	s.Equal(http.StatusBadGateway, rec.Code)
+	s.Contains(rec.Body.String(), "machine halted")
+}
+
+func (s *InspectSuite) TestInvalidReport() {
+	s.response = `{"status":"Accepted","reports":[{"payload":"nothex"}],"processed_input_count":0}`
+	rec := s.request(http.MethodPost, "/inspect/"+app, "")
+	s.Equal(http.StatusBadGateway, rec.Code)
+	s.Contains(rec.Body.String(), "invalid report 0 payload")
+}
@@ -0,0 +1,41 @@
+// This package checks the output proofs without an RPC. The outputs of an
+// epoch are the leaves of a Merkle tree whose root is the claim of the epoch.
+package merkle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// OutputHash is the leaf of the output in the outputs tree
+func OutputHash(output []byte) common.Hash {
+	return crypto.Keccak256Hash(output)
+}
+
+// RootFromSiblings folds the leaf with its siblings from the bottom of the
+// tree. The bits of the index tell on which side of each sibling the node is.
+func RootFromSiblings(leaf common.Hash, index uint64, siblings []common.Hash) (common.Hash, error) {
+	if index>>uint(len(siblings)) != 0 {
+		return common.Hash{}, fmt.Errorf("%w: index %d does not fit a tree of height %d",
+			ErrInvalidProof, index, len(siblings))
+	}
+	node := leaf
+	for i, sibling := range siblings {
+		if (index>>uint(i))&1 == 0 {
+			node = crypto.Keccak256Hash(node.Bytes(), sibling.Bytes())
+		} else {
+			node = crypto.Keccak256Hash(sibling.Bytes(), node.Bytes())
+		}
+	}
+	return node, nil
+}
+
+// OutputsRoot computes the outputs root from the output and its proof
+func OutputsRoot(output []byte, index uint64, siblings []common.Hash) (common.Hash, error) {
+	return RootFromSiblings(OutputHash(output), index, siblings)
+}
@@ -0,0 +1,70 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+)
+
+type MerkleSuite struct {
+	suite.Suite
+}
+
+func TestMerkleSuite(t *testing.T) {
+	suite.Run(t, new(MerkleSuite))
+}
+
+// tree builds every level of a complete tree from its leaves
+func tree(leaves []common.Hash) [][]common.Hash {
+	levels := [][]common.Hash{leaves}
+	for len(levels[len(levels)-1]) > 1 {
+		prev := levels[len(levels)-1]
+		next := make([]common.Hash, len(prev)/2)
+		for i := range next {
+			next[i] = crypto.Keccak256Hash(prev[2*i].Bytes(), prev[2*i+1].Bytes())
+		}
+		levels = append(levels, next)
+	}
+	return levels
+}
+
+func siblings(levels [][]common.Hash, index uint64) []common.Hash {
+	result := []common.Hash{}
+	for _, level := range levels[:len(levels)-1] {
+		result = append(result, level[index^1])
+		index >>= 1
+	}
+	return result
+}
+
+func (s *MerkleSuite) TestOutputsRoot() {
+	outputs := [][]byte{{0x01}, {0x02}, {0x03}, {}}
+	leaves := make([]common.Hash, len(outputs))
+	for i, output := range outputs {
+		leaves[i] = OutputHash(output)
+	}
+	levels := tree(leaves)
+	root := levels[len(levels)-1][0]
+	for i, output := range outputs {
+		computed, err := OutputsRoot(output, uint64(i), siblings(levels, uint64(i)))
+		s.Require().NoError(err)
+		s.Equal(root, computed)
+	}
+
+	// another output or index does not reach the root
+	computed, err := OutputsRoot([]byte{0x04}, 0, siblings(levels, 0))
+	s.Require().NoError(err)
+	s.NotEqual(root, computed)
+	computed, err = OutputsRoot(outputs[0], 1, siblings(levels, 0))
+	s.Require().NoError(err)
+	s.NotEqual(root, computed)
+}
+
+func (s *MerkleSuite) TestIndexOutOfTree() {
+	_, err := RootFromSiblings(common.Hash{}, 4, make([]common.Hash, 2)) // nolint
+	s.ErrorIs(err, ErrInvalidProof)
+	_, err = RootFromSiblings(common.Hash{}, ^uint64(0), make([]common.Hash, 64)) // nolint
+	s.NoError(err)
+}
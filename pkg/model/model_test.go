@@ -6,8 +6,6 @@ package model
 import (
 	"context"
 	"fmt"
-	"os"
-	"path"
 	"testing"
 	"time"
 
@@ -18,7 +16,6 @@ import (
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 
@@ -41,17 +38,13 @@ type ModelSuite struct {
 	inputRepository    *cRepos.InputRepository
 	voucherRepository  *cRepos.VoucherRepository
 	noticeRepository   *cRepos.NoticeRepository
-	tempDir            string
+	dbFactory          *commons.DbFactory
 	convenienceService *services.ConvenienceService
 }
 
 func (s *ModelSuite) SetupTest() {
-	tempDir, err := os.MkdirTemp("", "")
-	s.tempDir = tempDir
-	s.NoError(err)
-	sqliteFileName := fmt.Sprintf("test%d.sqlite3", time.Now().UnixMilli())
-	sqliteFileName = path.Join(tempDir, sqliteFileName)
-	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("model.sqlite3")
 	container := convenience.NewContainer(*db, false)
 	decoder := container.GetOutputDecoder()
 	s.reportRepository = container.GetReportRepository()
@@ -119,7 +112,7 @@ func (s *ModelSuite) TestItGetsNoReports() {
 }
 
 func (s *ModelSuite) TearDownTest() {
-	defer os.RemoveAll(s.tempDir)
+	s.dbFactory.Cleanup()
 }
 
 func (s *ModelSuite) getAllVouchers(
@@ -0,0 +1,129 @@
+package reader
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	abiAdminToken = "secret"
+	transferAbi   = `[{"type":"function","name":"transfer","stateMutability":"nonpayable",
+		"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]}]`
+)
+
+var (
+	abiApp   = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	abiToken = common.HexToAddress("0xc6e7DF5E7b4f2A278906862b61205850344D4e7d")
+)
+
+type AbiSuite struct {
+	suite.Suite
+	server *testServer
+}
+
+func TestAbiSuite(t *testing.T) {
+	suite.Run(t, new(AbiSuite))
+}
+
+func (s *AbiSuite) SetupTest() {
+	s.server = newTestServer("abi.sqlite3")
+	s.server.register(Options{
+		AbiRegistry:  s.server.container.GetAbiRegistry(),
+		AdminToken:   abiAdminToken,
+		Applications: []common.Address{abiApp},
+	})
+}
+
+func (s *AbiSuite) TearDownTest() {
+	s.server.cleanup()
+}
+
+func (s *AbiSuite) query(path string, token string, query string, variables map[string]any) map[string]any {
+	var res map[string]any
+	s.server.query(s.Require(), path, token, query, variables, &res)
+	return res
+}
+
+const registerAbiMutation = `mutation($address: String!, $abi: String!) {
+	registerAbi(address: $address, abi: $abi)
+}`
+
+func (s *AbiSuite) registerAbi(token string, address string, abiJSON string) map[string]any {
+	return s.query("/graphql", token, registerAbiMutation, map[string]any{"address": address, "abi": abiJSON})
+}
+
+func (s *AbiSuite) errorCode(res map[string]any) string {
+	errs, ok := res["errors"].([]any)
+	s.Require().True(ok, "expected errors: %v", res)
+	return errs[0].(map[string]any)["extensions"].(map[string]any)["code"].(string)
+}
+
+func (s *AbiSuite) TestRegisterAbiErrors() {
+	s.Equal(CodeUnauthorized, s.errorCode(s.registerAbi("", abiToken.Hex(), transferAbi)))
+	s.Equal(CodeUnauthorized, s.errorCode(s.registerAbi("wrong", abiToken.Hex(), transferAbi)))
+	s.Equal(CodeInvalidArgument, s.errorCode(s.registerAbi(abiAdminToken, "wrong", transferAbi)))
+	s.Equal(CodeInvalidArgument, s.errorCode(s.registerAbi(abiAdminToken, abiToken.Hex(), "{}")))
+}
+
+func (s *AbiSuite) TestDecodedPayload() {
+	ctx := context.Background()
+	res := s.registerAbi(abiAdminToken, abiToken.Hex(), transferAbi)
+	s.Require().Nil(res["errors"])
+	s.Equal(true, res["data"].(map[string]any)["registerAbi"])
+
+	parsed, err := abi.JSON(strings.NewReader(transferAbi))
+	s.Require().NoError(err)
+	calldata, err := parsed.Pack("transfer", abiApp, big.NewInt(10))
+	s.Require().NoError(err)
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	rawVoucher, err := outputsAbi.Pack("Voucher", abiToken, big.NewInt(0), calldata)
+	s.Require().NoError(err)
+	_, err = s.server.container.GetVoucherRepository().CreateVoucher(ctx, &cModel.ConvenienceVoucher{
+		AppContract: abiApp,
+		Destination: abiToken,
+		Payload:     hexutil.Encode(rawVoucher),
+	})
+	s.Require().NoError(err)
+	_, err = s.server.container.GetNoticeRepository().Create(ctx, &cModel.ConvenienceNotice{
+		AppContract: abiApp.Hex(),
+		Payload:     hexutil.Encode(calldata),
+	})
+	s.Require().NoError(err)
+
+	res = s.query("/graphql/"+abiApp.Hex(), "", `{
+		vouchers { edges { node { decodedPayload { kind name signature args } } } }
+		notices { edges { node { decodedPayload { name } } } }
+	}`, nil)
+	s.Require().Nil(res["errors"])
+	data := res["data"].(map[string]any)
+	voucher := data["vouchers"].(map[string]any)["edges"].([]any)[0].(map[string]any)["node"].(map[string]any)
+	s.Equal(map[string]any{
+		"kind":      "function",
+		"name":      "transfer",
+		"signature": "transfer(address,uint256)",
+		"args":      `{"to":"` + abiApp.Hex() + `","amount":"10"}`,
+	}, voucher["decodedPayload"])
+
+	// the application has no ABI
+	notice := data["notices"].(map[string]any)["edges"].([]any)[0].(map[string]any)["node"].(map[string]any)
+	s.Nil(notice["decodedPayload"])
+
+	res = s.registerAbi(abiAdminToken, abiApp.Hex(), transferAbi)
+	s.Require().Nil(res["errors"])
+	res = s.query("/graphql/"+abiApp.Hex(), "", `{
+		notices { edges { node { decodedPayload { name } } } }
+	}`, nil)
+	s.Require().Nil(res["errors"])
+	notice = res["data"].(map[string]any)["notices"].(map[string]any)["edges"].([]any)[0].(map[string]any)["node"].(map[string]any)
+	s.Equal(map[string]any{"name": "transfer"}, notice["decodedPayload"])
+}
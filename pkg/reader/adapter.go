@@ -3,7 +3,9 @@ package reader
 import (
 	"context"
 
+	cRepos "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	graphql "github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/model"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type Adapter interface {
@@ -15,6 +17,7 @@ type Adapter interface {
 	GetReports(
 		ctx context.Context,
 		first *int, last *int, after *string, before *string, inputIndex *int,
+		where *graphql.PayloadFilter,
 	) (*graphql.ReportConnection, error)
 
 	GetAllReportsByInputIndex(
@@ -44,6 +47,7 @@ type Adapter interface {
 	GetNotices(
 		ctx context.Context,
 		first *int, last *int, after *string, before *string, inputIndex *int,
+		where *graphql.PayloadFilter,
 	) (*graphql.NoticeConnection, error)
 
 	GetVoucher(
@@ -65,4 +69,57 @@ type Adapter interface {
 		ctx context.Context,
 		inputIndex *int,
 	) (*graphql.Connection[*graphql.Notice], error)
+
+	GetVoucherProof(
+		ctx context.Context,
+		voucher *graphql.Voucher,
+	) (*graphql.Proof, error)
+
+	GetNoticeProof(
+		ctx context.Context,
+		notice *graphql.Notice,
+	) (*graphql.Proof, error)
+
+	GetClaims(
+		ctx context.Context,
+		first *int, last *int, after *string, before *string,
+	) (*graphql.ClaimConnection, error)
+
+	GetVoucherClaim(
+		ctx context.Context,
+		voucher *graphql.Voucher,
+	) (*graphql.Claim, error)
+
+	GetOutputClaim(
+		ctx context.Context,
+		appContract string,
+		inputIndex int,
+	) (*graphql.Claim, error)
+
+	Search(
+		ctx context.Context,
+		appContract *common.Address,
+		text string,
+		first *int, after *string,
+	) (*graphql.SearchConnection, error)
+
+	GetStats(
+		ctx context.Context,
+		appContract *common.Address,
+	) (*graphql.Stats, error)
+
+	GetWebhookDeliveries(
+		ctx context.Context,
+		first *int,
+		last *int,
+		after *string,
+		before *string,
+		filter cRepos.WebhookDeliveryFilter,
+	) (*graphql.WebhookDeliveryConnection, error)
+
+	GetNonce(
+		ctx context.Context,
+		appContract common.Address,
+		msgSender common.Address,
+	) (uint64, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	cRepos "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
@@ -18,6 +19,9 @@ type AdapterV1 struct {
 	reportRepository   *cRepos.ReportRepository
 	inputRepository    *cRepos.InputRepository
 	voucherRepository  *cRepos.VoucherRepository
+	claimRepository    *cRepos.ClaimRepository
+	searchRepository   *cRepos.SearchRepository
+	webhookRepository  *cRepos.WebhookRepository
 	convenienceService *services.ConvenienceService
 }
 
@@ -27,31 +31,61 @@ func NewAdapterV1(
 ) Adapter {
 	slog.Debug("NewAdapterV1")
 	reportRepository := &cRepos.ReportRepository{
-		Db: db,
+		Db:     db,
+		ReadDb: convenienceService.ReportRepository.ReadDb,
 	}
 	err := reportRepository.CreateTables()
 	if err != nil {
 		panic(err)
 	}
 	inputRepository := &cRepos.InputRepository{
-		Db: *db,
+		Db:     *db,
+		ReadDb: convenienceService.InputRepository.ReadDb,
 	}
 	err = inputRepository.CreateTables()
 	if err != nil {
 		panic(err)
 	}
 	voucherRepository := &cRepos.VoucherRepository{
-		Db: *db,
+		Db:     *db,
+		ReadDb: convenienceService.VoucherRepository.ReadDb,
 	}
 	err = voucherRepository.CreateTables()
 	if err != nil {
 		panic(err)
 	}
+	claimRepository := &cRepos.ClaimRepository{
+		Db:     *db,
+		ReadDb: convenienceService.InputRepository.ReadDb,
+	}
+	err = claimRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	searchRepository := &cRepos.SearchRepository{
+		Db:     db,
+		ReadDb: convenienceService.ReportRepository.ReadDb,
+	}
+	err = searchRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
+	webhookRepository := &cRepos.WebhookRepository{
+		Db:     db,
+		ReadDb: convenienceService.ReportRepository.ReadDb,
+	}
+	err = webhookRepository.CreateTables()
+	if err != nil {
+		panic(err)
+	}
 
 	return AdapterV1{
 		reportRepository:   reportRepository,
 		inputRepository:    inputRepository,
 		voucherRepository:  voucherRepository,
+		claimRepository:    claimRepository,
+		searchRepository:   searchRepository,
+		webhookRepository:  webhookRepository,
 		convenienceService: convenienceService,
 	}
 }
@@ -63,8 +97,9 @@ func (a AdapterV1) GetNotices(
 	after *string,
 	before *string,
 	inputIndex *int,
+	where *graphql.PayloadFilter,
 ) (*graphql.Connection[*graphql.Notice], error) {
-	filters := []*cModel.ConvenienceFilter{}
+	filters := graphql.ConvertPayloadFilter(where)
 	filters, err := addAppContractFilterAsNeeded(ctx, filters)
 	if err != nil {
 		return nil, err
@@ -106,7 +141,7 @@ func (a AdapterV1) GetVoucher(ctx context.Context, outputIndex int) (*graphql.Vo
 		return nil, err
 	}
 	if voucher == nil {
-		return nil, fmt.Errorf("voucher not found")
+		return nil, NotFoundError("voucher not found")
 	}
 	return graphql.ConvertConvenientVoucherV1(*voucher), nil
 }
@@ -116,7 +151,10 @@ func getAppContractFromContext(ctx context.Context) (*common.Address, error) {
 	if appContractParam != nil {
 		appContract, ok := appContractParam.(string)
 		if !ok {
-			return nil, fmt.Errorf("wrong app contract type")
+			return nil, UnknownAppError("wrong app contract type")
+		}
+		if !common.IsHexAddress(appContract) {
+			return nil, InvalidArgumentError("invalid app contract: %s", appContract)
 		}
 		value := common.HexToAddress(appContract)
 		return &value, nil
@@ -184,14 +222,14 @@ func (a AdapterV1) GetVouchers(
 }
 
 func (a AdapterV1) GetAllNoticesByInputIndex(ctx context.Context, inputIndex *int) (*graphql.Connection[*graphql.Notice], error) {
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	loaders := loaders.For(ctx)
-	if loaders == nil {
-		return a.GetNotices(ctx, nil, nil, nil, nil, inputIndex)
+	if loaders == nil || appContract == nil {
+		return a.GetNotices(ctx, nil, nil, nil, nil, inputIndex, nil)
 	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
 		key := cRepos.GenerateBatchNoticeKey(appContract.Hex(), uint64(*inputIndex))
 		notices, err := loaders.NoticeLoader.Load(ctx, key)
 		if err != nil {
@@ -206,14 +244,14 @@ func (a AdapterV1) GetAllNoticesByInputIndex(ctx context.Context, inputIndex *in
 }
 
 func (a AdapterV1) GetAllVouchersByInputIndex(ctx context.Context, inputIndex *int) (*graphql.Connection[*graphql.Voucher], error) {
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	loaders := loaders.For(ctx)
-	if loaders == nil {
+	if loaders == nil || appContract == nil {
 		return a.GetVouchers(ctx, nil, nil, nil, nil, inputIndex, nil)
 	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
 		key := cRepos.GenerateBatchVoucherKey(appContract, *inputIndex)
 		vouchers, err := loaders.VoucherLoader.Load(ctx, key)
 		if err != nil {
@@ -241,7 +279,7 @@ func (a AdapterV1) GetNotice(ctx context.Context, outputIndex int) (*graphql.Not
 		return nil, err
 	}
 	if notice == nil {
-		return nil, fmt.Errorf("notice not found")
+		return nil, NotFoundError("notice not found")
 	}
 	return graphql.ConvertConvenientNoticeV1(*notice), nil
 }
@@ -263,7 +301,7 @@ func (a AdapterV1) GetReport(
 		return nil, err
 	}
 	if report == nil {
-		return nil, fmt.Errorf("report not found")
+		return nil, NotFoundError("report not found")
 	}
 	return a.convertToReport(*report), nil
 }
@@ -271,12 +309,10 @@ func (a AdapterV1) GetReport(
 func (a AdapterV1) GetReports(
 	ctx context.Context,
 	first *int, last *int, after *string, before *string, inputIndex *int,
+	where *graphql.PayloadFilter,
 ) (*graphql.ReportConnection, error) {
-	filters, err := graphql.ConvertToConvenienceFilter(nil)
-	if err != nil {
-		return nil, err
-	}
-	filters, err = addAppContractFilterAsNeeded(ctx, filters)
+	filters := graphql.ConvertPayloadFilter(where)
+	filters, err := addAppContractFilterAsNeeded(ctx, filters)
 	if err != nil {
 		return nil, err
 	}
@@ -304,14 +340,14 @@ func (a AdapterV1) GetReports(
 }
 
 func (a AdapterV1) GetAllReportsByInputIndex(ctx context.Context, inputIndex *int) (*graphql.Connection[*graphql.Report], error) {
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	loaders := loaders.For(ctx)
-	if loaders == nil {
-		return a.GetReports(ctx, nil, nil, nil, nil, inputIndex)
+	if loaders == nil || appContract == nil {
+		return a.GetReports(ctx, nil, nil, nil, nil, inputIndex, nil)
 	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
 		key := cRepos.GenerateBatchReportKey(appContract, *inputIndex)
 		reports, err := loaders.ReportLoader.Load(ctx, key)
 		if err != nil {
@@ -340,10 +376,189 @@ func (a AdapterV1) convertToReport(
 	report cModel.Report,
 ) *graphql.Report {
 	return &graphql.Report{
-		Index:      report.Index,
-		InputIndex: report.InputIndex,
-		Payload:    report.Payload,
+		Index:       report.Index,
+		InputIndex:  report.InputIndex,
+		Payload:     report.Payload,
+		PayloadText: report.PayloadText,
+		PayloadJson: report.PayloadJson,
+		AppContract: report.AppContract.Hex(),
+	}
+}
+
+// GetVoucherProof implements Adapter.
+// The voucher lists do not read the proofs, they are loaded in batches.
+func (a AdapterV1) GetVoucherProof(ctx context.Context, voucher *graphql.Voucher) (*graphql.Proof, error) {
+	loaders := loaders.For(ctx)
+	if loaders == nil || voucher.AppContract == "" {
+		return &voucher.Proof, nil
+	}
+	key := cRepos.GenerateBatchProofKey(
+		common.HexToAddress(voucher.AppContract).Hex(),
+		uint64(voucher.Index),
+	)
+	proof, err := loaders.VoucherProofLoader.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if proof == nil {
+		return &voucher.Proof, nil
+	}
+	converted := graphql.ConvertProof(proof.ProofOutputIndex, proof.OutputHashesSiblings)
+	return &converted, nil
+}
+
+// GetNoticeProof implements Adapter.
+// The notice lists do not read the proofs, they are loaded in batches.
+func (a AdapterV1) GetNoticeProof(ctx context.Context, notice *graphql.Notice) (*graphql.Proof, error) {
+	loaders := loaders.For(ctx)
+	if loaders == nil || notice.AppContract == "" {
+		return &notice.Proof, nil
+	}
+	key := cRepos.GenerateBatchProofKey(
+		common.HexToAddress(notice.AppContract).Hex(),
+		uint64(notice.Index),
+	)
+	proof, err := loaders.NoticeProofLoader.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if proof == nil {
+		return &notice.Proof, nil
+	}
+	converted := graphql.ConvertProof(proof.ProofOutputIndex, proof.OutputHashesSiblings)
+	return &converted, nil
+}
+
+// GetClaims implements Adapter.
+func (a AdapterV1) GetClaims(
+	ctx context.Context,
+	first *int, last *int, after *string, before *string,
+) (*graphql.ClaimConnection, error) {
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := a.claimRepository.FindAll(ctx, first, last, after, before, appContract)
+	if err != nil {
+		slog.Error("Adapter GetClaims", "error", err)
+		return nil, err
+	}
+	return graphql.ConvertToClaimConnection(
+		claims.Rows,
+		int(claims.Offset),
+		int(claims.Total),
+	), nil
+}
+
+// Search implements Adapter.
+// Without the appContract argument it searches the application of the URL, if any.
+func (a AdapterV1) Search(
+	ctx context.Context,
+	appContract *common.Address,
+	text string,
+	first *int, after *string,
+) (*graphql.SearchConnection, error) {
+	if appContract == nil {
+		var err error
+		appContract, err = getAppContractFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	docs, err := a.searchRepository.Search(ctx, appContract, text, first, after)
+	if err != nil {
+		slog.Error("Adapter Search", "error", err)
+		return nil, err
+	}
+	return graphql.ConvertToSearchConnection(
+		docs.Rows,
+		int(docs.Offset),
+		int(docs.Total),
+	), nil
+}
+
+// GetWebhookDeliveries implements Adapter.
+func (a AdapterV1) GetWebhookDeliveries(
+	ctx context.Context,
+	first *int,
+	last *int,
+	after *string,
+	before *string,
+	filter cRepos.WebhookDeliveryFilter,
+) (*graphql.WebhookDeliveryConnection, error) {
+	deliveries, err := a.webhookRepository.FindAllDeliveries(ctx, first, last, after, before, filter)
+	if err != nil {
+		slog.Error("Adapter GetWebhookDeliveries", "error", err)
+		return nil, err
+	}
+	return graphql.ConvertToWebhookDeliveryConnection(
+		deliveries.Rows,
+		int(deliveries.Offset),
+		int(deliveries.Total),
+	), nil
+}
+
+// GetStats implements Adapter.
+// Without the appContract argument it aggregates the application of the URL, if any.
+func (a AdapterV1) GetStats(
+	ctx context.Context,
+	appContract *common.Address,
+) (*graphql.Stats, error) {
+	if appContract == nil {
+		var err error
+		appContract, err = getAppContractFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stats, err := a.convenienceService.Stats(ctx, appContract)
+	if err != nil {
+		slog.Error("Adapter GetStats", "error", err)
+		return nil, err
+	}
+	return graphql.ConvertStats(stats)
+}
+
+// GetNonce implements Adapter.
+// The nonce is the number of inputs the sender already has in the application.
+func (a AdapterV1) GetNonce(
+	ctx context.Context,
+	appContract common.Address,
+	msgSender common.Address,
+) (uint64, error) {
+	return a.inputRepository.GetNonce(ctx, appContract, msgSender)
+}
+
+// GetVoucherClaim implements Adapter.
+// The voucher proof is executable once the claim of the epoch of its input is accepted.
+func (a AdapterV1) GetVoucherClaim(ctx context.Context, voucher *graphql.Voucher) (*graphql.Claim, error) {
+	return a.GetOutputClaim(ctx, voucher.AppContract, voucher.InputIndex)
+}
+
+// GetOutputClaim implements Adapter.
+// It returns the accepted claim of the epoch of the input, nil while there is none.
+func (a AdapterV1) GetOutputClaim(
+	ctx context.Context,
+	appContract string,
+	inputIndex int,
+) (*graphql.Claim, error) {
+	if appContract == "" {
+		return nil, nil
+	}
+	input, err := a.GetInputByIndex(ctx, inputIndex)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber, err := strconv.ParseUint(input.BlockNumber, 10, 64) // nolint
+	if err != nil {
+		return nil, err
+	}
+	claim, err := a.claimRepository.FindAcceptedByBlock(
+		ctx, common.HexToAddress(appContract), blockNumber)
+	if err != nil || claim == nil {
+		return nil, err
 	}
+	return graphql.ConvertClaim(*claim), nil
 }
 
 // GetInputByIndex implements Adapter.
@@ -356,7 +571,7 @@ func (a AdapterV1) GetInputByIndex(
 		return nil, err
 	}
 	loaders := loaders.For(ctx)
-	if loaders != nil {
+	if loaders != nil && appContract != nil {
 		key := cRepos.GenerateBatchInputKey(appContract.Hex(), uint64(inputIndex))
 		input, err := loaders.InputLoader.Load(ctx, key)
 		if err != nil {
@@ -374,7 +589,7 @@ func (a AdapterV1) GetInputByIndex(
 
 func getConvertedInputFromGraphql(input *cModel.AdvanceInput) (*graphql.Input, error) {
 	if input == nil {
-		return nil, fmt.Errorf("input not found")
+		return nil, NotFoundError("input not found")
 	}
 	convertedInput, err := graphql.ConvertInput(*input)
 
@@ -13,6 +13,7 @@ import (
 	cRepos "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/devnet"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/loaders"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/model"
 	"github.com/ethereum/go-ethereum/common"
 	_ "github.com/ncruces/go-sqlite3/driver"
@@ -30,6 +31,7 @@ type AdapterSuite struct {
 	inputRepository   *cRepos.InputRepository
 	voucherRepository *cRepos.VoucherRepository
 	noticeRepository  *cRepos.NoticeRepository
+	claimRepository   *cRepos.ClaimRepository
 	adapter           Adapter
 	dbFactory         *commons.DbFactory
 }
@@ -50,7 +52,8 @@ func (s *AdapterSuite) SetupTest() {
 	s.NoError(err)
 
 	s.voucherRepository = &cRepos.VoucherRepository{
-		Db: *db,
+		Db:               *db,
+		OutputRepository: cRepos.OutputRepository{Db: *db},
 	}
 	err = s.voucherRepository.CreateTables()
 	s.Require().NoError(err)
@@ -60,12 +63,19 @@ func (s *AdapterSuite) SetupTest() {
 	}
 	err = s.noticeRepository.CreateTables()
 	s.Require().NoError(err)
+
+	s.claimRepository = &cRepos.ClaimRepository{
+		Db: *db,
+	}
+	err = s.claimRepository.CreateTables()
+	s.Require().NoError(err)
 	s.adapter = &AdapterV1{
 		reportRepository:  s.reportRepository,
 		inputRepository:   s.inputRepository,
 		voucherRepository: s.voucherRepository,
+		claimRepository:   s.claimRepository,
 		convenienceService: services.NewConvenienceService(
-			s.voucherRepository, s.noticeRepository, nil, nil,
+			s.voucherRepository, s.noticeRepository, s.inputRepository, s.reportRepository,
 		),
 	}
 }
@@ -99,16 +109,76 @@ func (s *AdapterSuite) TestGetReport() {
 func (s *AdapterSuite) TestGetReports() {
 	ctx := context.Background()
 	s.createTestData(ctx)
-	res, err := s.adapter.GetReports(ctx, nil, nil, nil, nil, nil)
+	res, err := s.adapter.GetReports(ctx, nil, nil, nil, nil, nil, nil)
 	s.NoError(err)
 	s.Equal(3, res.TotalCount)
 
 	inputIndex := 1
-	res, err = s.adapter.GetReports(ctx, nil, nil, nil, nil, &inputIndex)
+	res, err = s.adapter.GetReports(ctx, nil, nil, nil, nil, &inputIndex, nil)
 	s.NoError(err)
 	s.Equal(1, res.TotalCount)
 }
 
+func (s *AdapterSuite) TestGetReportsByJson() {
+	ctx := context.Background()
+	for i, payload := range []string{`{"type":"trade"}`, `{"type":"cancel"}`, "binary\x00"} {
+		_, err := s.reportRepository.CreateReport(ctx, cModel.Report{
+			Index:   i,
+			Payload: common.Bytes2Hex([]byte(payload)),
+		})
+		s.Require().NoError(err)
+	}
+	trade := "trade"
+	res, err := s.adapter.GetReports(ctx, nil, nil, nil, nil, nil, &model.PayloadFilter{
+		JSON: []*model.UserDataFilter{{Path: "$.type", Eq: &trade}},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(1, res.TotalCount)
+	s.Equal(`{"type":"trade"}`, *res.Edges[0].Node.PayloadText)
+	s.Equal(`{"type":"trade"}`, *res.Edges[0].Node.PayloadJson)
+
+	res, err = s.adapter.GetReports(ctx, nil, nil, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(3, res.TotalCount)
+	s.Nil(res.Edges[2].Node.PayloadText)
+}
+
+func (s *AdapterSuite) TestGetStats() {
+	app := common.HexToAddress(devnet.ApplicationAddress)
+	ctx := context.WithValue(context.Background(), cModel.AppContractKey, app.Hex())
+	for i := 0; i < 3; i++ {
+		_, err := s.inputRepository.Create(ctx, cModel.AdvanceInput{
+			ID:             strconv.Itoa(i),
+			Index:          i,
+			Status:         cModel.CompletionStatus(i % 2),
+			AppContract:    app,
+			BlockTimestamp: time.Date(2024, 5, 1, 12, 0, i, 0, time.UTC),
+		})
+		s.Require().NoError(err)
+	}
+	_, err := s.reportRepository.CreateReport(ctx, cModel.Report{AppContract: app, Payload: "0x01"})
+	s.Require().NoError(err)
+
+	stats, err := s.adapter.GetStats(ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(3, stats.Inputs)
+	s.Equal([]*model.StatusCount{
+		{Status: model.CompletionStatusUnprocessed, Count: 2},
+		{Status: model.CompletionStatusAccepted, Count: 1},
+	}, stats.InputsByStatus)
+	s.Equal(1, stats.Reports)
+	s.Equal(0, stats.Vouchers)
+	s.Equal(1, stats.UniqueSenders)
+	s.Equal("1714564800", *stats.FirstInputTimestamp)
+	s.Equal([]*model.DailyCount{{Day: "2024-05-01", Count: 3}}, stats.InputsPerDay)
+
+	other := common.HexToAddress("0x01")
+	stats, err = s.adapter.GetStats(ctx, &other)
+	s.Require().NoError(err)
+	s.Equal(0, stats.Inputs)
+	s.Nil(stats.FirstInputTimestamp)
+}
+
 func (s *AdapterSuite) TestGetInputs() {
 	ctx := context.Background()
 	s.createTestData(ctx)
@@ -204,20 +274,20 @@ func (s *AdapterSuite) TestGetNoticesFilteredByAppContract() {
 	s.createTestData(ctx)
 
 	// without address
-	res, err := s.adapter.GetNotices(ctx, nil, nil, nil, nil, nil)
+	res, err := s.adapter.GetNotices(ctx, nil, nil, nil, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Equal(3, res.TotalCount) // returns all
 
 	// with inexistent address
 	appContract2 := common.HexToAddress("0x000028bb862fb57e8a2bcd567a2e929a0be56a5e")
 	ctx2 := context.WithValue(ctx, cModel.AppContractKey, appContract2.Hex())
-	res2, err := s.adapter.GetNotices(ctx2, nil, nil, nil, nil, nil)
+	res2, err := s.adapter.GetNotices(ctx2, nil, nil, nil, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Equal(0, res2.TotalCount) // returns nothing
 
 	// with correct address
 	ctx3 := context.WithValue(ctx, cModel.AppContractKey, appContract.Hex())
-	res3, err := s.adapter.GetNotices(ctx3, nil, nil, nil, nil, nil)
+	res3, err := s.adapter.GetNotices(ctx3, nil, nil, nil, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Equal(3, res3.TotalCount) // returns all
 }
@@ -300,20 +370,20 @@ func (s *AdapterSuite) TestGetReportsFilteredByAppContract() {
 	s.createTestData(ctx)
 
 	// without address
-	res, err := s.adapter.GetReports(ctx, nil, nil, nil, nil, nil)
+	res, err := s.adapter.GetReports(ctx, nil, nil, nil, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Equal(3, res.TotalCount) // returns all
 
 	// with inexistent address
 	appContract2 := common.HexToAddress("0x000028bb862fb57e8a2bcd567a2e929a0be56a5e")
 	ctx2 := context.WithValue(ctx, cModel.AppContractKey, appContract2.Hex())
-	res2, err := s.adapter.GetReports(ctx2, nil, nil, nil, nil, nil)
+	res2, err := s.adapter.GetReports(ctx2, nil, nil, nil, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Equal(0, res2.TotalCount) // returns nothing
 
 	// with correct address
 	ctx3 := context.WithValue(ctx, cModel.AppContractKey, appContract.Hex())
-	res3, err := s.adapter.GetReports(ctx3, nil, nil, nil, nil, nil)
+	res3, err := s.adapter.GetReports(ctx3, nil, nil, nil, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Equal(3, res3.TotalCount) // returns all
 }
@@ -342,6 +412,92 @@ func (s *AdapterSuite) TestGetReportFilteredByAppContract() {
 	s.NotNil(res3) // returns all
 }
 
+func (s *AdapterSuite) TestGetAllByInputIndexWithLoadersOnRootRoute() {
+	ctx := context.Background()
+	s.createTestData(ctx)
+	ctx = context.WithValue(ctx, loaders.LoadersKey, loaders.NewLoaders(
+		s.reportRepository,
+		s.voucherRepository,
+		s.noticeRepository,
+		s.inputRepository,
+	))
+	inputIndex := 1
+
+	// without address it must not use the batch keys
+	vouchers, err := s.adapter.GetAllVouchersByInputIndex(ctx, &inputIndex)
+	s.Require().NoError(err)
+	s.Equal(1, vouchers.TotalCount)
+	input, err := s.adapter.GetInputByIndex(ctx, inputIndex)
+	s.Require().NoError(err)
+	s.Equal(1, input.Index)
+
+	// nested fields are scoped by the parent app contract
+	appCtx := withAppContract(ctx, input.AppContract)
+	reports, err := s.adapter.GetAllReportsByInputIndex(appCtx, &inputIndex)
+	s.Require().NoError(err)
+	s.Equal(1, reports.TotalCount)
+	s.Equal(input.AppContract, reports.Edges[0].Node.AppContract)
+	notices, err := s.adapter.GetAllNoticesByInputIndex(appCtx, &inputIndex)
+	s.Require().NoError(err)
+	s.Equal(1, notices.TotalCount)
+
+	// the list does not read the proof, it comes from the loader
+	notice := notices.Edges[0].Node
+	err = s.noticeRepository.SetProof(ctx, &cModel.ConvenienceNotice{
+		AppContract:          notice.AppContract,
+		OutputIndex:          uint64(notice.Index),
+		OutputHashesSiblings: `["0x03"]`,
+		ProofOutputIndex:     7, // nolint
+	})
+	s.Require().NoError(err)
+	s.Empty(notice.Proof.OutputHashesSiblings)
+	proof, err := s.adapter.GetNoticeProof(appCtx, notice)
+	s.Require().NoError(err)
+	s.Equal("7", proof.OutputIndex)
+	s.Equal([]string{"0x03"}, proof.OutputHashesSiblings)
+}
+
+func (s *AdapterSuite) TestGetClaimsAndVoucherClaim() {
+	ctx := context.Background()
+	appContract := common.HexToAddress(devnet.ApplicationAddress)
+	s.createTestData(ctx)
+	claim := cModel.ConvenienceClaim{
+		AppContract: appContract.Hex(),
+		EpochIndex:  0,
+		FirstBlock:  0,
+		LastBlock:   9, // nolint
+		ClaimHash:   common.HexToHash("0xaa").Hex(),
+		Submitter:   common.HexToAddress("0x01").Hex(),
+	}
+	s.Require().NoError(s.claimRepository.Create(ctx, claim))
+	ctx = context.WithValue(ctx, cModel.AppContractKey, appContract.Hex())
+
+	claims, err := s.adapter.GetClaims(ctx, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Equal(1, claims.TotalCount)
+	s.Equal("9", claims.Edges[0].Node.LastBlock)
+	s.False(claims.Edges[0].Node.Accepted)
+
+	voucher, err := s.adapter.GetVoucher(ctx, 1)
+	s.Require().NoError(err)
+	res, err := s.adapter.GetVoucherClaim(ctx, voucher)
+	s.Require().NoError(err)
+	s.Nil(res) // not executable yet
+
+	s.Require().NoError(s.claimRepository.SetAccepted(ctx, claim))
+	res, err = s.adapter.GetVoucherClaim(ctx, voucher)
+	s.Require().NoError(err)
+	s.Require().NotNil(res)
+	s.True(res.Accepted)
+	s.Equal(claim.ClaimHash, res.ClaimHash)
+
+	otherApp := common.HexToAddress("0x000028bb862fb57e8a2bcd567a2e929a0be56a5e")
+	ctx = context.WithValue(context.Background(), cModel.AppContractKey, otherApp.Hex())
+	claims, err = s.adapter.GetClaims(ctx, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Equal(0, claims.TotalCount)
+}
+
 func (s *AdapterSuite) createTestData(ctx context.Context) {
 	appContract := common.HexToAddress(devnet.ApplicationAddress)
 	for i := 0; i < 3; i++ {
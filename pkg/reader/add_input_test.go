@@ -0,0 +1,130 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+const addInputToken = "secret"
+
+var (
+	fakeSenderAddress = common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	fakeSignerAddress = common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+)
+
+type fakeSender struct {
+	appContract common.Address
+	payload     []byte
+	sigAndData  string
+}
+
+func (f *fakeSender) AddInput(ctx context.Context, appContract common.Address, payload []byte) (*inputsender.Result, error) {
+	f.appContract = appContract
+	f.payload = payload
+	index := uint64(3)
+	return &inputsender.Result{
+		TransactionHash: common.HexToHash("0x01"),
+		InputIndex:      &index,
+		MsgSender:       fakeSenderAddress,
+	}, nil
+}
+
+func (f *fakeSender) AddSignedInput(ctx context.Context, appContract common.Address, payload []byte, sigAndData string) (*inputsender.Result, error) {
+	f.appContract = appContract
+	f.payload = payload
+	f.sigAndData = sigAndData
+	if sigAndData == "wrong" {
+		return nil, fmt.Errorf("%w: wrong", inputsender.ErrInvalidSignature)
+	}
+	return &inputsender.Result{
+		TransactionHash: common.HexToHash("0x02"),
+		MsgSender:       fakeSignerAddress,
+	}, nil
+}
+
+type AddInputSuite struct {
+	suite.Suite
+	server *testServer
+	sender *fakeSender
+}
+
+func TestAddInputSuite(t *testing.T) {
+	suite.Run(t, new(AddInputSuite))
+}
+
+func (s *AddInputSuite) SetupTest() {
+	s.server = newTestServer("add_input.sqlite3")
+	s.sender = &fakeSender{}
+	s.server.register(Options{
+		InputSender: s.sender,
+		AdminToken:  addInputToken,
+	})
+}
+
+func (s *AddInputSuite) TearDownTest() {
+	s.server.cleanup()
+}
+
+func (s *AddInputSuite) query(query string, variables map[string]any, token string) map[string]any {
+	var res map[string]any
+	s.server.query(s.Require(), "/graphql", token, query, variables, &res)
+	return res
+}
+
+const addInputMutation = `mutation($app: String!, $payload: String!, $signature: String) {
+	addInput(appContract: $app, payload: $payload, signature: $signature) {
+		transactionHash inputIndex msgSender
+	}
+}`
+
+func (s *AddInputSuite) TestAddInput() {
+	app := common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")
+	res := s.query(addInputMutation, map[string]any{"app": app.Hex(), "payload": "0x1234"}, addInputToken)
+	s.Nil(res["errors"])
+	s.Equal(map[string]any{
+		"transactionHash": common.HexToHash("0x01").Hex(),
+		"inputIndex":      float64(3),
+		"msgSender":       fakeSenderAddress.Hex(),
+	}, res["data"].(map[string]any)["addInput"])
+	s.Equal(app, s.sender.appContract)
+	s.Equal([]byte{0x12, 0x34}, s.sender.payload)
+}
+
+func (s *AddInputSuite) TestAddSignedInput() {
+	res := s.query(addInputMutation, map[string]any{
+		"app":       "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb",
+		"payload":   "0x1234",
+		"signature": "signed",
+	}, "")
+	s.Nil(res["errors"])
+	s.Equal(map[string]any{
+		"transactionHash": common.HexToHash("0x02").Hex(),
+		"inputIndex":      nil,
+		"msgSender":       fakeSignerAddress.Hex(),
+	}, res["data"].(map[string]any)["addInput"])
+	s.Equal("signed", s.sender.sigAndData)
+}
+
+func (s *AddInputSuite) TestErrors() {
+	cases := []struct {
+		variables map[string]any
+		code      string
+	}{
+		{map[string]any{"app": "wrong", "payload": "0x1234"}, CodeInvalidArgument},
+		{map[string]any{"app": fakeSenderAddress.Hex(), "payload": "hello"}, CodeInvalidArgument},
+		{map[string]any{"app": fakeSenderAddress.Hex(), "payload": "0x12", "signature": "wrong"}, CodeInvalidArgument},
+		// the unsigned inputs require the admin token
+		{map[string]any{"app": fakeSenderAddress.Hex(), "payload": "0x12"}, CodeUnauthorized},
+	}
+	for _, c := range cases {
+		res := s.query(addInputMutation, c.variables, "")
+		errs := res["errors"].([]any)
+		s.Require().Len(errs, 1)
+		s.Equal(c.code, errs[0].(map[string]any)["extensions"].(map[string]any)["code"])
+	}
+}
@@ -0,0 +1,129 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	cRepos "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inspect"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Error codes sent to the clients in the `extensions.code` field
+const (
+	CodeNotFound        = "NOT_FOUND"
+	CodeInvalidCursor   = "INVALID_CURSOR"
+	CodeInvalidArgument = "INVALID_ARGUMENT"
+	CodeUnknownApp      = "UNKNOWN_APP"
+	CodeInspectFailed   = "INSPECT_FAILED"
+	CodeAddInputFailed  = "ADD_INPUT_FAILED"
+	CodeUnauthorized    = "UNAUTHORIZED"
+	CodeInternal        = "INTERNAL"
+)
+
+const internalErrorMessage = "internal server error"
+
+// Error is an error that can be safely shown to the client
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(code string, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func NotFoundError(format string, args ...any) *Error {
+	return newError(CodeNotFound, format, args...)
+}
+
+func InvalidArgumentError(format string, args ...any) *Error {
+	return newError(CodeInvalidArgument, format, args...)
+}
+
+func UnknownAppError(format string, args ...any) *Error {
+	return newError(CodeUnknownApp, format, args...)
+}
+
+func UnauthorizedError(format string, args ...any) *Error {
+	return newError(CodeUnauthorized, format, args...)
+}
+
+// inspectError keeps the message of the inspect failures,
+// which come from the node and not from the database
+func inspectError(err error) error {
+	if errors.Is(err, inspect.ErrTimeout) ||
+		errors.Is(err, inspect.ErrUpstream) ||
+		errors.Is(err, inspect.ErrDisabled) {
+		return newError(CodeInspectFailed, "%s", err)
+	}
+	return err
+}
+
+// addInputError keeps the message of the signature and transaction failures,
+// which the client needs to fix the input
+func addInputError(err error) error {
+	if errors.Is(err, inputsender.ErrInvalidSignature) || errors.Is(err, inputsender.ErrInvalidNonce) {
+		return InvalidArgumentError("%s", err)
+	}
+	return newError(CodeAddInputFailed, "%s", err)
+}
+
+// errorPresenter adds the error code to the response and hides
+// the errors that were not meant to the client, like database ones
+func errorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	code, message := classifyError(err)
+	if code == CodeInternal {
+		slog.Error("graphql internal error", "path", gqlErr.Path.String(), "err", err)
+	}
+	if message != "" {
+		gqlErr.Message = message
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+	gqlErr.Extensions["code"] = code
+	return gqlErr
+}
+
+// classifyError returns the error code and, when the original
+// message must not be shown, the message to replace it
+func classifyError(err error) (string, string) {
+	var typedErr *Error
+	if errors.As(err, &typedErr) {
+		return typedErr.Code, typedErr.Message
+	}
+	switch {
+	case errors.Is(err, commons.ErrInvalidCursor):
+		return CodeInvalidCursor, commons.ErrInvalidCursor.Error()
+	case errors.Is(err, commons.ErrMixedPagination):
+		return CodeInvalidArgument, commons.ErrMixedPagination.Error()
+	case errors.Is(err, commons.ErrInvalidLimit):
+		return CodeInvalidArgument, commons.ErrInvalidLimit.Error()
+	case errors.Is(err, cRepos.ErrInvalidFilter):
+		return CodeInvalidArgument, err.Error()
+	}
+	// errors created by gqlgen itself, like the argument ones,
+	// do not wrap anything and are safe to be shown
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Unwrap() == nil {
+		if code, ok := gqlErr.Extensions["code"].(string); ok {
+			return code, ""
+		}
+		return CodeInvalidArgument, ""
+	}
+	return CodeInternal, internalErrorMessage
+}
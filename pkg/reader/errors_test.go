@@ -0,0 +1,143 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inspect"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type ErrorPresenterSuite struct {
+	suite.Suite
+	server *testServer
+}
+
+type graphqlErrorResponse struct {
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestErrorPresenterSuite(t *testing.T) {
+	suite.Run(t, new(ErrorPresenterSuite))
+}
+
+func (s *ErrorPresenterSuite) SetupTest() {
+	s.server = newTestServer("errors.sqlite3")
+	s.server.register(Options{
+		Applications: []common.Address{common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")},
+	})
+}
+
+func (s *ErrorPresenterSuite) TearDownTest() {
+	s.server.cleanup()
+}
+
+func (s *ErrorPresenterSuite) query(path string, query string) graphqlErrorResponse {
+	var res graphqlErrorResponse
+	s.server.query(s.Require(), path, "", query, nil, &res)
+	s.Require().Len(res.Errors, 1)
+	return res
+}
+
+func (s *ErrorPresenterSuite) TestNotFound() {
+	res := s.query("/graphql", `{ voucher(outputIndex: 99999) { index } }`)
+	s.Equal("voucher not found", res.Errors[0].Message)
+	s.Equal(CodeNotFound, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestInvalidCursor() {
+	res := s.query("/graphql", `{ inputs(after: "wrong") { totalCount } }`)
+	s.Equal(commons.ErrInvalidCursor.Error(), res.Errors[0].Message)
+	s.Equal(CodeInvalidCursor, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestMixedPagination() {
+	res := s.query("/graphql", `{ inputs(first: 1, last: 1) { totalCount } }`)
+	s.Equal(CodeInvalidArgument, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestInvalidJsonPath() {
+	res := s.query("/graphql", `{ reports(where: { json: { path: "type", eq: "trade" } }) { totalCount } }`)
+	s.Contains(res.Errors[0].Message, "type")
+	s.Equal(CodeInvalidArgument, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestEmptySearch() {
+	res := s.query("/graphql", `{ search(text: " ") { totalCount } }`)
+	s.Contains(res.Errors[0].Message, "search text is empty")
+	s.Equal(CodeInvalidArgument, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestInvalidScalarArgument() {
+	res := s.query("/graphql", `{ vouchers(filter: [{ executedAt: { gt: "soon" } }]) { totalCount } }`)
+	s.NotEqual(internalErrorMessage, res.Errors[0].Message)
+	s.Equal(CodeInvalidArgument, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestUnknownApp() {
+	res := s.query("/graphql/0x0000000000000000000000000000000000000001", `{ inputs { totalCount } }`)
+	s.Equal(CodeUnknownApp, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestAppWithInputs() {
+	app := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	_, err := s.server.container.GetInputRepository().Create(context.Background(), cModel.AdvanceInput{
+		ID:          "1",
+		Index:       0,
+		AppContract: app,
+		Status:      cModel.CompletionStatusUnprocessed,
+	})
+	s.Require().NoError(err)
+	res := s.query("/graphql/"+app.Hex(), `{ voucher(outputIndex: 0) { index } }`)
+	s.Equal(CodeNotFound, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestMalformedApp() {
+	res := s.query("/graphql/not-an-address", `{ voucher(outputIndex: 0) { index } }`)
+	s.Equal(CodeInvalidArgument, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestInternalErrorIsHidden() {
+	err := fmt.Errorf("pq: relation \"vouchers\" does not exist")
+	gqlErr := errorPresenter(context.Background(), err)
+	s.Equal(internalErrorMessage, gqlErr.Message)
+	s.Equal(CodeInternal, gqlErr.Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestWrappedTypedError() {
+	err := fmt.Errorf("loading: %w", NotFoundError("input not found"))
+	gqlErr := errorPresenter(context.Background(), err)
+	s.Equal("input not found", gqlErr.Message)
+	s.Equal(CodeNotFound, gqlErr.Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestGqlgenErrorIsKept() {
+	gqlErr := errorPresenter(context.Background(), gqlerror.Errorf("must be defined"))
+	s.Equal("must be defined", gqlErr.Message)
+	s.Equal(CodeInvalidArgument, gqlErr.Extensions["code"])
+
+	gqlErr = errorPresenter(context.Background(), gqlerror.WrapPath(nil, errors.New("db is down")))
+	s.Equal(internalErrorMessage, gqlErr.Message)
+}
+
+func (s *ErrorPresenterSuite) TestInspectDisabled() {
+	res := s.query("/graphql/0x5112cf49f2511ac7b13a032c4c62a48410fc28fb", `{ inspect(payload: "0x00") { status } }`)
+	s.Equal(inspect.ErrDisabled.Error(), res.Errors[0].Message)
+	s.Equal(CodeInspectFailed, res.Errors[0].Extensions["code"])
+}
+
+func (s *ErrorPresenterSuite) TestAddInputDisabled() {
+	res := s.query("/graphql", `mutation { addInput(appContract: "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb", payload: "0x00") { transactionHash } }`)
+	s.Equal(inputsender.ErrNoSigner.Error(), res.Errors[0].Message)
+	s.Equal(CodeAddInputFailed, res.Errors[0].Extensions["code"])
+}
@@ -0,0 +1,83 @@
+package reader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inspect"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeInspector struct {
+	appContract common.Address
+	payload     []byte
+}
+
+func (f *fakeInspector) Inspect(ctx context.Context, appContract common.Address, payload []byte) (*inspect.Result, error) {
+	f.appContract = appContract
+	f.payload = payload
+	return &inspect.Result{
+		Status:              "Accepted",
+		Reports:             []inspect.Report{{Payload: "0xdeadbeef"}},
+		ProcessedInputCount: 1,
+	}, nil
+}
+
+type InspectQuerySuite struct {
+	suite.Suite
+	server    *testServer
+	inspector *fakeInspector
+}
+
+func TestInspectQuerySuite(t *testing.T) {
+	suite.Run(t, new(InspectQuerySuite))
+}
+
+func (s *InspectQuerySuite) SetupTest() {
+	s.server = newTestServer("inspect.sqlite3")
+	s.inspector = &fakeInspector{}
+	s.server.register(Options{
+		Inspector:    s.inspector,
+		Applications: []common.Address{common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")},
+	})
+}
+
+func (s *InspectQuerySuite) TearDownTest() {
+	s.server.cleanup()
+}
+
+func (s *InspectQuerySuite) query(path string, query string) map[string]any {
+	var res map[string]any
+	s.server.query(s.Require(), path, "", query, nil, &res)
+	return res
+}
+
+func (s *InspectQuerySuite) TestInspect() {
+	app := common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")
+	res := s.query("/graphql/"+app.Hex(),
+		`{ inspect(payload: "0x1234") { status exceptionPayload reports { payload } processedInputCount } }`)
+	s.Nil(res["errors"])
+	s.Equal(map[string]any{
+		"status":              "Accepted",
+		"exceptionPayload":    nil,
+		"reports":             []any{map[string]any{"payload": "0xdeadbeef"}},
+		"processedInputCount": float64(1),
+	}, res["data"].(map[string]any)["inspect"])
+	s.Equal(app, s.inspector.appContract)
+	s.Equal([]byte{0x12, 0x34}, s.inspector.payload)
+}
+
+func (s *InspectQuerySuite) TestInspectRequiresApp() {
+	res := s.query("/graphql", `{ inspect(payload: "0x1234") { status } }`)
+	errs := res["errors"].([]any)
+	s.Require().Len(errs, 1)
+	s.Equal(CodeUnknownApp, errs[0].(map[string]any)["extensions"].(map[string]any)["code"])
+}
+
+func (s *InspectQuerySuite) TestInvalidPayload() {
+	res := s.query("/graphql/0x5112cf49f2511ac7b13a032c4c62a48410fc28fb", `{ inspect(payload: "hello") { status } }`)
+	errs := res["errors"].([]any)
+	s.Require().Len(errs, 1)
+	s.Equal(CodeInvalidArgument, errs[0].(map[string]any)["extensions"].(map[string]any)["code"])
+}
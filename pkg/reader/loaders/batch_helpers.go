@@ -2,16 +2,19 @@ package loaders
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
 	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/tracing"
 	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-// dataReader reads Users from a database
+// dataReader reads the convenience data from the database in batches
 type dataReader struct {
 	reportRepository  *repository.ReportRepository
 	voucherRepository *repository.VoucherRepository
@@ -19,79 +22,157 @@ type dataReader struct {
 	inputRepository   *repository.InputRepository
 }
 
-// getReports implements a batch function that can retrieve many users by ID,
+// getReports implements a batch function that can retrieve many reports by key,
 // for use in a dataloader
 func (u *dataReader) getReports(ctx context.Context, reportsKeys []string) ([]*commons.PageResult[cModel.Report], []error) {
-	filters, errors := buildBatchFilters(reportsKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
-		return &repository.BatchFilterItem{
-			AppContract: &appContract,
-			InputIndex:  inputIndex,
-		}
-	})
-	if errors != nil {
-		return nil, errors
-	}
-
-	return u.reportRepository.BatchFindAllByInputIndexAndAppContract(ctx, filters)
+	return batchLoad(ctx, "reports", reportsKeys,
+		func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
+			return &repository.BatchFilterItem{
+				AppContract: &appContract,
+				InputIndex:  inputIndex,
+			}
+		},
+		u.reportRepository.BatchFindAllByInputIndexAndAppContract,
+	)
 }
 
 func (u *dataReader) getVouchers(ctx context.Context, voucherKeys []string) ([]*commons.PageResult[cModel.ConvenienceVoucher], []error) {
-	filters, errors := buildBatchFilters(voucherKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
-		return &repository.BatchFilterItem{
-			AppContract: &appContract,
-			InputIndex:  inputIndex,
-		}
-	})
-	if errors != nil {
-		return nil, errors
-	}
-
-	return u.voucherRepository.BatchFindAllByInputIndexAndAppContract(ctx, filters)
+	return batchLoad(ctx, "vouchers", voucherKeys,
+		func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
+			return &repository.BatchFilterItem{
+				AppContract: &appContract,
+				InputIndex:  inputIndex,
+			}
+		},
+		u.voucherRepository.BatchFindAllByInputIndexAndAppContract,
+	)
 }
 
 func (u *dataReader) getNotices(ctx context.Context, noticesKeys []string) ([]*commons.PageResult[cModel.ConvenienceNotice], []error) {
-	filters, errors := buildBatchFilters(noticesKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItemForNotice {
-		return &repository.BatchFilterItemForNotice{
-			AppContract: appContract.Hex(),
-			InputIndex:  inputIndex,
-		}
-	})
-	if errors != nil {
-		return nil, errors
-	}
-
-	return u.noticeRepository.BatchFindAllNoticesByInputIndexAndAppContract(ctx, filters)
+	return batchLoad(ctx, "notices", noticesKeys,
+		func(appContract common.Address, inputIndex int) *repository.BatchFilterItemForNotice {
+			return &repository.BatchFilterItemForNotice{
+				AppContract: appContract.Hex(),
+				InputIndex:  inputIndex,
+			}
+		},
+		u.noticeRepository.BatchFindAllNoticesByInputIndexAndAppContract,
+	)
 }
 
 func (u *dataReader) getInputs(ctx context.Context, inputsKeys []string) ([]*cModel.AdvanceInput, []error) {
-	filters, errors := buildBatchFilters(inputsKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
-		return &repository.BatchFilterItem{
-			AppContract: &appContract,
-			InputIndex:  inputIndex,
-		}
-	})
-	if errors != nil {
-		return nil, errors
+	return batchLoad(ctx, "inputs", inputsKeys,
+		func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
+			return &repository.BatchFilterItem{
+				AppContract: &appContract,
+				InputIndex:  inputIndex,
+			}
+		},
+		u.inputRepository.BatchFindInputByInputIndexAndAppContract,
+	)
+}
+
+func (u *dataReader) getVoucherProofs(ctx context.Context, proofKeys []string) ([]*cModel.ConvenienceProof, []error) {
+	return batchLoad(ctx, "voucherProofs", proofKeys, newProofFilter,
+		u.voucherRepository.BatchFindProofsByOutputIndexAndAppContract,
+	)
+}
+
+func (u *dataReader) getNoticeProofs(ctx context.Context, proofKeys []string) ([]*cModel.ConvenienceProof, []error) {
+	return batchLoad(ctx, "noticeProofs", proofKeys, newProofFilter,
+		u.noticeRepository.BatchFindProofsByOutputIndexAndAppContract,
+	)
+}
+
+func newProofFilter(appContract common.Address, outputIndex int) *repository.BatchFilterItemForProof {
+	return &repository.BatchFilterItemForProof{
+		AppContract: appContract.Hex(),
+		OutputIndex: outputIndex,
 	}
+}
 
-	return u.inputRepository.BatchFindInputByInputIndexAndAppContract(ctx, filters)
+// batchLoad parses the loader keys, fetches the valid ones in a single batch
+// and maps the results back to the original key positions.
+// A malformed key only fails its own load, not the whole batch.
+func batchLoad[F any, R any](
+	ctx context.Context,
+	name string,
+	keys []string,
+	filterFunc func(appContract common.Address, index int) F,
+	fetch func(ctx context.Context, filters []F) ([]R, []error),
+) ([]R, []error) {
+	filters, positions, errs := buildBatchFilters(keys, filterFunc)
+	results := make([]R, len(keys))
+	if len(filters) > 0 {
+		ctx, span := tracing.Start(ctx, "dataloader "+name,
+			attribute.Int("dataloader.keys", len(filters)),
+		)
+		rows, fetchErrs := fetch(ctx, filters)
+		if len(fetchErrs) > 0 {
+			tracing.End(span, fetchErrs[0])
+		} else {
+			span.End()
+		}
+		if len(fetchErrs) > 0 {
+			for _, pos := range positions {
+				errs[pos] = fetchErrs[0]
+			}
+			return results, errs
+		}
+		if len(rows) != len(filters) {
+			err := fmt.Errorf("batch returned %d results for %d keys", len(rows), len(filters))
+			for _, pos := range positions {
+				errs[pos] = err
+			}
+			return results, errs
+		}
+		for i, pos := range positions {
+			results[pos] = rows[i]
+		}
+	}
+	for _, err := range errs {
+		if err != nil {
+			return results, errs
+		}
+	}
+	return results, nil
 }
 
-func buildBatchFilters[T any](keys []string, filterFunc func(appContract common.Address, inputIndex int) T) ([]T, []error) {
-	errors := []error{}
+// buildBatchFilters converts the keys in the "appContract|index" format
+// into filters. It returns the filters of the valid keys, their positions
+// in the keys slice and one error slot per key.
+func buildBatchFilters[T any](
+	keys []string,
+	filterFunc func(appContract common.Address, index int) T,
+) ([]T, []int, []error) {
+	errs := make([]error, len(keys))
 	filters := []T{}
+	positions := []int{}
 
-	for _, key := range keys {
-		aux := strings.Split(key, "|")
-		appContract := common.HexToAddress(aux[0])
-		inputIndex, err := strconv.Atoi(aux[1])
+	for i, key := range keys {
+		appContract, index, err := parseBatchKey(key)
 		if err != nil {
-			return nil, errors
+			errs[i] = err
+			continue
 		}
-
-		filter := filterFunc(appContract, inputIndex)
-		filters = append(filters, filter)
+		filters = append(filters, filterFunc(appContract, index))
+		positions = append(positions, i)
 	}
 
-	return filters, nil
+	return filters, positions, errs
+}
+
+func parseBatchKey(key string) (common.Address, int, error) {
+	aux := strings.Split(key, "|")
+	if len(aux) != 2 {
+		return common.Address{}, 0, fmt.Errorf("invalid batch key %q", key)
+	}
+	if !common.IsHexAddress(aux[0]) {
+		return common.Address{}, 0, fmt.Errorf("invalid app contract in batch key %q", key)
+	}
+	index, err := strconv.Atoi(aux[1])
+	if err != nil {
+		return common.Address{}, 0, fmt.Errorf("invalid index in batch key %q: %w", key, err)
+	}
+	return common.HexToAddress(aux[0]), index, nil
 }
@@ -22,6 +22,9 @@ type Loaders struct {
 	VoucherLoader *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceVoucher]]
 	NoticeLoader  *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceNotice]]
 	InputLoader   *dataloadgen.Loader[string, *cModel.AdvanceInput]
+
+	VoucherProofLoader *dataloadgen.Loader[string, *cModel.ConvenienceProof]
+	NoticeProofLoader  *dataloadgen.Loader[string, *cModel.ConvenienceProof]
 }
 
 // NewLoaders instantiates data loaders for the middleware
@@ -55,6 +58,14 @@ func NewLoaders(
 			ur.getInputs,
 			dataloadgen.WithWait(time.Millisecond),
 		),
+		VoucherProofLoader: dataloadgen.NewLoader(
+			ur.getVoucherProofs,
+			dataloadgen.WithWait(time.Millisecond),
+		),
+		NoticeProofLoader: dataloadgen.NewLoader(
+			ur.getNoticeProofs,
+			dataloadgen.WithWait(time.Millisecond),
+		),
 	}
 }
 
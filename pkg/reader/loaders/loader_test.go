@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/stretchr/testify/suite"
+	"github.com/vikstrous/dataloadgen"
 )
 
 //
@@ -255,6 +257,104 @@ func (s *LoaderSuite) TestGetNotices() {
 	// s.Fail("This failure is intentional ;-)")
 }
 
+func (s *LoaderSuite) TestGetInputs() {
+	ctx := context.Background()
+	s.createTestData(ctx)
+	appContract := common.HexToAddress(devnet.ApplicationAddress)
+	loaders := NewLoaders(
+		s.reportRepository,
+		s.voucherRepository,
+		s.noticeRepository,
+		s.inputRepository,
+	)
+	keys := []string{
+		cRepos.GenerateBatchInputKey(appContract.Hex(), 1),
+		cRepos.GenerateBatchInputKey(appContract.Hex(), 2),
+		cRepos.GenerateBatchInputKey(appContract.Hex(), 99),
+	}
+	inputs, err := loaders.InputLoader.LoadAll(ctx, keys)
+	s.Require().NoError(err)
+	s.Require().Equal(3, len(inputs))
+	s.Equal(1, inputs[0].Index)
+	s.Equal(2, inputs[1].Index)
+	s.Nil(inputs[2])
+}
+
+func (s *LoaderSuite) TestGetVoucherAndNoticeProofs() {
+	ctx := context.Background()
+	s.createTestData(ctx)
+	appContract := common.HexToAddress(devnet.ApplicationAddress)
+	err := s.voucherRepository.SetProof(ctx, &cModel.ConvenienceVoucher{
+		AppContract:          appContract,
+		OutputIndex:          1,
+		OutputHashesSiblings: `["0x01","0x02"]`,
+		ProofOutputIndex:     7,
+	})
+	s.Require().NoError(err)
+	err = s.noticeRepository.SetProof(ctx, &cModel.ConvenienceNotice{
+		AppContract:          appContract.Hex(),
+		OutputIndex:          2,
+		OutputHashesSiblings: `["0x03"]`,
+		ProofOutputIndex:     8,
+	})
+	s.Require().NoError(err)
+	loaders := NewLoaders(
+		s.reportRepository,
+		s.voucherRepository,
+		s.noticeRepository,
+		s.inputRepository,
+	)
+
+	voucherProofs, err := loaders.VoucherProofLoader.LoadAll(ctx, []string{
+		cRepos.GenerateBatchProofKey(appContract.Hex(), 1),
+		cRepos.GenerateBatchProofKey(appContract.Hex(), 42),
+	})
+	s.Require().NoError(err)
+	s.Equal(`["0x01","0x02"]`, voucherProofs[0].OutputHashesSiblings)
+	s.Equal(7, int(voucherProofs[0].ProofOutputIndex))
+	s.Nil(voucherProofs[1])
+
+	noticeProof, err := loaders.NoticeProofLoader.Load(ctx,
+		cRepos.GenerateBatchProofKey(appContract.Hex(), 2),
+	)
+	s.Require().NoError(err)
+	s.Equal(`["0x03"]`, noticeProof.OutputHashesSiblings)
+	s.Equal(8, int(noticeProof.ProofOutputIndex))
+
+	// the app contract casing of the key does not matter
+	noticeProof, err = loaders.NoticeProofLoader.Load(ctx,
+		cRepos.GenerateBatchProofKey(strings.ToLower(appContract.Hex()), 2),
+	)
+	s.Require().NoError(err)
+	s.Require().NotNil(noticeProof)
+	s.Equal(8, int(noticeProof.ProofOutputIndex))
+}
+
+func (s *LoaderSuite) TestInvalidKeyOnlyFailsItsOwnLoad() {
+	ctx := context.Background()
+	s.createTestData(ctx)
+	appContract := common.HexToAddress(devnet.ApplicationAddress)
+	loaders := NewLoaders(
+		s.reportRepository,
+		s.voucherRepository,
+		s.noticeRepository,
+		s.inputRepository,
+	)
+	reports, err := loaders.ReportLoader.LoadAll(ctx, []string{
+		cRepos.GenerateBatchReportKey(&appContract, 1),
+		fmt.Sprintf("%s|abc", appContract.Hex()),
+		"invalid",
+	})
+	s.Require().Error(err)
+	errs, ok := err.(dataloadgen.ErrorSlice)
+	s.Require().True(ok)
+	s.Require().Equal(3, len(errs))
+	s.NoError(errs[0])
+	s.ErrorContains(errs[1], "invalid index")
+	s.ErrorContains(errs[2], "invalid batch key")
+	s.Equal(1, reports[0].Rows[0].InputIndex)
+}
+
 func (s *LoaderSuite) createTestData(ctx context.Context) {
 	appContract := common.HexToAddress(devnet.ApplicationAddress)
 	for i := 0; i < 3; i++ {
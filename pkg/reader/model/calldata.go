@@ -0,0 +1,147 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//
+// Outputs -> base layer transactions
+//
+
+// ConvertExecutionCalldata builds the Application.executeOutput transaction of
+// the voucher. It returns nil while the proof is not available.
+func ConvertExecutionCalldata(voucher *Voucher, proof *Proof) (*TransactionRequest, error) {
+	output, err := VoucherOutput(voucher)
+	if err != nil {
+		return nil, err
+	}
+	return applicationTransaction("executeOutput", voucher.AppContract, output, proof)
+}
+
+// ConvertValidationCalldata builds the Application.validateOutput call of the
+// notice. It returns nil while the proof is not available.
+func ConvertValidationCalldata(notice *Notice, proof *Proof) (*TransactionRequest, error) {
+	output, err := NoticeOutput(notice)
+	if err != nil {
+		return nil, err
+	}
+	return applicationTransaction("validateOutput", notice.AppContract, output, proof)
+}
+
+// VoucherOutput returns the voucher output as emitted by the machine
+func VoucherOutput(voucher *Voucher) ([]byte, error) {
+	value, ok := new(big.Int).SetString(voucher.Value, 10) // nolint
+	if !ok {
+		return nil, fmt.Errorf("invalid voucher value: %q", voucher.Value)
+	}
+	return encodeOutput(voucher.Payload, "Voucher",
+		common.HexToAddress(voucher.Destination), value)
+}
+
+// NoticeOutput returns the notice output as emitted by the machine
+func NoticeOutput(notice *Notice) ([]byte, error) {
+	return encodeOutput(notice.Payload, "Notice")
+}
+
+// VoucherPayload returns the payload the voucher calls the destination with
+func VoucherPayload(voucher *Voucher) ([]byte, error) {
+	return decodeOutput(voucher.Payload, "Voucher")
+}
+
+// NoticePayload returns the payload emitted by the application
+func NoticePayload(notice *Notice) ([]byte, error) {
+	return decodeOutput(notice.Payload, "Notice")
+}
+
+// decodeOutput is the inverse of encodeOutput, it returns the payload
+// argument when the stored payload is the encoded output
+func decodeOutput(payload string, method string) ([]byte, error) {
+	data, err := hexutil.Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output payload: %w", err)
+	}
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	outputMethod := outputsAbi.Methods[method]
+	if !bytes.HasPrefix(data, outputMethod.ID) {
+		return data, nil
+	}
+	values, err := outputMethod.Inputs.Unpack(data[len(outputMethod.ID):])
+	if err != nil {
+		return nil, fmt.Errorf("invalid output payload: %w", err)
+	}
+	return values[len(values)-1].([]byte), nil
+}
+
+// encodeOutput returns the output as emitted by the machine. The stored payload
+// is already the encoded output when it starts with the method selector,
+// otherwise the output is encoded from the arguments and the payload.
+func encodeOutput(payload string, method string, args ...any) ([]byte, error) {
+	data, err := hexutil.Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output payload: %w", err)
+	}
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, outputsAbi.Methods[method].ID) {
+		return data, nil
+	}
+	return outputsAbi.Pack(method, append(args, data)...)
+}
+
+func applicationTransaction(
+	method string, appContract string, output []byte, proof *Proof,
+) (*TransactionRequest, error) {
+	validityProof, err := convertValidityProof(proof)
+	if err != nil || validityProof == nil {
+		return nil, err
+	}
+	applicationAbi, err := contracts.ApplicationMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	data, err := applicationAbi.Pack(method, output, *validityProof)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionRequest{
+		To:    common.HexToAddress(appContract).Hex(),
+		Data:  hexutil.Encode(data),
+		Value: "0",
+	}, nil
+}
+
+// convertValidityProof converts the graphql proof to the contract struct.
+// It returns nil if the siblings were not computed yet.
+func convertValidityProof(proof *Proof) (*contracts.OutputValidityProof, error) {
+	if proof == nil || len(proof.OutputHashesSiblings) == 0 {
+		return nil, nil
+	}
+	outputIndex, err := strconv.ParseUint(proof.OutputIndex, 10, 64) // nolint
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof output index: %w", err)
+	}
+	siblings := make([][32]byte, len(proof.OutputHashesSiblings))
+	for i, sibling := range proof.OutputHashesSiblings {
+		hash, err := hexutil.Decode(sibling)
+		if err != nil || len(hash) != common.HashLength {
+			return nil, fmt.Errorf("invalid proof sibling %d: %s", i, sibling)
+		}
+		siblings[i] = common.BytesToHash(hash)
+	}
+	return &contracts.OutputValidityProof{
+		OutputIndex:          outputIndex,
+		OutputHashesSiblings: siblings,
+	}, nil
+}
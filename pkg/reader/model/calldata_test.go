@@ -0,0 +1,102 @@
+package model
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const calldataApp = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+
+func calldataProof() *Proof {
+	return &Proof{
+		OutputIndex: "3",
+		OutputHashesSiblings: []string{
+			common.HexToHash("0x01").Hex(),
+			common.HexToHash("0x02").Hex(),
+		},
+	}
+}
+
+func (s *ConversionsSuite) unpackApplicationCall(method string, data string) (output []byte, proof contracts.OutputValidityProof) {
+	applicationAbi, err := contracts.ApplicationMetaData.GetAbi()
+	s.Require().NoError(err)
+	raw, err := hexutil.Decode(data)
+	s.Require().NoError(err)
+	s.Require().Equal(applicationAbi.Methods[method].ID, raw[:4])
+	args, err := applicationAbi.Methods[method].Inputs.Unpack(raw[4:])
+	s.Require().NoError(err)
+	output = args[0].([]byte)
+	proofArg := args[1].(struct {
+		OutputIndex          uint64      `json:"outputIndex"`
+		OutputHashesSiblings [][32]uint8 `json:"outputHashesSiblings"`
+	})
+	proof.OutputIndex = proofArg.OutputIndex
+	proof.OutputHashesSiblings = proofArg.OutputHashesSiblings
+	return output, proof
+}
+
+func (s *ConversionsSuite) TestConvertExecutionCalldata() {
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	destination := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	rawOutput, err := outputsAbi.Pack("Voucher", destination, big.NewInt(7), []byte{0xca, 0xfe})
+	s.Require().NoError(err)
+
+	// the synchronizer stores the raw output and older sources the payload only
+	for _, payload := range []string{hexutil.Encode(rawOutput), "0xcafe"} {
+		voucher := &Voucher{
+			Destination: destination.Hex(),
+			Payload:     payload,
+			Value:       "7",
+			AppContract: strings.ToLower(calldataApp),
+		}
+		tx, err := ConvertExecutionCalldata(voucher, calldataProof())
+		s.Require().NoError(err)
+		s.Require().NotNil(tx)
+		s.Equal(calldataApp, tx.To)
+		s.Equal("0", tx.Value)
+		output, proof := s.unpackApplicationCall("executeOutput", tx.Data)
+		s.Equal(rawOutput, output)
+		s.Equal(uint64(3), proof.OutputIndex)
+		s.Equal([][32]byte{common.HexToHash("0x01"), common.HexToHash("0x02")}, proof.OutputHashesSiblings)
+	}
+}
+
+func (s *ConversionsSuite) TestInvalidVoucherValue() {
+	voucher := &Voucher{
+		Destination: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Payload:     "0xcafe",
+		Value:       "0x07",
+		AppContract: calldataApp,
+	}
+	tx, err := ConvertExecutionCalldata(voucher, calldataProof())
+	s.ErrorContains(err, `invalid voucher value: "0x07"`)
+	s.Nil(tx)
+}
+
+func (s *ConversionsSuite) TestConvertValidationCalldata() {
+	notice := &Notice{Payload: "0xdeadbeef", AppContract: calldataApp}
+	tx, err := ConvertValidationCalldata(notice, calldataProof())
+	s.Require().NoError(err)
+	s.Require().NotNil(tx)
+	outputsAbi, err := contracts.OutputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	rawOutput, err := outputsAbi.Pack("Notice", []byte{0xde, 0xad, 0xbe, 0xef})
+	s.Require().NoError(err)
+	output, _ := s.unpackApplicationCall("validateOutput", tx.Data)
+	s.Equal(rawOutput, output)
+}
+
+func (s *ConversionsSuite) TestCalldataWithoutProof() {
+	notice := &Notice{Payload: "0xdeadbeef", AppContract: calldataApp}
+	tx, err := ConvertValidationCalldata(notice, &Proof{OutputIndex: "0", OutputHashesSiblings: []string{}})
+	s.NoError(err)
+	s.Nil(tx)
+
+	_, err = ConvertValidationCalldata(notice, &Proof{OutputIndex: "0", OutputHashesSiblings: []string{"0x01"}})
+	s.Error(err)
+}
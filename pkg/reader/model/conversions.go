@@ -11,13 +11,14 @@ import (
 	"strconv"
 
 	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
 )
 
 //
 // Nonodo -> GraphQL conversions
 //
 
-func convertCompletionStatus(status cModel.CompletionStatus) (CompletionStatus, error) {
+func ConvertCompletionStatus(status cModel.CompletionStatus) (CompletionStatus, error) {
 	switch status {
 	case cModel.CompletionStatusUnprocessed:
 		return CompletionStatusUnprocessed, nil
@@ -41,7 +42,7 @@ func convertCompletionStatus(status cModel.CompletionStatus) (CompletionStatus,
 }
 
 func ConvertInput(input cModel.AdvanceInput) (*Input, error) {
-	convertedStatus, err := convertCompletionStatus(input.Status)
+	convertedStatus, err := ConvertCompletionStatus(input.Status)
 
 	if err != nil {
 		slog.Error("Error converting CompletionStatus", "Error", err)
@@ -76,15 +77,20 @@ func ConvertInput(input cModel.AdvanceInput) (*Input, error) {
 		InputBoxIndex:       inputBoxIndexStr,
 		BlockTimestamp:      timestamp,
 		PrevRandao:          input.PrevRandao,
+		TransactionHash:     emptyToNil(input.TransactionHash),
+		BlockHash:           emptyToNil(input.BlockHash),
+		AppContract:         input.AppContract.Hex(),
 	}, nil
 }
 
-func ConvertConvenientVoucherV1(cVoucher cModel.ConvenienceVoucher) *Voucher {
-	var outputHashesSiblings []string
-	err := json.Unmarshal([]byte(cVoucher.OutputHashesSiblings), &outputHashesSiblings)
-	if err != nil {
-		outputHashesSiblings = []string{}
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
 	}
+	return &value
+}
+
+func ConvertConvenientVoucherV1(cVoucher cModel.ConvenienceVoucher) *Voucher {
 	return &Voucher{
 		Index:           int(cVoucher.OutputIndex),
 		InputIndex:      int(cVoucher.InputIndex),
@@ -93,10 +99,35 @@ func ConvertConvenientVoucherV1(cVoucher cModel.ConvenienceVoucher) *Voucher {
 		Value:           cVoucher.Value,
 		Executed:        cVoucher.Executed,
 		TransactionHash: cVoucher.TransactionHash,
-		Proof: Proof{
-			OutputIndex:          strconv.FormatUint(cVoucher.ProofOutputIndex, 10),
-			OutputHashesSiblings: outputHashesSiblings,
-		},
+		AppContract:     cVoucher.AppContract.Hex(),
+		Proof:           ConvertProof(cVoucher.ProofOutputIndex, cVoucher.OutputHashesSiblings),
+		ExecutedAt:      convertExecutionBlock(cVoucher),
+		ExecutedBy:      emptyToNil(cVoucher.ExecutedBy),
+
+		InputIndexUnknown: cVoucher.InputIndexUnknown,
+	}
+}
+
+func convertExecutionBlock(cVoucher cModel.ConvenienceVoucher) *ExecutionBlock {
+	if cVoucher.ExecutedBlock == 0 {
+		return nil
+	}
+	return &ExecutionBlock{
+		Number:    strconv.FormatUint(cVoucher.ExecutedBlock, 10),
+		Timestamp: strconv.FormatUint(cVoucher.ExecutedAt, 10),
+	}
+}
+
+// ConvertProof builds the graphql proof from the stored siblings json
+func ConvertProof(proofOutputIndex uint64, siblings string) Proof {
+	var outputHashesSiblings []string
+	err := json.Unmarshal([]byte(siblings), &outputHashesSiblings)
+	if err != nil {
+		outputHashesSiblings = []string{}
+	}
+	return Proof{
+		OutputIndex:          strconv.FormatUint(proofOutputIndex, 10),
+		OutputHashesSiblings: outputHashesSiblings,
 	}
 }
 
@@ -185,10 +216,65 @@ func ConvertToConvenienceFilter(
 				Or:    or,
 			})
 		}
+
+		// Execution ranges
+		if f.ExecutedAt != nil {
+			filters = append(filters, convertRangeFilter(cModel.EXECUTED_AT, f.ExecutedAt))
+		}
+		if f.ExecutedBlock != nil {
+			filters = append(filters, convertRangeFilter(cModel.EXECUTED_BLOCK, f.ExecutedBlock))
+		}
 	}
 	return filters, nil
 }
 
+// ConvertPayloadFilter returns one filter per JSON condition,
+// all of them must match
+func ConvertPayloadFilter(where *PayloadFilter) []*cModel.ConvenienceFilter {
+	filters := []*cModel.ConvenienceFilter{}
+	if where == nil {
+		return filters
+	}
+	for _, condition := range where.JSON {
+		field := cModel.PAYLOAD_JSON
+		path := condition.Path
+		filters = append(filters, &cModel.ConvenienceFilter{
+			Field: &field,
+			Path:  &path,
+			Eq:    condition.Eq,
+			Ne:    condition.Ne,
+			Gt:    condition.Gt,
+			Gte:   condition.Gte,
+			Lt:    condition.Lt,
+			Lte:   condition.Lte,
+			In:    toPointers(condition.In),
+			Nin:   toPointers(condition.Nin),
+		})
+	}
+	return filters
+}
+
+func toPointers(values []string) []*string {
+	if values == nil {
+		return nil
+	}
+	pointers := make([]*string, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	return pointers
+}
+
+func convertRangeFilter(field string, r *RangeFilterInput) *cModel.ConvenienceFilter {
+	return &cModel.ConvenienceFilter{
+		Field: &field,
+		Gt:    r.Gt,
+		Gte:   r.Gte,
+		Lt:    r.Lt,
+		Lte:   r.Lte,
+	}
+}
+
 func ConvertToVoucherConnectionV1(
 	vouchers []cModel.ConvenienceVoucher,
 	offset int, total int,
@@ -201,20 +287,145 @@ func ConvertToVoucherConnectionV1(
 }
 
 func ConvertConvenientNoticeV1(cNotice cModel.ConvenienceNotice) *Notice {
-	var outputHashesSiblings []string
-	err := json.Unmarshal([]byte(cNotice.OutputHashesSiblings), &outputHashesSiblings)
-	if err != nil {
-		outputHashesSiblings = []string{}
-	}
 	return &Notice{
-		Index:      int(cNotice.OutputIndex),
-		InputIndex: int(cNotice.InputIndex),
-		Payload:    cNotice.Payload,
-		Proof: Proof{
-			OutputIndex:          strconv.FormatUint(cNotice.ProofOutputIndex, 10),
-			OutputHashesSiblings: outputHashesSiblings,
-		},
+		Index:       int(cNotice.OutputIndex),
+		InputIndex:  int(cNotice.InputIndex),
+		Payload:     cNotice.Payload,
+		PayloadText: cNotice.PayloadText,
+		PayloadJson: cNotice.PayloadJson,
+		AppContract: cNotice.AppContract,
+		Proof:       ConvertProof(cNotice.ProofOutputIndex, cNotice.OutputHashesSiblings),
+	}
+}
+
+func ConvertClaim(cClaim cModel.ConvenienceClaim) *Claim {
+	return &Claim{
+		EpochIndex:      int(cClaim.EpochIndex),
+		FirstBlock:      strconv.FormatUint(cClaim.FirstBlock, 10),
+		LastBlock:       strconv.FormatUint(cClaim.LastBlock, 10),
+		ClaimHash:       cClaim.ClaimHash,
+		Submitter:       cClaim.Submitter,
+		Accepted:        cClaim.Accepted,
+		TransactionHash: cClaim.TransactionHash,
+		AppContract:     cClaim.AppContract,
+	}
+}
+
+func ConvertToClaimConnection(
+	claims []cModel.ConvenienceClaim,
+	offset int, total int,
+) *ClaimConnection {
+	convNodes := make([]*Claim, len(claims))
+	for i := range claims {
+		convNodes[i] = ConvertClaim(claims[i])
+	}
+	return NewConnection(offset, total, convNodes)
+}
+
+func ConvertSearchResult(doc cModel.SearchDocument) *SearchResult {
+	return &SearchResult{
+		Kind:        doc.Kind,
+		AppContract: doc.AppContract,
+		InputIndex:  int(doc.InputIndex),
+		Index:       int(doc.OutputIndex),
+		PayloadText: doc.Content,
+	}
+}
+
+func ConvertToSearchConnection(
+	docs []cModel.SearchDocument,
+	offset int, total int,
+) *SearchConnection {
+	convNodes := make([]*SearchResult, len(docs))
+	for i := range docs {
+		convNodes[i] = ConvertSearchResult(docs[i])
+	}
+	return NewConnection(offset, total, convNodes)
+}
+
+func ConvertWebhookDelivery(delivery cModel.WebhookDelivery) *WebhookDelivery {
+	converted := &WebhookDelivery{
+		EventID:       int(delivery.EventId),
+		EventType:     delivery.EventType,
+		AppContract:   delivery.AppContract,
+		Endpoint:      delivery.Endpoint,
+		Status:        WebhookDeliveryStatus(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: strconv.FormatInt(delivery.NextAttemptAt, 10),
+		UpdatedAt:     strconv.FormatInt(delivery.UpdatedAt, 10),
+	}
+	if delivery.ResponseStatus != 0 {
+		responseStatus := delivery.ResponseStatus
+		converted.ResponseStatus = &responseStatus
+	}
+	if delivery.LastError != "" {
+		lastError := delivery.LastError
+		converted.LastError = &lastError
+	}
+	return converted
+}
+
+func ConvertToWebhookDeliveryConnection(
+	deliveries []cModel.WebhookDelivery,
+	offset int, total int,
+) *WebhookDeliveryConnection {
+	convNodes := make([]*WebhookDelivery, len(deliveries))
+	for i := range deliveries {
+		convNodes[i] = ConvertWebhookDelivery(deliveries[i])
+	}
+	return NewConnection(offset, total, convNodes)
+}
+
+// ConvertStats lists the statuses in the order of the enum
+func ConvertStats(stats *cModel.AppStats) (*Stats, error) {
+	converted := &Stats{
+		InputsByStatus:   []*StatusCount{},
+		Vouchers:         int(stats.Vouchers),
+		ExecutedVouchers: int(stats.ExecutedVouchers),
+		Notices:          int(stats.Notices),
+		Reports:          int(stats.Reports),
+		UniqueSenders:    int(stats.Inputs.UniqueSenders),
+		InputsPerDay:     make([]*DailyCount, len(stats.Inputs.PerDay)),
+	}
+	for status := cModel.CompletionStatusUnprocessed; status <= cModel.CompletionStatusPayloadLengthLimitExceeded; status++ {
+		count, ok := stats.Inputs.ByStatus[status]
+		if !ok {
+			continue
+		}
+		convertedStatus, err := ConvertCompletionStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		converted.Inputs += int(count)
+		converted.InputsByStatus = append(converted.InputsByStatus, &StatusCount{
+			Status: convertedStatus,
+			Count:  int(count),
+		})
+	}
+	if stats.Inputs.FirstTimestamp != nil {
+		first := strconv.FormatInt(stats.Inputs.FirstTimestamp.Unix(), 10)
+		converted.FirstInputTimestamp = &first
+	}
+	if stats.Inputs.LastTimestamp != nil {
+		last := strconv.FormatInt(stats.Inputs.LastTimestamp.Unix(), 10)
+		converted.LastInputTimestamp = &last
+	}
+	for i, day := range stats.Inputs.PerDay {
+		converted.InputsPerDay[i] = &DailyCount{Day: day.Day, Count: int(day.Count)}
+	}
+	return converted, nil
+}
+
+func ConvertAddInputResult(result *inputsender.Result) *AddInputResult {
+	converted := &AddInputResult{
+		TransactionHash: result.TransactionHash.Hex(),
+		MsgSender:       result.MsgSender.Hex(),
+	}
+	if result.InputIndex != nil {
+		index := int(*result.InputIndex)
+		converted.InputIndex = &index
 	}
+	return converted
 }
 
 func ConvertToNoticeConnectionV1(
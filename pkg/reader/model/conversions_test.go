@@ -31,3 +31,47 @@ func (s *ConversionsSuite) TestConvertConvenientVoucherV1() {
 	s.Equal("0x02", graphVoucher.Proof.OutputHashesSiblings[1])
 	s.Equal("0x03", graphVoucher.Proof.OutputHashesSiblings[2])
 }
+
+func (s *ConversionsSuite) TestConvertVoucherExecution() {
+	graphVoucher := ConvertConvenientVoucherV1(cModel.ConvenienceVoucher{Executed: true})
+	s.Nil(graphVoucher.ExecutedAt)
+	s.Nil(graphVoucher.ExecutedBy)
+
+	graphVoucher = ConvertConvenientVoucherV1(cModel.ConvenienceVoucher{
+		Executed:      true,
+		ExecutedBlock: 12,
+		ExecutedAt:    1700000000,
+		ExecutedBy:    "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+	})
+	s.Equal(&ExecutionBlock{Number: "12", Timestamp: "1700000000"}, graphVoucher.ExecutedAt)
+	s.Equal("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", *graphVoucher.ExecutedBy)
+}
+
+func (s *ConversionsSuite) TestConvertExecutionRangeFilter() {
+	gte, lt := "100", "200"
+	filters, err := ConvertToConvenienceFilter([]*ConvenientFilter{
+		{ExecutedAt: &RangeFilterInput{Gte: &gte, Lt: &lt}},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(filters, 1)
+	s.Equal(cModel.EXECUTED_AT, *filters[0].Field)
+	s.Equal("100", *filters[0].Gte)
+	s.Equal("200", *filters[0].Lt)
+	s.Nil(filters[0].Gt)
+}
+
+func (s *ConversionsSuite) TestConvertInputTransaction() {
+	input, err := ConvertInput(cModel.AdvanceInput{Status: cModel.CompletionStatusAccepted})
+	s.Require().NoError(err)
+	s.Nil(input.TransactionHash)
+	s.Nil(input.BlockHash)
+
+	input, err = ConvertInput(cModel.AdvanceInput{
+		Status:          cModel.CompletionStatusAccepted,
+		TransactionHash: "0xaa",
+		BlockHash:       "0xbb",
+	})
+	s.Require().NoError(err)
+	s.Equal("0xaa", *input.TransactionHash)
+	s.Equal("0xbb", *input.BlockHash)
+}
@@ -8,6 +8,16 @@ import (
 	"strconv"
 )
 
+// Input sent to the InputBox
+type AddInputResult struct {
+	// Hash of the transaction that added the input
+	TransactionHash string `json:"transactionHash"`
+	// Index of the input, or null if the transaction was not mined yet
+	InputIndex *int `json:"inputIndex,omitempty"`
+	// Address that signed the input, the application receives the signed inputs from the relay address
+	MsgSender string `json:"msgSender"`
+}
+
 type AddressFilterInput struct {
 	Eq  *string             `json:"eq,omitempty"`
 	Ne  *string             `json:"ne,omitempty"`
@@ -27,8 +37,27 @@ type BooleanFilterInput struct {
 type ConvenientFilter struct {
 	Destination *AddressFilterInput `json:"destination,omitempty"`
 	Executed    *BooleanFilterInput `json:"executed,omitempty"`
-	And         []*ConvenientFilter `json:"and,omitempty"`
-	Or          []*ConvenientFilter `json:"or,omitempty"`
+	// Timestamp of the execution block, in seconds since the Unix epoch
+	ExecutedAt *RangeFilterInput `json:"executedAt,omitempty"`
+	// Number of the execution block
+	ExecutedBlock *RangeFilterInput   `json:"executedBlock,omitempty"`
+	And           []*ConvenientFilter `json:"and,omitempty"`
+	Or            []*ConvenientFilter `json:"or,omitempty"`
+}
+
+// Number of inputs of a UTC day
+type DailyCount struct {
+	// Day in the YYYY-MM-DD format
+	Day   string `json:"day"`
+	Count int    `json:"count"`
+}
+
+// Base layer block of a voucher execution
+type ExecutionBlock struct {
+	// Number of the block
+	Number string `json:"number"`
+	// Timestamp of the block in seconds since the Unix epoch
+	Timestamp string `json:"timestamp"`
 }
 
 // Filter object to restrict results depending on input properties
@@ -39,7 +68,7 @@ type InputFilter struct {
 	IndexGreaterThan *int `json:"indexGreaterThan,omitempty"`
 	// Filter only inputs with the message sender
 	MsgSender *string `json:"msgSender,omitempty"`
-	// Filter only inputs from 'inputbox' or 'espresso'
+	// Filter only inputs from 'inputbox', 'espresso' or 'relay'
 	Type *string `json:"type,omitempty"`
 }
 
@@ -55,6 +84,87 @@ type PageInfo struct {
 	HasPreviousPage bool `json:"hasPreviousPage"`
 }
 
+// Filter object to restrict notices and reports depending on their payload
+type PayloadFilter struct {
+	// Filter only the payloads whose JSON matches, all the conditions must match
+	JSON []*UserDataFilter `json:"json,omitempty"`
+}
+
+type RangeFilterInput struct {
+	Gt  *string `json:"gt,omitempty"`
+	Gte *string `json:"gte,omitempty"`
+	Lt  *string `json:"lt,omitempty"`
+	Lte *string `json:"lte,omitempty"`
+}
+
+// Aggregate statistics of the inputs and outputs of an application
+type Stats struct {
+	// Total number of inputs
+	Inputs int `json:"inputs"`
+	// Number of inputs by completion status, only the statuses that have inputs
+	InputsByStatus []*StatusCount `json:"inputsByStatus"`
+	// Total number of vouchers
+	Vouchers int `json:"vouchers"`
+	// Number of executed vouchers
+	ExecutedVouchers int `json:"executedVouchers"`
+	// Total number of notices
+	Notices int `json:"notices"`
+	// Total number of reports
+	Reports int `json:"reports"`
+	// Number of distinct addresses that sent inputs
+	UniqueSenders int `json:"uniqueSenders"`
+	// Block timestamp of the first input, null when there are no inputs
+	FirstInputTimestamp *string `json:"firstInputTimestamp,omitempty"`
+	// Block timestamp of the last input, null when there are no inputs
+	LastInputTimestamp *string `json:"lastInputTimestamp,omitempty"`
+	// Number of inputs per UTC day, in ascending order
+	InputsPerDay []*DailyCount `json:"inputsPerDay"`
+}
+
+// Number of inputs with a completion status
+type StatusCount struct {
+	Status CompletionStatus `json:"status"`
+	Count  int              `json:"count"`
+}
+
+// Unsigned base layer transaction, ready to be signed by a wallet
+type TransactionRequest struct {
+	// Address of the application in Ethereum hex binary format (20 bytes), starting with '0x'
+	To string `json:"to"`
+	// Call data in Ethereum hex binary format, starting with '0x'
+	Data string `json:"data"`
+	// Amount of wei sent along with the transaction
+	Value string `json:"value"`
+}
+
+// Comparison of the value at a path of the JSON payload, the values are compared as text
+type UserDataFilter struct {
+	// Path of the value, like $.type or $.items[0].id
+	Path string  `json:"path"`
+	Eq   *string `json:"eq,omitempty"`
+	Ne   *string `json:"ne,omitempty"`
+	// Greater than, comparing numbers
+	Gt *string `json:"gt,omitempty"`
+	// Greater than or equal, comparing numbers
+	Gte *string `json:"gte,omitempty"`
+	// Lower than, comparing numbers
+	Lt *string `json:"lt,omitempty"`
+	// Lower than or equal, comparing numbers
+	Lte *string  `json:"lte,omitempty"`
+	In  []string `json:"in,omitempty"`
+	Nin []string `json:"nin,omitempty"`
+}
+
+// Filter object to restrict the webhook deliveries
+type WebhookDeliveryFilter struct {
+	// Address of the application
+	AppContract *string `json:"appContract,omitempty"`
+	// Event type: voucher.proof, voucher.executed or input.exception
+	EventType *string `json:"eventType,omitempty"`
+	// Status of the delivery
+	Status *WebhookDeliveryStatus `json:"status,omitempty"`
+}
+
 type CompletionStatus string
 
 const (
@@ -107,3 +217,50 @@ func (e *CompletionStatus) UnmarshalGQL(v interface{}) error {
 func (e CompletionStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+// Status of the delivery of an event to a webhook endpoint
+type WebhookDeliveryStatus string
+
+const (
+	// Waiting for the first attempt or for a retry
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "PENDING"
+	// Endpoint answered with a 2xx status
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "DELIVERED"
+	// Every attempt failed
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusDelivered,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusDelivered, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
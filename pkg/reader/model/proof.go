@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CheckProof returns a copy of the proof with the outputs root computed from
+// the output and, when the epoch has a claim, whether both roots match.
+// A proof that can not be folded is inconsistent.
+func CheckProof(proof *Proof, output []byte, claim *Claim) *Proof {
+	if proof == nil {
+		return nil
+	}
+	checked := *proof
+	validityProof, err := convertValidityProof(proof)
+	if err == nil && validityProof == nil {
+		// the siblings were not computed yet
+		return &checked
+	}
+	inconsistent := false
+	if err != nil {
+		checked.IsConsistent = &inconsistent
+		return &checked
+	}
+	siblings := make([]common.Hash, len(validityProof.OutputHashesSiblings))
+	for i, sibling := range validityProof.OutputHashesSiblings {
+		siblings[i] = sibling
+	}
+	root, err := merkle.OutputsRoot(output, validityProof.OutputIndex, siblings)
+	if err != nil {
+		checked.IsConsistent = &inconsistent
+		return &checked
+	}
+	computedRoot := root.Hex()
+	checked.ComputedRoot = &computedRoot
+	if claim != nil {
+		consistent := strings.EqualFold(claim.ClaimHash, computedRoot)
+		checked.IsConsistent = &consistent
+	}
+	return &checked
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func (s *ConversionsSuite) TestCheckProof() {
+	notice := &Notice{Payload: "0xdeadbeef", AppContract: calldataApp}
+	output, err := NoticeOutput(notice)
+	s.Require().NoError(err)
+
+	// the notice is the output 1 of a tree of two outputs
+	sibling := merkle.OutputHash([]byte{0x01})
+	root := crypto.Keccak256Hash(sibling.Bytes(), merkle.OutputHash(output).Bytes())
+	proof := &Proof{OutputIndex: "1", OutputHashesSiblings: []string{sibling.Hex()}}
+
+	checked := CheckProof(proof, output, nil)
+	s.Require().NotNil(checked.ComputedRoot)
+	s.Equal(root.Hex(), *checked.ComputedRoot)
+	s.Nil(checked.IsConsistent)
+	s.Nil(proof.ComputedRoot)
+
+	checked = CheckProof(proof, output, &Claim{ClaimHash: root.Hex()})
+	s.Require().NotNil(checked.IsConsistent)
+	s.True(*checked.IsConsistent)
+
+	// a corrupted sibling does not reach the claim
+	corrupted := &Proof{OutputIndex: "1", OutputHashesSiblings: []string{common.Hash{}.Hex()}}
+	checked = CheckProof(corrupted, output, &Claim{ClaimHash: root.Hex()})
+	s.Require().NotNil(checked.IsConsistent)
+	s.False(*checked.IsConsistent)
+
+	// an index out of the tree can not be folded
+	checked = CheckProof(&Proof{OutputIndex: "2", OutputHashesSiblings: []string{sibling.Hex()}}, output, nil)
+	s.Nil(checked.ComputedRoot)
+	s.Require().NotNil(checked.IsConsistent)
+	s.False(*checked.IsConsistent)
+
+	// without siblings nothing is known yet
+	checked = CheckProof(&Proof{OutputIndex: "1", OutputHashesSiblings: []string{}}, output, nil)
+	s.Nil(checked.ComputedRoot)
+	s.Nil(checked.IsConsistent)
+}
@@ -30,6 +30,14 @@ type Input struct {
 	BlockTimestamp string `json:"blockTimestamp"`
 
 	PrevRandao string `json:"prevRandao"`
+
+	// Hash of the base layer transaction that added the input, nil while it is unknown
+	TransactionHash *string `json:"transactionHash,omitempty"`
+	// Hash of the base layer block of the input, nil while it is unknown
+	BlockHash *string `json:"blockHash,omitempty"`
+
+	// Address of the application, used to resolve the nested fields
+	AppContract string `json:"-"`
 }
 
 // Representation of a transaction that can be carried out on the base layer blockchain, such as a
@@ -39,6 +47,8 @@ type Voucher struct {
 	Index int `json:"index"`
 	// Index of the input
 	InputIndex int
+	// InputIndexUnknown is set when the voucher is only known by its execution
+	InputIndexUnknown bool `json:"-"`
 	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with
 	// '0x'
 	Destination string `json:"destination"`
@@ -52,11 +62,23 @@ type Voucher struct {
 	Proof Proof `json:"proof"`
 
 	TransactionHash string `json:"transactionHash"`
+
+	// Block in which the voucher was executed, nil while it is unknown
+	ExecutedAt *ExecutionBlock `json:"executedAt,omitempty"`
+	// Sender of the execution transaction, nil while it is unknown
+	ExecutedBy *string `json:"executedBy,omitempty"`
+
+	// Address of the application, used to resolve the nested fields
+	AppContract string `json:"-"`
 }
 
 type Proof struct {
 	OutputIndex          string   `json:"outputIndex"`
 	OutputHashesSiblings []string `json:"outputHashesSiblings"`
+	// Outputs root folded from the output and the siblings, nil without siblings
+	ComputedRoot *string `json:"computedRoot,omitempty"`
+	// Whether the computed root matches the claim, nil while it is unknown
+	IsConsistent *bool `json:"isConsistent,omitempty"`
 }
 
 // Application log or diagnostic information
@@ -67,6 +89,13 @@ type Report struct {
 	InputIndex int
 	// Report data as a payload in Ethereum hex binary format, starting with '0x'
 	Payload string `json:"payload"`
+	// Report data as UTF-8 text, nil when it is binary
+	PayloadText *string `json:"payloadText,omitempty"`
+	// Report data as compact JSON, nil when the text is not a JSON object or array
+	PayloadJson *string `json:"payloadJson,omitempty"`
+
+	// Address of the application, used to resolve the nested fields
+	AppContract string `json:"-"`
 }
 
 // Informational statement that can be validated in the base layer blockchain
@@ -77,8 +106,72 @@ type Notice struct {
 	InputIndex int
 	// Notice data as a payload in Ethereum hex binary format, starting with '0x'
 	Payload string `json:"payload"`
+	// Notice data as UTF-8 text, nil when it is binary
+	PayloadText *string `json:"payloadText,omitempty"`
+	// Notice data as compact JSON, nil when the text is not a JSON object or array
+	PayloadJson *string `json:"payloadJson,omitempty"`
 	// InputId string
 	Proof Proof `json:"proof"`
+
+	// Address of the application, used to resolve the nested fields
+	AppContract string `json:"-"`
+}
+
+// Claim of the outputs of an epoch submitted to the consensus
+type Claim struct {
+	// Epoch index, starting from genesis
+	EpochIndex int `json:"epochIndex"`
+	// Number of the first base layer block of the epoch
+	FirstBlock string `json:"firstBlock"`
+	// Number of the last base layer block processed by the claim
+	LastBlock string `json:"lastBlock"`
+	// Claimed outputs merkle root
+	ClaimHash string `json:"claimHash"`
+	// Address of the validator that submitted the claim
+	Submitter string `json:"submitter"`
+	// Indicates whether the consensus accepted the claim
+	Accepted bool `json:"accepted"`
+	// The hash of the transaction that submitted the claim
+	TransactionHash string `json:"transactionHash"`
+	// Address of the application
+	AppContract string `json:"appContract"`
+}
+
+// Report or notice whose text matches the search
+type SearchResult struct {
+	// Either report or notice
+	Kind string `json:"kind"`
+	// Address of the application
+	AppContract string `json:"appContract"`
+	// Index of the input whose processing produced the output
+	InputIndex int `json:"inputIndex"`
+	// Index of the report or notice
+	Index int `json:"index"`
+	// Payload of the output as UTF-8 text
+	PayloadText string `json:"payloadText"`
+}
+
+type WebhookDelivery struct {
+	// Identifier of the event, sent in the X-Webhook-Id header
+	EventID int `json:"eventId"`
+	// Event type: voucher.proof, voucher.executed or input.exception
+	EventType string `json:"eventType"`
+	// Address of the application
+	AppContract string `json:"appContract"`
+	// URL of the endpoint
+	Endpoint string `json:"endpoint"`
+	// Status of the delivery
+	Status WebhookDeliveryStatus `json:"status"`
+	// Number of attempts made
+	Attempts int `json:"attempts"`
+	// HTTP status of the last response, null when there was none
+	ResponseStatus *int `json:"responseStatus,omitempty"`
+	// Error of the last attempt, null when it succeeded
+	LastError *string `json:"lastError,omitempty"`
+	// Unix timestamp in seconds of the next attempt of a pending delivery
+	NextAttemptAt string `json:"nextAttemptAt"`
+	// Unix timestamp in seconds of the last change
+	UpdatedAt string `json:"updatedAt"`
 }
 
 //
@@ -96,3 +189,12 @@ type NoticeEdge = Edge[*Notice]
 
 type ReportConnection = Connection[*Report]
 type ReportEdge = Edge[*Report]
+
+type ClaimConnection = Connection[*Claim]
+type ClaimEdge = Edge[*Claim]
+
+type SearchConnection = Connection[*SearchResult]
+type SearchEdge = Edge[*SearchResult]
+
+type WebhookDeliveryConnection = Connection[*WebhookDelivery]
+type WebhookDeliveryEdge = Edge[*WebhookDelivery]
@@ -0,0 +1,107 @@
+package reader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	nonceApp    = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+	nonceSender = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+type fakeNonceSource uint64
+
+func (f fakeNonceSource) NextNonce(ctx context.Context, appContract common.Address, msgSender common.Address) (uint64, error) {
+	return uint64(f), nil
+}
+
+type NonceSuite struct {
+	suite.Suite
+	server *testServer
+}
+
+func TestNonceSuite(t *testing.T) {
+	suite.Run(t, new(NonceSuite))
+}
+
+func (s *NonceSuite) SetupTest() {
+	s.server = newTestServer("nonce.sqlite3")
+	s.server.register(Options{})
+	for i := 0; i < 2; i++ {
+		_, err := s.server.container.GetConvenienceService().CreateInput(context.Background(), &cModel.AdvanceInput{
+			ID:             common.Bytes2Hex([]byte{byte(i)}),
+			Index:          i,
+			MsgSender:      common.HexToAddress(nonceSender),
+			Payload:        "0x00",
+			AppContract:    common.HexToAddress(nonceApp),
+			BlockTimestamp: time.Now(),
+		})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *NonceSuite) TearDownTest() {
+	s.server.cleanup()
+}
+
+func (s *NonceSuite) post(path string, body string) *httptest.ResponseRecorder {
+	return s.server.post(path, "", body)
+}
+
+func (s *NonceSuite) queryNonce(app string) map[string]any {
+	query := `{ nonce(msgSender: "` + nonceSender + `", appContract: "` + app + `") }`
+	var res map[string]any
+	s.server.query(s.Require(), "/graphql", "", query, nil, &res)
+	return res
+}
+
+func (s *NonceSuite) TestQueryNonce() {
+	res := s.queryNonce(nonceApp)
+	s.Nil(res["errors"])
+	s.Equal(float64(2), res["data"].(map[string]any)["nonce"])
+
+	res = s.queryNonce("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	s.Equal(float64(0), res["data"].(map[string]any)["nonce"])
+}
+
+func (s *NonceSuite) TestQueryNonceInvalidApp() {
+	res := s.queryNonce("wrong")
+	errs := res["errors"].([]any)
+	s.Require().Len(errs, 1)
+	s.Equal(CodeInvalidArgument, errs[0].(map[string]any)["extensions"].(map[string]any)["code"])
+}
+
+func (s *NonceSuite) TestRestNonce() {
+	rec := s.post("/nonce", `{"msg_sender":"`+nonceSender+`","app_contract":"`+nonceApp+`"}`)
+	s.Equal(http.StatusOK, rec.Code)
+	s.JSONEq(`{"nonce":2}`, rec.Body.String())
+
+	rec = s.post("/nonce", `{"msg_sender":"wrong","app_contract":"`+nonceApp+`"}`)
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *NonceSuite) TestNonceSource() {
+	s.server.register(Options{
+		NonceSources: []NonceSource{fakeNonceSource(42), fakeNonceSource(1)},
+	})
+	res := s.queryNonce(nonceApp)
+	s.Equal(float64(42), res["data"].(map[string]any)["nonce"])
+	rec := s.post("/nonce", `{"msg_sender":"`+nonceSender+`","app_contract":"`+nonceApp+`"}`)
+	s.JSONEq(`{"nonce":42}`, rec.Body.String())
+}
+
+func (s *NonceSuite) TestStoredInputsAreCounted() {
+	s.server.register(Options{
+		NonceSources: []NonceSource{fakeNonceSource(1)},
+	})
+	res := s.queryNonce(nonceApp)
+	s.Equal(float64(2), res["data"].(map[string]any)["nonce"])
+}
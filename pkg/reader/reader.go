@@ -8,33 +8,81 @@ package reader
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/decoder"
 	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	cRepos "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inspect"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/graph"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/loaders"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
+// Options of the GraphQL reader API, the zero values disable what they configure
+type Options struct {
+	// Inspector is optional, without it the inspect query fails
+	Inspector inspect.Inspector
+	// InputSender is optional, without it the addInput mutation fails
+	InputSender inputsender.Sender
+	// NonceSources are optional, the next nonce is the highest of
+	// theirs and of the count of the stored inputs
+	NonceSources []NonceSource
+	// AbiRegistry is optional, without it the payloads are not decoded
+	AbiRegistry *decoder.AbiRegistry
+	// AdminToken is optional, without it the admin mutations fail
+	AdminToken string
+	// Applications are the configured applications. The other ones
+	// are unknown to /graphql/:appContract while they have no inputs.
+	Applications []common.Address
+}
+
 // Register the GraphQL reader API to echo.
 func Register(
 	e *echo.Echo,
 	convenienceService *services.ConvenienceService,
 	adapter Adapter,
+	opts Options,
 ) {
 	resolver := Resolver{
 		convenienceService,
 		adapter,
+		opts,
 	}
 	config := graph.Config{Resolvers: &resolver}
 	schema := graph.NewExecutableSchema(config)
 	graphqlHandler := handler.NewDefaultServer(schema)
+	graphqlHandler.SetErrorPresenter(errorPresenter)
+	graphqlHandler.Use(tracing.GraphQLTracer{})
+	apps := newAppChecker(convenienceService.InputRepository, opts.Applications)
+	graphqlHandler.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		if err := apps.check(ctx); err != nil {
+			return graphql.OneShot(&graphql.Response{
+				Errors: gqlerror.List{errorPresenter(ctx, err)},
+			})
+		}
+		return next(ctx)
+	})
 	playgroundHandler := playground.Handler("GraphQL", "/graphql")
 	e.POST("/graphql", func(c echo.Context) error {
+		ctx := withReadRouting(c.Request().Context(), c.Request())
+		ctx = withAuthorization(ctx, c.Request())
+		ctx = withLoaders(ctx, convenienceService)
+		c.SetRequest(c.Request().WithContext(ctx))
 		graphqlHandler.ServeHTTP(c.Response(), c.Request())
 		return nil
 	})
@@ -42,17 +90,29 @@ func Register(
 		appContract := c.Param("appContract")
 		slog.Debug("path parameter received: ", "app_contract", appContract)
 		ctx := context.WithValue(c.Request().Context(), cModel.AppContractKey, appContract)
-		loader := loaders.NewLoaders(
-			convenienceService.ReportRepository,
-			convenienceService.VoucherRepository,
-			convenienceService.NoticeRepository,
-			convenienceService.InputRepository,
-		)
-		ctx = context.WithValue(ctx, loaders.LoadersKey, loader)
+		ctx = withReadRouting(ctx, c.Request())
+		ctx = withAuthorization(ctx, c.Request())
+		ctx = withLoaders(ctx, convenienceService)
 		c.SetRequest(c.Request().WithContext(ctx))
 		graphqlHandler.ServeHTTP(c.Response(), c.Request())
 		return nil
 	})
+	e.POST("/nonce", func(c echo.Context) error {
+		var request NonceRequest
+		if err := c.Bind(&request); err != nil {
+			return c.String(http.StatusBadRequest, "invalid body")
+		}
+		nonce, err := resolver.nextNonce(c.Request().Context(), request.AppContract, request.MsgSender)
+		if err != nil {
+			var typedErr *Error
+			if errors.As(err, &typedErr) {
+				return c.String(http.StatusBadRequest, typedErr.Message)
+			}
+			slog.Error("nonce failed", "err", err)
+			return c.String(http.StatusInternalServerError, internalErrorMessage)
+		}
+		return c.JSON(http.StatusOK, NonceResponse{Nonce: nonce})
+	})
 	e.GET("/graphql", func(c echo.Context) error {
 		playgroundHandler.ServeHTTP(c.Response(), c.Request())
 		return nil
@@ -67,3 +127,105 @@ func Register(
 		return nil
 	})
 }
+
+// appChecker tells whether the app contract of the URL is known, as a
+// configured application or one with inputs. An application with inputs
+// stays known, so it is not looked up again.
+type appChecker struct {
+	inputRepository *cRepos.InputRepository
+	known           sync.Map
+}
+
+func newAppChecker(inputRepository *cRepos.InputRepository, applications []common.Address) *appChecker {
+	checker := &appChecker{inputRepository: inputRepository}
+	for _, app := range applications {
+		checker.known.Store(app, true)
+	}
+	return checker
+}
+
+// check returns UNKNOWN_APP for an unknown app contract. The introspection
+// is allowed, so the playground of any address loads.
+func (c *appChecker) check(ctx context.Context) error {
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil || appContract == nil || isIntrospection(ctx) {
+		return err
+	}
+	if _, ok := c.known.Load(*appContract); ok {
+		return nil
+	}
+	hasInputs, err := c.inputRepository.HasInputs(ctx, *appContract)
+	if err != nil {
+		return err
+	}
+	if !hasInputs {
+		return UnknownAppError("unknown app contract: %s", appContract.Hex())
+	}
+	c.known.Store(*appContract, true)
+	return nil
+}
+
+// isIntrospection tells whether the operation only asks for the schema
+func isIntrospection(ctx context.Context) bool {
+	operation := graphql.GetOperationContext(ctx).Operation
+	if operation == nil {
+		return false
+	}
+	for _, selection := range operation.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok || !strings.HasPrefix(field.Name, "__") {
+			return false
+		}
+	}
+	return true
+}
+
+// NonceRequest is the body of the REST nonce endpoint
+type NonceRequest struct {
+	MsgSender   string `json:"msg_sender"`
+	AppContract string `json:"app_contract"`
+}
+
+type NonceResponse struct {
+	Nonce uint64 `json:"nonce"`
+}
+
+// withLoaders attaches a fresh set of data loaders to the request context
+func withLoaders(
+	ctx context.Context,
+	convenienceService *services.ConvenienceService,
+) context.Context {
+	loader := loaders.NewLoaders(
+		convenienceService.ReportRepository,
+		convenienceService.VoucherRepository,
+		convenienceService.NoticeRepository,
+		convenienceService.InputRepository,
+	)
+	return context.WithValue(ctx, loaders.LoadersKey, loader)
+}
+
+// ReadYourWritesHeader makes a query read from the primary database
+// instead of the read replica, e.g. right after sending an input.
+const ReadYourWritesHeader = "X-Read-Your-Writes"
+
+// withReadRouting lets the query traffic use the read replica,
+// unless the client asked for read-your-writes consistency
+func withReadRouting(ctx context.Context, req *http.Request) context.Context {
+	if strings.EqualFold(req.Header.Get(ReadYourWritesHeader), "true") {
+		return cRepos.WithReadPrimary(ctx)
+	}
+	return cRepos.WithReadReplica(ctx)
+}
+
+type authorizationKeyType struct{}
+
+var authorizationKey = authorizationKeyType{}
+
+// withAuthorization keeps the bearer token of the request for the admin mutations
+func withAuthorization(ctx context.Context, req *http.Request) context.Context {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, authorizationKey, token)
+}
@@ -6,14 +6,21 @@ package reader
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/decoder"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inputsender"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/inspect"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/graph"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Vouchers is the resolver for the vouchers field.
 func (r *inputResolver) Vouchers(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.Voucher], error) {
+	ctx = withAppContract(ctx, obj.AppContract)
 	if first == nil && last == nil && after == nil && before == nil {
 		return r.adapter.GetAllVouchersByInputIndex(ctx, &obj.Index)
 	}
@@ -22,22 +29,72 @@ func (r *inputResolver) Vouchers(ctx context.Context, obj *model.Input, first *i
 
 // Notices is the resolver for the notices field.
 func (r *inputResolver) Notices(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.Notice], error) {
+	ctx = withAppContract(ctx, obj.AppContract)
 	if first == nil && last == nil && after == nil && before == nil {
 		return r.adapter.GetAllNoticesByInputIndex(ctx, &obj.Index)
 	}
-	return r.adapter.GetNotices(ctx, first, last, after, before, &obj.Index)
+	return r.adapter.GetNotices(ctx, first, last, after, before, &obj.Index, nil)
 }
 
 // Reports is the resolver for the reports field.
 func (r *inputResolver) Reports(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.Report], error) {
+	ctx = withAppContract(ctx, obj.AppContract)
 	if first == nil && last == nil && after == nil && before == nil {
 		return r.adapter.GetAllReportsByInputIndex(ctx, &obj.Index)
 	}
-	return r.adapter.GetReports(ctx, first, last, after, before, &obj.Index)
+	return r.adapter.GetReports(ctx, first, last, after, before, &obj.Index, nil)
+}
+
+// AddInput is the resolver for the addInput field.
+func (r *mutationResolver) AddInput(ctx context.Context, appContract string, payload string, signature *string) (*model.AddInputResult, error) {
+	if r.opts.InputSender == nil {
+		return nil, addInputError(inputsender.ErrNoSigner)
+	}
+	if !common.IsHexAddress(appContract) {
+		return nil, InvalidArgumentError("invalid app contract: %s", appContract)
+	}
+	app := common.HexToAddress(appContract)
+	data, err := hexutil.Decode(payload)
+	if err != nil {
+		return nil, InvalidArgumentError("invalid payload: %s", err)
+	}
+	var result *inputsender.Result
+	if signature == nil {
+		// the server key pays for the unsigned inputs
+		if err := r.checkAdmin(ctx); err != nil {
+			return nil, err
+		}
+		result, err = r.opts.InputSender.AddInput(ctx, app, data)
+	} else {
+		result, err = r.opts.InputSender.AddSignedInput(ctx, app, data, *signature)
+	}
+	if err != nil {
+		return nil, addInputError(err)
+	}
+	return model.ConvertAddInputResult(result), nil
+}
+
+// RegisterAbi is the resolver for the registerAbi field.
+func (r *mutationResolver) RegisterAbi(ctx context.Context, address string, abi string) (bool, error) {
+	if err := r.checkAdmin(ctx); err != nil {
+		return false, err
+	}
+	if !common.IsHexAddress(address) {
+		return false, InvalidArgumentError("invalid address: %s", address)
+	}
+	err := r.opts.AbiRegistry.Register(ctx, common.HexToAddress(address), abi)
+	if errors.Is(err, decoder.ErrInvalidAbi) {
+		return false, InvalidArgumentError("%s", err)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // Input is the resolver for the input field.
 func (r *noticeResolver) Input(ctx context.Context, obj *model.Notice) (*model.Input, error) {
+	ctx = withAppContract(ctx, obj.AppContract)
 	slog.Debug("Find input by index", "inputIndex", obj.InputIndex)
 	input, err := r.adapter.GetInputByIndex(ctx, obj.InputIndex)
 	if err != nil {
@@ -47,6 +104,37 @@ func (r *noticeResolver) Input(ctx context.Context, obj *model.Notice) (*model.I
 	return input, nil
 }
 
+// Proof is the resolver for the proof field.
+func (r *noticeResolver) Proof(ctx context.Context, obj *model.Notice) (*model.Proof, error) {
+	proof, err := r.adapter.GetNoticeProof(ctx, obj)
+	if err != nil || proof == nil {
+		return proof, err
+	}
+	output, err := model.NoticeOutput(obj)
+	if err != nil {
+		return nil, err
+	}
+	return r.checkProof(ctx, obj.AppContract, obj.InputIndex, output, proof)
+}
+
+// ValidationCalldata is the resolver for the validationCalldata field.
+func (r *noticeResolver) ValidationCalldata(ctx context.Context, obj *model.Notice) (*model.TransactionRequest, error) {
+	proof, err := r.adapter.GetNoticeProof(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return model.ConvertValidationCalldata(obj, proof)
+}
+
+// DecodedPayload is the resolver for the decodedPayload field.
+func (r *noticeResolver) DecodedPayload(ctx context.Context, obj *model.Notice) (*decoder.DecodedPayload, error) {
+	payload, err := model.NoticePayload(obj)
+	if err != nil {
+		return nil, err
+	}
+	return r.decodePayload(ctx, payload, obj.AppContract)
+}
+
 // Input is the resolver for the input field.
 func (r *queryResolver) Input(ctx context.Context, id string) (*model.Input, error) {
 	slog.Debug("queryResolver.Input", "id", id)
@@ -79,28 +167,150 @@ func (r *queryResolver) Vouchers(ctx context.Context, first *int, last *int, aft
 }
 
 // Notices is the resolver for the notices field.
-func (r *queryResolver) Notices(ctx context.Context, first *int, last *int, after *string, before *string) (*model.Connection[*model.Notice], error) {
-	return r.adapter.GetNotices(ctx, first, last, after, before, nil)
+func (r *queryResolver) Notices(ctx context.Context, first *int, last *int, after *string, before *string, where *model.PayloadFilter) (*model.Connection[*model.Notice], error) {
+	return r.adapter.GetNotices(ctx, first, last, after, before, nil, where)
 }
 
 // Reports is the resolver for the reports field.
-func (r *queryResolver) Reports(ctx context.Context, first *int, last *int, after *string, before *string) (*model.Connection[*model.Report], error) {
-	return r.adapter.GetReports(ctx, first, last, after, before, nil)
+func (r *queryResolver) Reports(ctx context.Context, first *int, last *int, after *string, before *string, where *model.PayloadFilter) (*model.Connection[*model.Report], error) {
+	return r.adapter.GetReports(ctx, first, last, after, before, nil, where)
+}
+
+// Claims is the resolver for the claims field.
+func (r *queryResolver) Claims(ctx context.Context, first *int, last *int, after *string, before *string) (*model.Connection[*model.Claim], error) {
+	return r.adapter.GetClaims(ctx, first, last, after, before)
+}
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, appContract *string, text string, first *int, after *string) (*model.Connection[*model.SearchResult], error) {
+	app, err := optionalAppContract(appContract)
+	if err != nil {
+		return nil, err
+	}
+	return r.adapter.Search(ctx, app, text, first, after)
+}
+
+// Stats is the resolver for the stats field.
+func (r *queryResolver) Stats(ctx context.Context, appContract *string) (*model.Stats, error) {
+	app, err := optionalAppContract(appContract)
+	if err != nil {
+		return nil, err
+	}
+	return r.adapter.GetStats(ctx, app)
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, first *int, last *int, after *string, before *string, where *model.WebhookDeliveryFilter) (*model.Connection[*model.WebhookDelivery], error) {
+	err := r.checkAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := webhookDeliveryFilter(where)
+	if err != nil {
+		return nil, err
+	}
+	return r.adapter.GetWebhookDeliveries(ctx, first, last, after, before, filter)
+}
+
+// Nonce is the resolver for the nonce field.
+func (r *queryResolver) Nonce(ctx context.Context, msgSender string, appContract string) (int, error) {
+	nonce, err := r.nextNonce(ctx, appContract, msgSender)
+	if err != nil {
+		return 0, err
+	}
+	return int(nonce), nil
+}
+
+// Inspect is the resolver for the inspect field.
+func (r *queryResolver) Inspect(ctx context.Context, payload string) (*inspect.Result, error) {
+	if r.opts.Inspector == nil {
+		return nil, inspectError(inspect.ErrDisabled)
+	}
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if appContract == nil {
+		return nil, UnknownAppError("inspect requires the app contract in the URL")
+	}
+	data, err := hexutil.Decode(payload)
+	if err != nil {
+		return nil, InvalidArgumentError("invalid payload: %s", err)
+	}
+	result, err := r.opts.Inspector.Inspect(ctx, *appContract, data)
+	if err != nil {
+		return nil, inspectError(err)
+	}
+	return result, nil
 }
 
 // Input is the resolver for the input field.
 func (r *reportResolver) Input(ctx context.Context, obj *model.Report) (*model.Input, error) {
+	ctx = withAppContract(ctx, obj.AppContract)
 	return r.adapter.GetInputByIndex(ctx, obj.InputIndex)
 }
 
+// DecodedPayload is the resolver for the decodedPayload field.
+func (r *reportResolver) DecodedPayload(ctx context.Context, obj *model.Report) (*decoder.DecodedPayload, error) {
+	payload, err := hexutil.Decode(obj.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return r.decodePayload(ctx, payload, obj.AppContract)
+}
+
 // Input is the resolver for the input field.
 func (r *voucherResolver) Input(ctx context.Context, obj *model.Voucher) (*model.Input, error) {
+	if obj.InputIndexUnknown {
+		return nil, nil
+	}
+	ctx = withAppContract(ctx, obj.AppContract)
 	return r.adapter.GetInputByIndex(ctx, obj.InputIndex)
 }
 
+// Proof is the resolver for the proof field.
+func (r *voucherResolver) Proof(ctx context.Context, obj *model.Voucher) (*model.Proof, error) {
+	proof, err := r.adapter.GetVoucherProof(ctx, obj)
+	if err != nil || proof == nil {
+		return proof, err
+	}
+	output, err := model.VoucherOutput(obj)
+	if err != nil {
+		return nil, err
+	}
+	return r.checkProof(ctx, obj.AppContract, obj.InputIndex, output, proof)
+}
+
+// Claim is the resolver for the claim field.
+func (r *voucherResolver) Claim(ctx context.Context, obj *model.Voucher) (*model.Claim, error) {
+	ctx = withAppContract(ctx, obj.AppContract)
+	return r.adapter.GetVoucherClaim(ctx, obj)
+}
+
+// ExecutionCalldata is the resolver for the executionCalldata field.
+func (r *voucherResolver) ExecutionCalldata(ctx context.Context, obj *model.Voucher) (*model.TransactionRequest, error) {
+	proof, err := r.adapter.GetVoucherProof(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return model.ConvertExecutionCalldata(obj, proof)
+}
+
+// DecodedPayload is the resolver for the decodedPayload field.
+func (r *voucherResolver) DecodedPayload(ctx context.Context, obj *model.Voucher) (*decoder.DecodedPayload, error) {
+	payload, err := model.VoucherPayload(obj)
+	if err != nil {
+		return nil, err
+	}
+	return r.decodePayload(ctx, payload, obj.Destination, obj.AppContract)
+}
+
 // Input returns graph.InputResolver implementation.
 func (r *Resolver) Input() graph.InputResolver { return &inputResolver{r} }
 
+// Mutation returns graph.MutationResolver implementation.
+func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
+
 // Notice returns graph.NoticeResolver implementation.
 func (r *Resolver) Notice() graph.NoticeResolver { return &noticeResolver{r} }
 
@@ -114,6 +324,7 @@ func (r *Resolver) Report() graph.ReportResolver { return &reportResolver{r} }
 func (r *Resolver) Voucher() graph.VoucherResolver { return &voucherResolver{r} }
 
 type inputResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
 type noticeResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type reportResolver struct{ *Resolver }
@@ -1,7 +1,17 @@
 package reader
 
 import (
+	"context"
+	"crypto/subtle"
+	"slices"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/decoder"
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	cRepos "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
 	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/services"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader/model"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // This file will not be regenerated automatically.
@@ -11,4 +21,123 @@ import (
 type Resolver struct {
 	convenienceService *services.ConvenienceService
 	adapter            Adapter
+	opts               Options
+}
+
+// NonceSource returns the nonce the next signed input of the sender must
+// have, counting the ones not stored yet, like the relay queue
+type NonceSource interface {
+	NextNonce(ctx context.Context, appContract common.Address, msgSender common.Address) (uint64, error)
+}
+
+// nextNonce reads from the primary database, since a stale
+// nonce makes the next signed input to be rejected
+func (r *Resolver) nextNonce(ctx context.Context, appContract string, msgSender string) (uint64, error) {
+	if !common.IsHexAddress(appContract) {
+		return 0, InvalidArgumentError("invalid app contract: %s", appContract)
+	}
+	if !common.IsHexAddress(msgSender) {
+		return 0, InvalidArgumentError("invalid msg sender: %s", msgSender)
+	}
+	ctx = cRepos.WithReadPrimary(ctx)
+	app := common.HexToAddress(appContract)
+	sender := common.HexToAddress(msgSender)
+	nonce, err := r.adapter.GetNonce(ctx, app, sender)
+	if err != nil {
+		return 0, err
+	}
+	for _, source := range r.opts.NonceSources {
+		next, err := source.NextNonce(ctx, app, sender)
+		if err != nil {
+			return 0, err
+		}
+		nonce = max(nonce, next)
+	}
+	return nonce, nil
+}
+
+// decodePayload tries the ABIs of the addresses in order,
+// the empty ones are skipped
+func (r *Resolver) decodePayload(
+	ctx context.Context, data []byte, addresses ...string,
+) (*decoder.DecodedPayload, error) {
+	if r.opts.AbiRegistry == nil {
+		return nil, nil
+	}
+	known := []common.Address{}
+	for _, address := range addresses {
+		if common.IsHexAddress(address) {
+			known = append(known, common.HexToAddress(address))
+		}
+	}
+	return r.opts.AbiRegistry.Decode(ctx, data, known...)
+}
+
+// checkAdmin compares the bearer token of the request with the admin token.
+// Without an admin token, the admin mutations are disabled.
+func (r *Resolver) checkAdmin(ctx context.Context) error {
+	if r.opts.AdminToken == "" {
+		return UnauthorizedError("admin mutations are disabled")
+	}
+	token, _ := ctx.Value(authorizationKey).(string)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(r.opts.AdminToken)) != 1 {
+		return UnauthorizedError("invalid admin token")
+	}
+	return nil
+}
+
+// checkProof folds the output with the proof siblings. The claim is only
+// looked up when the consistency was requested.
+func (r *Resolver) checkProof(
+	ctx context.Context, appContract string, inputIndex int, output []byte, proof *model.Proof,
+) (*model.Proof, error) {
+	var claim *model.Claim
+	if slices.Contains(graphql.CollectAllFields(ctx), "isConsistent") {
+		var err error
+		claim, err = r.adapter.GetOutputClaim(withAppContract(ctx, appContract), appContract, inputIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return model.CheckProof(proof, output, claim), nil
+}
+
+// withAppContract scopes the context to the application of the parent object,
+// so nested fields resolved on the root endpoint can be batched per application.
+func withAppContract(ctx context.Context, appContract string) context.Context {
+	if appContract == "" || ctx.Value(cModel.AppContractKey) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, cModel.AppContractKey, appContract)
+}
+
+// optionalAppContract parses the appContract argument of the queries that
+// default to the application of the URL when it is null
+func optionalAppContract(appContract *string) (*common.Address, error) {
+	if appContract == nil {
+		return nil, nil
+	}
+	if !common.IsHexAddress(*appContract) {
+		return nil, InvalidArgumentError("invalid app contract: %s", *appContract)
+	}
+	app := common.HexToAddress(*appContract)
+	return &app, nil
+}
+
+func webhookDeliveryFilter(where *model.WebhookDeliveryFilter) (cRepos.WebhookDeliveryFilter, error) {
+	filter := cRepos.WebhookDeliveryFilter{}
+	if where == nil {
+		return filter, nil
+	}
+	app, err := optionalAppContract(where.AppContract)
+	if err != nil {
+		return filter, err
+	}
+	filter.AppContract = app
+	filter.EventType = where.EventType
+	if where.Status != nil {
+		status := where.Status.String()
+		filter.Status = &status
+	}
+	return filter, nil
 }
@@ -0,0 +1,113 @@
+package reader
+
+import (
+	"context"
+	"testing"
+
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	searchApp   = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	searchOther = common.HexToAddress("0x75135d8ADb7180640d29d822D9AD59E83E8695b2")
+)
+
+type SearchSuite struct {
+	suite.Suite
+	server *testServer
+}
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, new(SearchSuite))
+}
+
+func (s *SearchSuite) SetupTest() {
+	s.server = newTestServer("search.sqlite3")
+	s.server.register(Options{
+		Applications: []common.Address{searchApp, searchOther},
+	})
+
+	ctx := context.Background()
+	for _, doc := range []cModel.SearchDocument{
+		{Kind: cModel.SEARCH_REPORT, AppContract: searchApp.Hex(), InputIndex: 2, OutputIndex: 0, Content: "error: balance too low"},
+		{Kind: cModel.SEARCH_NOTICE, AppContract: searchApp.Hex(), InputIndex: 1, OutputIndex: 3, Content: `{"balance": 10}`},
+		{Kind: cModel.SEARCH_REPORT, AppContract: searchApp.Hex(), InputIndex: 3, OutputIndex: 1, Content: "deposit accepted"},
+		{Kind: cModel.SEARCH_REPORT, AppContract: searchOther.Hex(), InputIndex: 0, OutputIndex: 0, Content: "Balance updated"},
+	} {
+		s.Require().NoError(s.server.container.GetSearchRepository().Index(ctx, doc))
+	}
+}
+
+func (s *SearchSuite) TearDownTest() {
+	s.server.cleanup()
+}
+
+const searchQuery = `query($appContract: String, $text: String!, $first: Int) {
+	search(appContract: $appContract, text: $text, first: $first) {
+		totalCount
+		edges { node { kind appContract inputIndex index payloadText } }
+	}
+}`
+
+type searchResponse struct {
+	Data struct {
+		Search struct {
+			TotalCount int
+			Edges      []struct {
+				Node struct {
+					Kind        string
+					AppContract string
+					InputIndex  int
+					Index       int
+					PayloadText string
+				}
+			}
+		}
+	}
+	Errors []struct {
+		Message    string
+		Extensions map[string]any
+	}
+}
+
+func (s *SearchSuite) search(path string, variables map[string]any) searchResponse {
+	var res searchResponse
+	s.server.query(s.Require(), path, "", searchQuery, variables, &res)
+	return res
+}
+
+func (s *SearchSuite) TestSearch() {
+	res := s.search("/graphql", map[string]any{"text": "balance"})
+	s.Require().Empty(res.Errors)
+	s.Equal(3, res.Data.Search.TotalCount)
+
+	res = s.search("/graphql", map[string]any{"appContract": searchApp.Hex(), "text": "BALANCE"})
+	s.Require().Empty(res.Errors)
+	s.Require().Equal(2, res.Data.Search.TotalCount)
+	node := res.Data.Search.Edges[0].Node
+	s.Equal("notice", node.Kind)
+	s.Equal(searchApp.Hex(), node.AppContract)
+	s.Equal(1, node.InputIndex)
+	s.Equal(3, node.Index)
+	s.Equal(`{"balance": 10}`, node.PayloadText)
+
+	res = s.search("/graphql", map[string]any{"text": "balance low", "first": 1})
+	s.Require().Empty(res.Errors)
+	s.Equal(1, res.Data.Search.TotalCount)
+	s.Equal("error: balance too low", res.Data.Search.Edges[0].Node.PayloadText)
+}
+
+func (s *SearchSuite) TestSearchByAppPath() {
+	res := s.search("/graphql/"+searchOther.Hex(), map[string]any{"text": "balance"})
+	s.Require().Empty(res.Errors)
+	s.Require().Equal(1, res.Data.Search.TotalCount)
+	s.Equal("Balance updated", res.Data.Search.Edges[0].Node.PayloadText)
+}
+
+func (s *SearchSuite) TestEmptyText() {
+	res := s.search("/graphql", map[string]any{"text": " "})
+	s.Require().NotEmpty(res.Errors)
+	s.Equal("INVALID_ARGUMENT", res.Errors[0].Extensions["code"])
+}
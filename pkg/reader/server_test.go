@@ -0,0 +1,75 @@
+package reader
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is the reader API of the suites that call it through HTTP,
+// on a database of its own
+type testServer struct {
+	dbFactory *commons.DbFactory
+	db        *sqlx.DB
+	container *convenience.Container
+	echo      *echo.Echo
+}
+
+// newTestServer creates the database, the API is served once registered
+func newTestServer(dbName string) *testServer {
+	commons.ConfigureLog(slog.LevelDebug)
+	dbFactory := commons.NewDbFactory()
+	db := dbFactory.CreateDb(dbName)
+	return &testServer{
+		dbFactory: dbFactory,
+		db:        db,
+		container: convenience.NewContainer(*db, false),
+	}
+}
+
+// register serves the API with the options, replacing the one registered before
+func (t *testServer) register(opts Options) {
+	convenienceService := t.container.GetConvenienceService()
+	t.echo = echo.New()
+	Register(t.echo, convenienceService, NewAdapterV1(t.db, convenienceService), opts)
+}
+
+func (t *testServer) cleanup() {
+	t.dbFactory.Cleanup()
+}
+
+// post sends the JSON body, with the token as bearer when it is set
+func (t *testServer) post(path string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	t.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+// query sends the GraphQL query and decodes the response into res
+func (t *testServer) query(
+	require *require.Assertions,
+	path string,
+	token string,
+	query string,
+	variables map[string]any,
+	res any,
+) {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(err)
+	rec := t.post(path, token, string(body))
+	require.Equal(http.StatusOK, rec.Code)
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), res))
+}
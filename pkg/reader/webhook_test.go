@@ -0,0 +1,45 @@
+package reader
+
+import (
+	"context"
+
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+)
+
+const webhookDeliveriesQuery = `query($status: WebhookDeliveryStatus) {
+	webhookDeliveries(where: { status: $status }) {
+		totalCount
+		edges { node { eventId eventType appContract endpoint status attempts responseStatus lastError } }
+	}
+}`
+
+// the delivery log shares the admin token of registerAbi
+func (s *AbiSuite) TestWebhookDeliveries() {
+	ctx := context.Background()
+	repository := s.server.container.GetWebhookRepository()
+	s.Require().NoError(repository.AddEvent(ctx, cModel.WEBHOOK_INPUT_EXCEPTION, abiApp,
+		cModel.InputExceptionEvent{InputIndex: 1}))
+	events, err := repository.FindPendingEvents(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().NoError(repository.Dispatch(ctx, events[0], []string{"https://example.com/hook"}, 1))
+
+	res := s.query("/graphql", "", webhookDeliveriesQuery, nil)
+	s.Equal(CodeUnauthorized, s.errorCode(res))
+
+	res = s.query("/graphql", abiAdminToken, webhookDeliveriesQuery, map[string]any{"status": "PENDING"})
+	s.Require().Nil(res["errors"])
+	deliveries := res["data"].(map[string]any)["webhookDeliveries"].(map[string]any)
+	s.Equal(float64(1), deliveries["totalCount"])
+	node := deliveries["edges"].([]any)[0].(map[string]any)["node"].(map[string]any)
+	s.Equal(cModel.WEBHOOK_INPUT_EXCEPTION, node["eventType"])
+	s.Equal(abiApp.Hex(), node["appContract"])
+	s.Equal("https://example.com/hook", node["endpoint"])
+	s.Equal("PENDING", node["status"])
+	s.Nil(node["responseStatus"])
+	s.Nil(node["lastError"])
+
+	res = s.query("/graphql", abiAdminToken, webhookDeliveriesQuery, map[string]any{"status": "FAILED"})
+	s.Require().Nil(res["errors"])
+	deliveries = res["data"].(map[string]any)["webhookDeliveries"].(map[string]any)
+	s.Equal(float64(0), deliveries["totalCount"])
+}
@@ -0,0 +1,161 @@
+package readerclient
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// InputFilterBuilder builds the filter of the inputs, the conditions must all match
+type InputFilterBuilder struct {
+	filter InputFilter
+}
+
+// InputsWhere starts a filter of the inputs, like
+//
+//	client.Inputs(readerclient.InputsWhere().MsgSender(sender).IndexGreaterThan(10).Build())
+func InputsWhere() *InputFilterBuilder {
+	return &InputFilterBuilder{}
+}
+
+func (b *InputFilterBuilder) IndexGreaterThan(index int) *InputFilterBuilder {
+	b.filter.IndexGreaterThan = &index
+	return b
+}
+
+func (b *InputFilterBuilder) IndexLowerThan(index int) *InputFilterBuilder {
+	b.filter.IndexLowerThan = &index
+	return b
+}
+
+func (b *InputFilterBuilder) MsgSender(msgSender common.Address) *InputFilterBuilder {
+	sender := msgSender.Hex()
+	b.filter.MsgSender = &sender
+	return b
+}
+
+// Type is where the inputs come from: inputbox, espresso or relay
+func (b *InputFilterBuilder) Type(inputType string) *InputFilterBuilder {
+	b.filter.Type = &inputType
+	return b
+}
+
+func (b *InputFilterBuilder) Build() *InputFilter {
+	filter := b.filter
+	return &filter
+}
+
+// VoucherFilterBuilder builds the filter of the vouchers, the conditions must all match
+type VoucherFilterBuilder struct {
+	filters []*ConvenientFilter
+}
+
+// VouchersWhere starts a filter of the vouchers, like
+//
+//	client.Vouchers(readerclient.VouchersWhere().Destination(token).Executed(false).Build())
+func VouchersWhere() *VoucherFilterBuilder {
+	return &VoucherFilterBuilder{}
+}
+
+func (b *VoucherFilterBuilder) Destination(destination common.Address) *VoucherFilterBuilder {
+	address := destination.Hex()
+	b.filters = append(b.filters, &ConvenientFilter{
+		Destination: &AddressFilterInput{Eq: &address},
+	})
+	return b
+}
+
+func (b *VoucherFilterBuilder) Executed(executed bool) *VoucherFilterBuilder {
+	b.filters = append(b.filters, &ConvenientFilter{
+		Executed: &BooleanFilterInput{Eq: &executed},
+	})
+	return b
+}
+
+// ExecutedBetween keeps the vouchers executed in blocks with timestamps
+// in [from, to). A zero time leaves that side open.
+func (b *VoucherFilterBuilder) ExecutedBetween(from time.Time, to time.Time) *VoucherFilterBuilder {
+	filter := RangeFilterInput{}
+	if !from.IsZero() {
+		filter.Gte = formatUint(uint64(from.Unix()))
+	}
+	if !to.IsZero() {
+		filter.Lt = formatUint(uint64(to.Unix()))
+	}
+	b.filters = append(b.filters, &ConvenientFilter{ExecutedAt: &filter})
+	return b
+}
+
+// ExecutedInBlocks keeps the vouchers executed in the blocks from first to last, inclusive
+func (b *VoucherFilterBuilder) ExecutedInBlocks(first uint64, last uint64) *VoucherFilterBuilder {
+	b.filters = append(b.filters, &ConvenientFilter{
+		ExecutedBlock: &RangeFilterInput{Gte: formatUint(first), Lte: formatUint(last)},
+	})
+	return b
+}
+
+func (b *VoucherFilterBuilder) Build() []*ConvenientFilter {
+	return append([]*ConvenientFilter{}, b.filters...)
+}
+
+// PayloadFilterBuilder builds the filter of the notices and reports
+// by the values of their JSON payloads, the conditions must all match.
+// The paths are like $.type or $.items[0].id.
+type PayloadFilterBuilder struct {
+	conditions []UserDataFilter
+}
+
+// PayloadWhere starts a filter of the notices or reports, like
+//
+//	client.Reports(readerclient.PayloadWhere().Eq("$.type", "trade").Gt("$.amount", "100").Build())
+func PayloadWhere() *PayloadFilterBuilder {
+	return &PayloadFilterBuilder{}
+}
+
+func (b *PayloadFilterBuilder) Eq(path string, value string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Eq: &value})
+}
+
+func (b *PayloadFilterBuilder) Ne(path string, value string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Ne: &value})
+}
+
+// Gt compares the values as numbers, as do Gte, Lt and Lte
+func (b *PayloadFilterBuilder) Gt(path string, value string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Gt: &value})
+}
+
+func (b *PayloadFilterBuilder) Gte(path string, value string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Gte: &value})
+}
+
+func (b *PayloadFilterBuilder) Lt(path string, value string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Lt: &value})
+}
+
+func (b *PayloadFilterBuilder) Lte(path string, value string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Lte: &value})
+}
+
+func (b *PayloadFilterBuilder) In(path string, values ...string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, In: values})
+}
+
+func (b *PayloadFilterBuilder) Nin(path string, values ...string) *PayloadFilterBuilder {
+	return b.add(UserDataFilter{Path: path, Nin: values})
+}
+
+func (b *PayloadFilterBuilder) add(condition UserDataFilter) *PayloadFilterBuilder {
+	b.conditions = append(b.conditions, condition)
+	return b
+}
+
+func (b *PayloadFilterBuilder) Build() *PayloadFilter {
+	return &PayloadFilter{Json: append([]UserDataFilter{}, b.conditions...)}
+}
+
+func formatUint(value uint64) *string {
+	formatted := strconv.FormatUint(value, 10)
+	return &formatted
+}
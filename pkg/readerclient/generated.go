@@ -4,10 +4,57 @@ package readerclient
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/Khan/genqlient/graphql"
 )
 
+type AddressFilterInput struct {
+	Eq  *string             `json:"eq"`
+	Ne  *string             `json:"ne"`
+	In  []*string           `json:"in"`
+	Nin []*string           `json:"nin"`
+	And []*ConvenientFilter `json:"and"`
+	Or  []*ConvenientFilter `json:"or"`
+}
+
+// GetEq returns AddressFilterInput.Eq, and is useful for accessing the field via an interface.
+func (v *AddressFilterInput) GetEq() *string { return v.Eq }
+
+// GetNe returns AddressFilterInput.Ne, and is useful for accessing the field via an interface.
+func (v *AddressFilterInput) GetNe() *string { return v.Ne }
+
+// GetIn returns AddressFilterInput.In, and is useful for accessing the field via an interface.
+func (v *AddressFilterInput) GetIn() []*string { return v.In }
+
+// GetNin returns AddressFilterInput.Nin, and is useful for accessing the field via an interface.
+func (v *AddressFilterInput) GetNin() []*string { return v.Nin }
+
+// GetAnd returns AddressFilterInput.And, and is useful for accessing the field via an interface.
+func (v *AddressFilterInput) GetAnd() []*ConvenientFilter { return v.And }
+
+// GetOr returns AddressFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *AddressFilterInput) GetOr() []*ConvenientFilter { return v.Or }
+
+type BooleanFilterInput struct {
+	Eq  *bool               `json:"eq"`
+	Ne  *bool               `json:"ne"`
+	And []*ConvenientFilter `json:"and"`
+	Or  []*ConvenientFilter `json:"or"`
+}
+
+// GetEq returns BooleanFilterInput.Eq, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetEq() *bool { return v.Eq }
+
+// GetNe returns BooleanFilterInput.Ne, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetNe() *bool { return v.Ne }
+
+// GetAnd returns BooleanFilterInput.And, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetAnd() []*ConvenientFilter { return v.And }
+
+// GetOr returns BooleanFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetOr() []*ConvenientFilter { return v.Or }
+
 type CompletionStatus string
 
 const (
@@ -21,6 +68,107 @@ const (
 	CompletionStatusPayloadLengthLimitExceeded CompletionStatus = "PAYLOAD_LENGTH_LIMIT_EXCEEDED"
 )
 
+type ConvenientFilter struct {
+	Destination *AddressFilterInput `json:"destination"`
+	Executed    *BooleanFilterInput `json:"executed"`
+	// Timestamp of the execution block, in seconds since the Unix epoch
+	ExecutedAt *RangeFilterInput `json:"executedAt"`
+	// Number of the execution block
+	ExecutedBlock *RangeFilterInput   `json:"executedBlock"`
+	And           []*ConvenientFilter `json:"and"`
+	Or            []*ConvenientFilter `json:"or"`
+}
+
+// GetDestination returns ConvenientFilter.Destination, and is useful for accessing the field via an interface.
+func (v *ConvenientFilter) GetDestination() *AddressFilterInput { return v.Destination }
+
+// GetExecuted returns ConvenientFilter.Executed, and is useful for accessing the field via an interface.
+func (v *ConvenientFilter) GetExecuted() *BooleanFilterInput { return v.Executed }
+
+// GetExecutedAt returns ConvenientFilter.ExecutedAt, and is useful for accessing the field via an interface.
+func (v *ConvenientFilter) GetExecutedAt() *RangeFilterInput { return v.ExecutedAt }
+
+// GetExecutedBlock returns ConvenientFilter.ExecutedBlock, and is useful for accessing the field via an interface.
+func (v *ConvenientFilter) GetExecutedBlock() *RangeFilterInput { return v.ExecutedBlock }
+
+// GetAnd returns ConvenientFilter.And, and is useful for accessing the field via an interface.
+func (v *ConvenientFilter) GetAnd() []*ConvenientFilter { return v.And }
+
+// GetOr returns ConvenientFilter.Or, and is useful for accessing the field via an interface.
+func (v *ConvenientFilter) GetOr() []*ConvenientFilter { return v.Or }
+
+// Fields of the entities returned by the client.
+type Input struct {
+	// id of the input
+	Id string `json:"id"`
+	// Input index starting from genesis
+	Index int `json:"index"`
+	// Status of the input
+	Status CompletionStatus `json:"status"`
+	// Address responsible for submitting the input
+	MsgSender string `json:"msgSender"`
+	// Number of the base layer block in which the input was recorded
+	BlockNumber    string  `json:"blockNumber"`
+	BlockTimestamp *string `json:"blockTimestamp"`
+	// Input payload in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+	// Input index in the Input Box
+	InputBoxIndex *string `json:"inputBoxIndex"`
+	// Hash of the base layer transaction that added the input, null while it is unknown
+	TransactionHash *string `json:"transactionHash"`
+}
+
+// GetId returns Input.Id, and is useful for accessing the field via an interface.
+func (v *Input) GetId() string { return v.Id }
+
+// GetIndex returns Input.Index, and is useful for accessing the field via an interface.
+func (v *Input) GetIndex() int { return v.Index }
+
+// GetStatus returns Input.Status, and is useful for accessing the field via an interface.
+func (v *Input) GetStatus() CompletionStatus { return v.Status }
+
+// GetMsgSender returns Input.MsgSender, and is useful for accessing the field via an interface.
+func (v *Input) GetMsgSender() string { return v.MsgSender }
+
+// GetBlockNumber returns Input.BlockNumber, and is useful for accessing the field via an interface.
+func (v *Input) GetBlockNumber() string { return v.BlockNumber }
+
+// GetBlockTimestamp returns Input.BlockTimestamp, and is useful for accessing the field via an interface.
+func (v *Input) GetBlockTimestamp() *string { return v.BlockTimestamp }
+
+// GetPayload returns Input.Payload, and is useful for accessing the field via an interface.
+func (v *Input) GetPayload() string { return v.Payload }
+
+// GetInputBoxIndex returns Input.InputBoxIndex, and is useful for accessing the field via an interface.
+func (v *Input) GetInputBoxIndex() *string { return v.InputBoxIndex }
+
+// GetTransactionHash returns Input.TransactionHash, and is useful for accessing the field via an interface.
+func (v *Input) GetTransactionHash() *string { return v.TransactionHash }
+
+// Filter object to restrict results depending on input properties
+type InputFilter struct {
+	// Filter only inputs with index lower than a given value
+	IndexLowerThan *int `json:"indexLowerThan"`
+	// Filter only inputs with index greater than a given value
+	IndexGreaterThan *int `json:"indexGreaterThan"`
+	// Filter only inputs with the message sender
+	MsgSender *string `json:"msgSender"`
+	// Filter only inputs from 'inputbox', 'espresso' or 'relay'
+	Type *string `json:"type"`
+}
+
+// GetIndexLowerThan returns InputFilter.IndexLowerThan, and is useful for accessing the field via an interface.
+func (v *InputFilter) GetIndexLowerThan() *int { return v.IndexLowerThan }
+
+// GetIndexGreaterThan returns InputFilter.IndexGreaterThan, and is useful for accessing the field via an interface.
+func (v *InputFilter) GetIndexGreaterThan() *int { return v.IndexGreaterThan }
+
+// GetMsgSender returns InputFilter.MsgSender, and is useful for accessing the field via an interface.
+func (v *InputFilter) GetMsgSender() *string { return v.MsgSender }
+
+// GetType returns InputFilter.Type, and is useful for accessing the field via an interface.
+func (v *InputFilter) GetType() *string { return v.Type }
+
 // InputStatusInput includes the requested fields of the GraphQL type Input.
 // The GraphQL type's documentation follows.
 //
@@ -42,310 +190,1977 @@ type InputStatusResponse struct {
 // GetInput returns InputStatusResponse.Input, and is useful for accessing the field via an interface.
 func (v *InputStatusResponse) GetInput() InputStatusInput { return v.Input }
 
-// StateInputsInputConnection includes the requested fields of the GraphQL type InputConnection.
+// InputsInputsInputConnection includes the requested fields of the GraphQL type InputConnection.
 // The GraphQL type's documentation follows.
 //
 // Pagination result
-type StateInputsInputConnection struct {
+type InputsInputsInputConnection struct {
+	// Total number of entries that match the query
+	TotalCount int `json:"totalCount"`
+	// Pagination metadata
+	PageInfo InputsInputsInputConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
-	Edges []StateInputsInputConnectionEdgesInputEdge `json:"edges"`
+	Edges []InputsInputsInputConnectionEdgesInputEdge `json:"edges"`
 }
 
-// GetEdges returns StateInputsInputConnection.Edges, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdge {
+// GetTotalCount returns InputsInputsInputConnection.TotalCount, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnection) GetTotalCount() int { return v.TotalCount }
+
+// GetPageInfo returns InputsInputsInputConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnection) GetPageInfo() InputsInputsInputConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetEdges returns InputsInputsInputConnection.Edges, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnection) GetEdges() []InputsInputsInputConnectionEdgesInputEdge {
 	return v.Edges
 }
 
-// StateInputsInputConnectionEdgesInputEdge includes the requested fields of the GraphQL type InputEdge.
+// InputsInputsInputConnectionEdgesInputEdge includes the requested fields of the GraphQL type InputEdge.
 // The GraphQL type's documentation follows.
 //
 // Pagination entry
-type StateInputsInputConnectionEdgesInputEdge struct {
+type InputsInputsInputConnectionEdgesInputEdge struct {
 	// Node instance
-	Node StateInputsInputConnectionEdgesInputEdgeNodeInput `json:"node"`
+	Node InputsInputsInputConnectionEdgesInputEdgeNodeInput `json:"node"`
 }
 
-// GetNode returns StateInputsInputConnectionEdgesInputEdge.Node, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInput {
+// GetNode returns InputsInputsInputConnectionEdgesInputEdge.Node, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdge) GetNode() InputsInputsInputConnectionEdgesInputEdgeNodeInput {
 	return v.Node
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInput includes the requested fields of the GraphQL type Input.
+// InputsInputsInputConnectionEdgesInputEdgeNodeInput includes the requested fields of the GraphQL type Input.
 // The GraphQL type's documentation follows.
 //
 // Request submitted to the application to advance its state
-type StateInputsInputConnectionEdgesInputEdgeNodeInput struct {
-	// Input index starting from genesis
-	Index int `json:"index"`
-	// Status of the input
-	Status CompletionStatus `json:"status"`
-	// Address responsible for submitting the input
-	MsgSender string `json:"msgSender"`
-	// Timestamp associated with the input submission, as defined by the base layer's block in which it was recorded
-	Timestamp string `json:"timestamp"`
-	// Number of the base layer block in which the input was recorded
-	BlockNumber string `json:"blockNumber"`
-	// Input payload in Ethereum hex binary format, starting with '0x'
-	Payload string `json:"payload"`
-	// Get notices from this particular input with support for pagination
-	Notices StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection `json:"notices"`
-	// Get vouchers from this particular input with support for pagination
-	Vouchers StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection `json:"vouchers"`
-	// Get reports from this particular input with support for pagination
-	Reports StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection `json:"reports"`
-}
-
-// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Index, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetIndex() int { return v.Index }
-
-// GetStatus returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Status, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetStatus() CompletionStatus {
-	return v.Status
+type InputsInputsInputConnectionEdgesInputEdgeNodeInput struct {
+	Input `json:"-"`
 }
 
-// GetMsgSender returns StateInputsInputConnectionEdgesInputEdgeNodeInput.MsgSender, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetMsgSender() string { return v.MsgSender }
+// GetId returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.Id, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetId() string { return v.Input.Id }
 
-// GetTimestamp returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Timestamp, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetTimestamp() string { return v.Timestamp }
+// GetIndex returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.Index, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetIndex() int { return v.Input.Index }
 
-// GetBlockNumber returns StateInputsInputConnectionEdgesInputEdgeNodeInput.BlockNumber, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetBlockNumber() string {
-	return v.BlockNumber
+// GetStatus returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.Status, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetStatus() CompletionStatus {
+	return v.Input.Status
 }
 
-// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Payload, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetPayload() string { return v.Payload }
-
-// GetNotices returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Notices, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetNotices() StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection {
-	return v.Notices
+// GetMsgSender returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.MsgSender, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetMsgSender() string {
+	return v.Input.MsgSender
 }
 
-// GetVouchers returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Vouchers, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetVouchers() StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection {
-	return v.Vouchers
+// GetBlockNumber returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.BlockNumber, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetBlockNumber() string {
+	return v.Input.BlockNumber
 }
 
-// GetReports returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Reports, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetReports() StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection {
-	return v.Reports
+// GetBlockTimestamp returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.BlockTimestamp, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetBlockTimestamp() *string {
+	return v.Input.BlockTimestamp
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection includes the requested fields of the GraphQL type NoticeConnection.
-// The GraphQL type's documentation follows.
-//
-// Pagination result
-type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection struct {
-	// Pagination entries returned for the current page
-	Edges []StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
+// GetPayload returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.Payload, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetPayload() string {
+	return v.Input.Payload
 }
 
-// GetEdges returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge {
-	return v.Edges
+// GetInputBoxIndex returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.InputBoxIndex, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetInputBoxIndex() *string {
+	return v.Input.InputBoxIndex
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge includes the requested fields of the GraphQL type NoticeEdge.
-// The GraphQL type's documentation follows.
-//
-// Pagination entry
-type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge struct {
-	// Node instance
-	Node StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice `json:"node"`
+// GetTransactionHash returns InputsInputsInputConnectionEdgesInputEdgeNodeInput.TransactionHash, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) GetTransactionHash() *string {
+	return v.Input.TransactionHash
 }
 
-// GetNode returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge.Node, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice {
-	return v.Node
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*InputsInputsInputConnectionEdgesInputEdgeNodeInput
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.InputsInputsInputConnectionEdgesInputEdgeNodeInput = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Input)
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice includes the requested fields of the GraphQL type Notice.
-// The GraphQL type's documentation follows.
-//
-// Informational statement that can be validated in the base layer blockchain
-type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice struct {
-	// Notice index within the context of the input that produced it
+type __premarshalInputsInputsInputConnectionEdgesInputEdgeNodeInput struct {
+	Id string `json:"id"`
+
 	Index int `json:"index"`
-	// Notice data as a payload in Ethereum hex binary format, starting with '0x'
+
+	Status CompletionStatus `json:"status"`
+
+	MsgSender string `json:"msgSender"`
+
+	BlockNumber string `json:"blockNumber"`
+
+	BlockTimestamp *string `json:"blockTimestamp"`
+
 	Payload string `json:"payload"`
-	// Proof object that allows this notice to be validated by the base layer blockchain
-	Proof StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof `json:"proof"`
-}
 
-// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Index, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetIndex() int {
-	return v.Index
+	InputBoxIndex *string `json:"inputBoxIndex"`
+
+	TransactionHash *string `json:"transactionHash"`
 }
 
-// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Payload, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetPayload() string {
-	return v.Payload
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
 }
 
-// GetProof returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Proof, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetProof() StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof {
-	return v.Proof
+func (v *InputsInputsInputConnectionEdgesInputEdgeNodeInput) __premarshalJSON() (*__premarshalInputsInputsInputConnectionEdgesInputEdgeNodeInput, error) {
+	var retval __premarshalInputsInputsInputConnectionEdgesInputEdgeNodeInput
+
+	retval.Id = v.Input.Id
+	retval.Index = v.Input.Index
+	retval.Status = v.Input.Status
+	retval.MsgSender = v.Input.MsgSender
+	retval.BlockNumber = v.Input.BlockNumber
+	retval.BlockTimestamp = v.Input.BlockTimestamp
+	retval.Payload = v.Input.Payload
+	retval.InputBoxIndex = v.Input.InputBoxIndex
+	retval.TransactionHash = v.Input.TransactionHash
+	return &retval, nil
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof includes the requested fields of the GraphQL type Proof.
+// InputsInputsInputConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
 // The GraphQL type's documentation follows.
 //
-// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
-type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof struct {
-	OutputIndex          string   `json:"outputIndex"`
-	OutputHashesSiblings []string `json:"outputHashesSiblings"`
+// Page metadata for the cursor-based Connection pagination pattern
+type InputsInputsInputConnectionPageInfo struct {
+	PageInfo `json:"-"`
 }
 
-// GetOutputIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof.OutputIndex, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof) GetOutputIndex() string {
-	return v.OutputIndex
-}
+// GetEndCursor returns InputsInputsInputConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionPageInfo) GetEndCursor() *string { return v.PageInfo.EndCursor }
 
-// GetOutputHashesSiblings returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof.OutputHashesSiblings, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof) GetOutputHashesSiblings() []string {
-	return v.OutputHashesSiblings
+// GetHasNextPage returns InputsInputsInputConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *InputsInputsInputConnectionPageInfo) GetHasNextPage() bool { return v.PageInfo.HasNextPage }
+
+func (v *InputsInputsInputConnectionPageInfo) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*InputsInputsInputConnectionPageInfo
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.InputsInputsInputConnectionPageInfo = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PageInfo)
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection includes the requested fields of the GraphQL type ReportConnection.
-// The GraphQL type's documentation follows.
-//
-// Pagination result
-type StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection struct {
-	// Pagination entries returned for the current page
-	Edges []StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge `json:"edges"`
+type __premarshalInputsInputsInputConnectionPageInfo struct {
+	EndCursor *string `json:"endCursor"`
+
+	HasNextPage bool `json:"hasNextPage"`
 }
 
-// GetEdges returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection.Edges, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge {
-	return v.Edges
+func (v *InputsInputsInputConnectionPageInfo) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge includes the requested fields of the GraphQL type ReportEdge.
-// The GraphQL type's documentation follows.
-//
-// Pagination entry
-type StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge struct {
-	// Node instance
-	Node StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport `json:"node"`
+func (v *InputsInputsInputConnectionPageInfo) __premarshalJSON() (*__premarshalInputsInputsInputConnectionPageInfo, error) {
+	var retval __premarshalInputsInputsInputConnectionPageInfo
+
+	retval.EndCursor = v.PageInfo.EndCursor
+	retval.HasNextPage = v.PageInfo.HasNextPage
+	return &retval, nil
 }
 
-// GetNode returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge.Node, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport {
-	return v.Node
+// InputsResponse is returned by Inputs on success.
+type InputsResponse struct {
+	// Get inputs with support for pagination
+	Inputs InputsInputsInputConnection `json:"inputs"`
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport includes the requested fields of the GraphQL type Report.
+// GetInputs returns InputsResponse.Inputs, and is useful for accessing the field via an interface.
+func (v *InputsResponse) GetInputs() InputsInputsInputConnection { return v.Inputs }
+
+// Notice includes the GraphQL fields of Notice requested by the fragment Notice.
 // The GraphQL type's documentation follows.
 //
-// Application log or diagnostic information
-type StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport struct {
-	// Report index within the context of the input that produced it
+// Informational statement that can be validated in the base layer blockchain
+type Notice struct {
+	// Notice index within the context of the input that produced it
 	Index int `json:"index"`
-	// Report data as a payload in Ethereum hex binary format, starting with '0x'
+	// Input whose processing produced the notice
+	Input NoticeInput `json:"input"`
+	// Notice data as a payload in Ethereum hex binary format, starting with '0x'
 	Payload string `json:"payload"`
+	// Notice data as UTF-8 text, null when it is binary
+	PayloadText *string `json:"payloadText"`
+	// Notice data as compact JSON, null when the text is not a JSON object or array
+	PayloadJson *string `json:"payloadJson"`
 }
 
-// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport.Index, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport) GetIndex() int {
-	return v.Index
-}
+// GetIndex returns Notice.Index, and is useful for accessing the field via an interface.
+func (v *Notice) GetIndex() int { return v.Index }
 
-// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport.Payload, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport) GetPayload() string {
-	return v.Payload
-}
+// GetInput returns Notice.Input, and is useful for accessing the field via an interface.
+func (v *Notice) GetInput() NoticeInput { return v.Input }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection includes the requested fields of the GraphQL type VoucherConnection.
+// GetPayload returns Notice.Payload, and is useful for accessing the field via an interface.
+func (v *Notice) GetPayload() string { return v.Payload }
+
+// GetPayloadText returns Notice.PayloadText, and is useful for accessing the field via an interface.
+func (v *Notice) GetPayloadText() *string { return v.PayloadText }
+
+// GetPayloadJson returns Notice.PayloadJson, and is useful for accessing the field via an interface.
+func (v *Notice) GetPayloadJson() *string { return v.PayloadJson }
+
+// NoticeInput includes the requested fields of the GraphQL type Input.
 // The GraphQL type's documentation follows.
 //
-// Pagination result
-type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection struct {
-	// Pagination entries returned for the current page
-	Edges []StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
+// Request submitted to the application to advance its state
+type NoticeInput struct {
+	// Input index starting from genesis
+	Index int `json:"index"`
 }
 
-// GetEdges returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge {
-	return v.Edges
-}
+// GetIndex returns NoticeInput.Index, and is useful for accessing the field via an interface.
+func (v *NoticeInput) GetIndex() int { return v.Index }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge includes the requested fields of the GraphQL type VoucherEdge.
+// NoticeProofNotice includes the requested fields of the GraphQL type Notice.
 // The GraphQL type's documentation follows.
 //
-// Pagination entry
-type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge struct {
-	// Node instance
-	Node StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher `json:"node"`
+// Informational statement that can be validated in the base layer blockchain
+type NoticeProofNotice struct {
+	Notice `json:"-"`
+	// Proof object that allows this notice to be validated by the base layer blockchain
+	Proof *NoticeProofNoticeProof `json:"proof"`
+	// Application.validateOutput call that validates the notice, null while the proof is not available
+	ValidationCalldata *NoticeProofNoticeValidationCalldataTransactionRequest `json:"validationCalldata"`
+}
+
+// GetProof returns NoticeProofNotice.Proof, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetProof() *NoticeProofNoticeProof { return v.Proof }
+
+// GetValidationCalldata returns NoticeProofNotice.ValidationCalldata, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetValidationCalldata() *NoticeProofNoticeValidationCalldataTransactionRequest {
+	return v.ValidationCalldata
+}
+
+// GetIndex returns NoticeProofNotice.Index, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetIndex() int { return v.Notice.Index }
+
+// GetInput returns NoticeProofNotice.Input, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetInput() NoticeInput { return v.Notice.Input }
+
+// GetPayload returns NoticeProofNotice.Payload, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetPayload() string { return v.Notice.Payload }
+
+// GetPayloadText returns NoticeProofNotice.PayloadText, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetPayloadText() *string { return v.Notice.PayloadText }
+
+// GetPayloadJson returns NoticeProofNotice.PayloadJson, and is useful for accessing the field via an interface.
+func (v *NoticeProofNotice) GetPayloadJson() *string { return v.Notice.PayloadJson }
+
+func (v *NoticeProofNotice) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*NoticeProofNotice
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.NoticeProofNotice = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Notice)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalNoticeProofNotice struct {
+	Proof *NoticeProofNoticeProof `json:"proof"`
+
+	ValidationCalldata *NoticeProofNoticeValidationCalldataTransactionRequest `json:"validationCalldata"`
+
+	Index int `json:"index"`
+
+	Input NoticeInput `json:"input"`
+
+	Payload string `json:"payload"`
+
+	PayloadText *string `json:"payloadText"`
+
+	PayloadJson *string `json:"payloadJson"`
+}
+
+func (v *NoticeProofNotice) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *NoticeProofNotice) __premarshalJSON() (*__premarshalNoticeProofNotice, error) {
+	var retval __premarshalNoticeProofNotice
+
+	retval.Proof = v.Proof
+	retval.ValidationCalldata = v.ValidationCalldata
+	retval.Index = v.Notice.Index
+	retval.Input = v.Notice.Input
+	retval.Payload = v.Notice.Payload
+	retval.PayloadText = v.Notice.PayloadText
+	retval.PayloadJson = v.Notice.PayloadJson
+	return &retval, nil
+}
+
+// NoticeProofNoticeProof includes the requested fields of the GraphQL type Proof.
+// The GraphQL type's documentation follows.
+//
+// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
+type NoticeProofNoticeProof struct {
+	Proof `json:"-"`
+}
+
+// GetOutputIndex returns NoticeProofNoticeProof.OutputIndex, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeProof) GetOutputIndex() string { return v.Proof.OutputIndex }
+
+// GetOutputHashesSiblings returns NoticeProofNoticeProof.OutputHashesSiblings, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeProof) GetOutputHashesSiblings() []*string {
+	return v.Proof.OutputHashesSiblings
+}
+
+// GetComputedRoot returns NoticeProofNoticeProof.ComputedRoot, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeProof) GetComputedRoot() *string { return v.Proof.ComputedRoot }
+
+// GetIsConsistent returns NoticeProofNoticeProof.IsConsistent, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeProof) GetIsConsistent() *bool { return v.Proof.IsConsistent }
+
+func (v *NoticeProofNoticeProof) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*NoticeProofNoticeProof
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.NoticeProofNoticeProof = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Proof)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalNoticeProofNoticeProof struct {
+	OutputIndex string `json:"outputIndex"`
+
+	OutputHashesSiblings []*string `json:"outputHashesSiblings"`
+
+	ComputedRoot *string `json:"computedRoot"`
+
+	IsConsistent *bool `json:"isConsistent"`
+}
+
+func (v *NoticeProofNoticeProof) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *NoticeProofNoticeProof) __premarshalJSON() (*__premarshalNoticeProofNoticeProof, error) {
+	var retval __premarshalNoticeProofNoticeProof
+
+	retval.OutputIndex = v.Proof.OutputIndex
+	retval.OutputHashesSiblings = v.Proof.OutputHashesSiblings
+	retval.ComputedRoot = v.Proof.ComputedRoot
+	retval.IsConsistent = v.Proof.IsConsistent
+	return &retval, nil
+}
+
+// NoticeProofNoticeValidationCalldataTransactionRequest includes the requested fields of the GraphQL type TransactionRequest.
+// The GraphQL type's documentation follows.
+//
+// Unsigned base layer transaction, ready to be signed by a wallet
+type NoticeProofNoticeValidationCalldataTransactionRequest struct {
+	TransactionRequest `json:"-"`
+}
+
+// GetTo returns NoticeProofNoticeValidationCalldataTransactionRequest.To, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeValidationCalldataTransactionRequest) GetTo() string {
+	return v.TransactionRequest.To
+}
+
+// GetData returns NoticeProofNoticeValidationCalldataTransactionRequest.Data, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeValidationCalldataTransactionRequest) GetData() string {
+	return v.TransactionRequest.Data
+}
+
+// GetValue returns NoticeProofNoticeValidationCalldataTransactionRequest.Value, and is useful for accessing the field via an interface.
+func (v *NoticeProofNoticeValidationCalldataTransactionRequest) GetValue() string {
+	return v.TransactionRequest.Value
+}
+
+func (v *NoticeProofNoticeValidationCalldataTransactionRequest) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*NoticeProofNoticeValidationCalldataTransactionRequest
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.NoticeProofNoticeValidationCalldataTransactionRequest = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.TransactionRequest)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalNoticeProofNoticeValidationCalldataTransactionRequest struct {
+	To string `json:"to"`
+
+	Data string `json:"data"`
+
+	Value string `json:"value"`
+}
+
+func (v *NoticeProofNoticeValidationCalldataTransactionRequest) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *NoticeProofNoticeValidationCalldataTransactionRequest) __premarshalJSON() (*__premarshalNoticeProofNoticeValidationCalldataTransactionRequest, error) {
+	var retval __premarshalNoticeProofNoticeValidationCalldataTransactionRequest
+
+	retval.To = v.TransactionRequest.To
+	retval.Data = v.TransactionRequest.Data
+	retval.Value = v.TransactionRequest.Value
+	return &retval, nil
+}
+
+// NoticeProofResponse is returned by NoticeProof on success.
+type NoticeProofResponse struct {
+	// Get a notice based on its index
+	Notice NoticeProofNotice `json:"notice"`
+}
+
+// GetNotice returns NoticeProofResponse.Notice, and is useful for accessing the field via an interface.
+func (v *NoticeProofResponse) GetNotice() NoticeProofNotice { return v.Notice }
+
+// NoticesNoticesNoticeConnection includes the requested fields of the GraphQL type NoticeConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type NoticesNoticesNoticeConnection struct {
+	// Total number of entries that match the query
+	TotalCount int `json:"totalCount"`
+	// Pagination metadata
+	PageInfo NoticesNoticesNoticeConnectionPageInfo `json:"pageInfo"`
+	// Pagination entries returned for the current page
+	Edges []NoticesNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
+}
+
+// GetTotalCount returns NoticesNoticesNoticeConnection.TotalCount, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnection) GetTotalCount() int { return v.TotalCount }
+
+// GetPageInfo returns NoticesNoticesNoticeConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnection) GetPageInfo() NoticesNoticesNoticeConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetEdges returns NoticesNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnection) GetEdges() []NoticesNoticesNoticeConnectionEdgesNoticeEdge {
+	return v.Edges
+}
+
+// NoticesNoticesNoticeConnectionEdgesNoticeEdge includes the requested fields of the GraphQL type NoticeEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type NoticesNoticesNoticeConnectionEdgesNoticeEdge struct {
+	// Node instance
+	Node NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice `json:"node"`
+}
+
+// GetNode returns NoticesNoticesNoticeConnectionEdgesNoticeEdge.Node, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdge) GetNode() NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice {
+	return v.Node
+}
+
+// NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice includes the requested fields of the GraphQL type Notice.
+// The GraphQL type's documentation follows.
+//
+// Informational statement that can be validated in the base layer blockchain
+type NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice struct {
+	Notice `json:"-"`
+}
+
+// GetIndex returns NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Index, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetIndex() int {
+	return v.Notice.Index
+}
+
+// GetInput returns NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Input, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetInput() NoticeInput {
+	return v.Notice.Input
+}
+
+// GetPayload returns NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Payload, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetPayload() string {
+	return v.Notice.Payload
+}
+
+// GetPayloadText returns NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.PayloadText, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetPayloadText() *string {
+	return v.Notice.PayloadText
+}
+
+// GetPayloadJson returns NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.PayloadJson, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetPayloadJson() *string {
+	return v.Notice.PayloadJson
+}
+
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Notice)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalNoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice struct {
+	Index int `json:"index"`
+
+	Input NoticeInput `json:"input"`
+
+	Payload string `json:"payload"`
+
+	PayloadText *string `json:"payloadText"`
+
+	PayloadJson *string `json:"payloadJson"`
+}
+
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *NoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) __premarshalJSON() (*__premarshalNoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice, error) {
+	var retval __premarshalNoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice
+
+	retval.Index = v.Notice.Index
+	retval.Input = v.Notice.Input
+	retval.Payload = v.Notice.Payload
+	retval.PayloadText = v.Notice.PayloadText
+	retval.PayloadJson = v.Notice.PayloadJson
+	return &retval, nil
+}
+
+// NoticesNoticesNoticeConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type NoticesNoticesNoticeConnectionPageInfo struct {
+	PageInfo `json:"-"`
+}
+
+// GetEndCursor returns NoticesNoticesNoticeConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionPageInfo) GetEndCursor() *string { return v.PageInfo.EndCursor }
+
+// GetHasNextPage returns NoticesNoticesNoticeConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *NoticesNoticesNoticeConnectionPageInfo) GetHasNextPage() bool { return v.PageInfo.HasNextPage }
+
+func (v *NoticesNoticesNoticeConnectionPageInfo) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*NoticesNoticesNoticeConnectionPageInfo
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.NoticesNoticesNoticeConnectionPageInfo = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PageInfo)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalNoticesNoticesNoticeConnectionPageInfo struct {
+	EndCursor *string `json:"endCursor"`
+
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+func (v *NoticesNoticesNoticeConnectionPageInfo) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *NoticesNoticesNoticeConnectionPageInfo) __premarshalJSON() (*__premarshalNoticesNoticesNoticeConnectionPageInfo, error) {
+	var retval __premarshalNoticesNoticesNoticeConnectionPageInfo
+
+	retval.EndCursor = v.PageInfo.EndCursor
+	retval.HasNextPage = v.PageInfo.HasNextPage
+	return &retval, nil
+}
+
+// NoticesResponse is returned by Notices on success.
+type NoticesResponse struct {
+	// Get notices with support for pagination
+	Notices NoticesNoticesNoticeConnection `json:"notices"`
+}
+
+// GetNotices returns NoticesResponse.Notices, and is useful for accessing the field via an interface.
+func (v *NoticesResponse) GetNotices() NoticesNoticesNoticeConnection { return v.Notices }
+
+// PageInfo includes the GraphQL fields of PageInfo requested by the fragment PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type PageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor *string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns PageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *PageInfo) GetEndCursor() *string { return v.EndCursor }
+
+// GetHasNextPage returns PageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *PageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
+// Filter object to restrict notices and reports depending on their payload
+type PayloadFilter struct {
+	// Filter only the payloads whose JSON matches, all the conditions must match
+	Json []UserDataFilter `json:"json"`
+}
+
+// GetJson returns PayloadFilter.Json, and is useful for accessing the field via an interface.
+func (v *PayloadFilter) GetJson() []UserDataFilter { return v.Json }
+
+// Proof includes the GraphQL fields of Proof requested by the fragment Proof.
+// The GraphQL type's documentation follows.
+//
+// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
+type Proof struct {
+	OutputIndex          string    `json:"outputIndex"`
+	OutputHashesSiblings []*string `json:"outputHashesSiblings"`
+	// Outputs Merkle root folded from the output and its siblings, null while the siblings are not available
+	ComputedRoot *string `json:"computedRoot"`
+	// Whether the computed root matches the claim of the epoch, null while the epoch has no accepted claim
+	IsConsistent *bool `json:"isConsistent"`
+}
+
+// GetOutputIndex returns Proof.OutputIndex, and is useful for accessing the field via an interface.
+func (v *Proof) GetOutputIndex() string { return v.OutputIndex }
+
+// GetOutputHashesSiblings returns Proof.OutputHashesSiblings, and is useful for accessing the field via an interface.
+func (v *Proof) GetOutputHashesSiblings() []*string { return v.OutputHashesSiblings }
+
+// GetComputedRoot returns Proof.ComputedRoot, and is useful for accessing the field via an interface.
+func (v *Proof) GetComputedRoot() *string { return v.ComputedRoot }
+
+// GetIsConsistent returns Proof.IsConsistent, and is useful for accessing the field via an interface.
+func (v *Proof) GetIsConsistent() *bool { return v.IsConsistent }
+
+type RangeFilterInput struct {
+	Gt  *string `json:"gt"`
+	Gte *string `json:"gte"`
+	Lt  *string `json:"lt"`
+	Lte *string `json:"lte"`
+}
+
+// GetGt returns RangeFilterInput.Gt, and is useful for accessing the field via an interface.
+func (v *RangeFilterInput) GetGt() *string { return v.Gt }
+
+// GetGte returns RangeFilterInput.Gte, and is useful for accessing the field via an interface.
+func (v *RangeFilterInput) GetGte() *string { return v.Gte }
+
+// GetLt returns RangeFilterInput.Lt, and is useful for accessing the field via an interface.
+func (v *RangeFilterInput) GetLt() *string { return v.Lt }
+
+// GetLte returns RangeFilterInput.Lte, and is useful for accessing the field via an interface.
+func (v *RangeFilterInput) GetLte() *string { return v.Lte }
+
+// Report includes the GraphQL fields of Report requested by the fragment Report.
+// The GraphQL type's documentation follows.
+//
+// Application log or diagnostic information
+type Report struct {
+	// Report index within the context of the input that produced it
+	Index int `json:"index"`
+	// Input whose processing produced the report
+	Input ReportInput `json:"input"`
+	// Report data as a payload in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+	// Report data as UTF-8 text, null when it is binary
+	PayloadText *string `json:"payloadText"`
+	// Report data as compact JSON, null when the text is not a JSON object or array
+	PayloadJson *string `json:"payloadJson"`
+}
+
+// GetIndex returns Report.Index, and is useful for accessing the field via an interface.
+func (v *Report) GetIndex() int { return v.Index }
+
+// GetInput returns Report.Input, and is useful for accessing the field via an interface.
+func (v *Report) GetInput() ReportInput { return v.Input }
+
+// GetPayload returns Report.Payload, and is useful for accessing the field via an interface.
+func (v *Report) GetPayload() string { return v.Payload }
+
+// GetPayloadText returns Report.PayloadText, and is useful for accessing the field via an interface.
+func (v *Report) GetPayloadText() *string { return v.PayloadText }
+
+// GetPayloadJson returns Report.PayloadJson, and is useful for accessing the field via an interface.
+func (v *Report) GetPayloadJson() *string { return v.PayloadJson }
+
+// ReportInput includes the requested fields of the GraphQL type Input.
+// The GraphQL type's documentation follows.
+//
+// Request submitted to the application to advance its state
+type ReportInput struct {
+	// Input index starting from genesis
+	Index int `json:"index"`
+}
+
+// GetIndex returns ReportInput.Index, and is useful for accessing the field via an interface.
+func (v *ReportInput) GetIndex() int { return v.Index }
+
+// ReportsReportsReportConnection includes the requested fields of the GraphQL type ReportConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type ReportsReportsReportConnection struct {
+	// Total number of entries that match the query
+	TotalCount int `json:"totalCount"`
+	// Pagination metadata
+	PageInfo ReportsReportsReportConnectionPageInfo `json:"pageInfo"`
+	// Pagination entries returned for the current page
+	Edges []ReportsReportsReportConnectionEdgesReportEdge `json:"edges"`
+}
+
+// GetTotalCount returns ReportsReportsReportConnection.TotalCount, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnection) GetTotalCount() int { return v.TotalCount }
+
+// GetPageInfo returns ReportsReportsReportConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnection) GetPageInfo() ReportsReportsReportConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetEdges returns ReportsReportsReportConnection.Edges, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnection) GetEdges() []ReportsReportsReportConnectionEdgesReportEdge {
+	return v.Edges
+}
+
+// ReportsReportsReportConnectionEdgesReportEdge includes the requested fields of the GraphQL type ReportEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type ReportsReportsReportConnectionEdgesReportEdge struct {
+	// Node instance
+	Node ReportsReportsReportConnectionEdgesReportEdgeNodeReport `json:"node"`
+}
+
+// GetNode returns ReportsReportsReportConnectionEdgesReportEdge.Node, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionEdgesReportEdge) GetNode() ReportsReportsReportConnectionEdgesReportEdgeNodeReport {
+	return v.Node
+}
+
+// ReportsReportsReportConnectionEdgesReportEdgeNodeReport includes the requested fields of the GraphQL type Report.
+// The GraphQL type's documentation follows.
+//
+// Application log or diagnostic information
+type ReportsReportsReportConnectionEdgesReportEdgeNodeReport struct {
+	Report `json:"-"`
+}
+
+// GetIndex returns ReportsReportsReportConnectionEdgesReportEdgeNodeReport.Index, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) GetIndex() int {
+	return v.Report.Index
+}
+
+// GetInput returns ReportsReportsReportConnectionEdgesReportEdgeNodeReport.Input, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) GetInput() ReportInput {
+	return v.Report.Input
+}
+
+// GetPayload returns ReportsReportsReportConnectionEdgesReportEdgeNodeReport.Payload, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) GetPayload() string {
+	return v.Report.Payload
+}
+
+// GetPayloadText returns ReportsReportsReportConnectionEdgesReportEdgeNodeReport.PayloadText, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) GetPayloadText() *string {
+	return v.Report.PayloadText
+}
+
+// GetPayloadJson returns ReportsReportsReportConnectionEdgesReportEdgeNodeReport.PayloadJson, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) GetPayloadJson() *string {
+	return v.Report.PayloadJson
+}
+
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ReportsReportsReportConnectionEdgesReportEdgeNodeReport
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ReportsReportsReportConnectionEdgesReportEdgeNodeReport = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Report)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalReportsReportsReportConnectionEdgesReportEdgeNodeReport struct {
+	Index int `json:"index"`
+
+	Input ReportInput `json:"input"`
+
+	Payload string `json:"payload"`
+
+	PayloadText *string `json:"payloadText"`
+
+	PayloadJson *string `json:"payloadJson"`
+}
+
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ReportsReportsReportConnectionEdgesReportEdgeNodeReport) __premarshalJSON() (*__premarshalReportsReportsReportConnectionEdgesReportEdgeNodeReport, error) {
+	var retval __premarshalReportsReportsReportConnectionEdgesReportEdgeNodeReport
+
+	retval.Index = v.Report.Index
+	retval.Input = v.Report.Input
+	retval.Payload = v.Report.Payload
+	retval.PayloadText = v.Report.PayloadText
+	retval.PayloadJson = v.Report.PayloadJson
+	return &retval, nil
+}
+
+// ReportsReportsReportConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type ReportsReportsReportConnectionPageInfo struct {
+	PageInfo `json:"-"`
+}
+
+// GetEndCursor returns ReportsReportsReportConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionPageInfo) GetEndCursor() *string { return v.PageInfo.EndCursor }
+
+// GetHasNextPage returns ReportsReportsReportConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *ReportsReportsReportConnectionPageInfo) GetHasNextPage() bool { return v.PageInfo.HasNextPage }
+
+func (v *ReportsReportsReportConnectionPageInfo) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ReportsReportsReportConnectionPageInfo
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ReportsReportsReportConnectionPageInfo = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PageInfo)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalReportsReportsReportConnectionPageInfo struct {
+	EndCursor *string `json:"endCursor"`
+
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+func (v *ReportsReportsReportConnectionPageInfo) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ReportsReportsReportConnectionPageInfo) __premarshalJSON() (*__premarshalReportsReportsReportConnectionPageInfo, error) {
+	var retval __premarshalReportsReportsReportConnectionPageInfo
+
+	retval.EndCursor = v.PageInfo.EndCursor
+	retval.HasNextPage = v.PageInfo.HasNextPage
+	return &retval, nil
+}
+
+// ReportsResponse is returned by Reports on success.
+type ReportsResponse struct {
+	// Get reports with support for pagination
+	Reports ReportsReportsReportConnection `json:"reports"`
+}
+
+// GetReports returns ReportsResponse.Reports, and is useful for accessing the field via an interface.
+func (v *ReportsResponse) GetReports() ReportsReportsReportConnection { return v.Reports }
+
+// StateInputsInputConnection includes the requested fields of the GraphQL type InputConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type StateInputsInputConnection struct {
+	// Pagination entries returned for the current page
+	Edges []StateInputsInputConnectionEdgesInputEdge `json:"edges"`
+}
+
+// GetEdges returns StateInputsInputConnection.Edges, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdge {
+	return v.Edges
+}
+
+// StateInputsInputConnectionEdgesInputEdge includes the requested fields of the GraphQL type InputEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type StateInputsInputConnectionEdgesInputEdge struct {
+	// Node instance
+	Node StateInputsInputConnectionEdgesInputEdgeNodeInput `json:"node"`
+}
+
+// GetNode returns StateInputsInputConnectionEdgesInputEdge.Node, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInput {
+	return v.Node
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInput includes the requested fields of the GraphQL type Input.
+// The GraphQL type's documentation follows.
+//
+// Request submitted to the application to advance its state
+type StateInputsInputConnectionEdgesInputEdgeNodeInput struct {
+	// Input index starting from genesis
+	Index int `json:"index"`
+	// Status of the input
+	Status CompletionStatus `json:"status"`
+	// Address responsible for submitting the input
+	MsgSender string `json:"msgSender"`
+	// Timestamp associated with the input submission, as defined by the base layer's block in which it was recorded
+	Timestamp string `json:"timestamp"`
+	// Number of the base layer block in which the input was recorded
+	BlockNumber string `json:"blockNumber"`
+	// Input payload in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+	// Get notices from this particular input with support for pagination
+	Notices StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection `json:"notices"`
+	// Get vouchers from this particular input with support for pagination
+	Vouchers StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection `json:"vouchers"`
+	// Get reports from this particular input with support for pagination
+	Reports StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection `json:"reports"`
+}
+
+// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Index, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetIndex() int { return v.Index }
+
+// GetStatus returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Status, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetStatus() CompletionStatus {
+	return v.Status
+}
+
+// GetMsgSender returns StateInputsInputConnectionEdgesInputEdgeNodeInput.MsgSender, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetMsgSender() string { return v.MsgSender }
+
+// GetTimestamp returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetTimestamp() string { return v.Timestamp }
+
+// GetBlockNumber returns StateInputsInputConnectionEdgesInputEdgeNodeInput.BlockNumber, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetBlockNumber() string {
+	return v.BlockNumber
+}
+
+// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Payload, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetPayload() string { return v.Payload }
+
+// GetNotices returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Notices, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetNotices() StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection {
+	return v.Notices
+}
+
+// GetVouchers returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Vouchers, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetVouchers() StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection {
+	return v.Vouchers
+}
+
+// GetReports returns StateInputsInputConnectionEdgesInputEdgeNodeInput.Reports, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInput) GetReports() StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection {
+	return v.Reports
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection includes the requested fields of the GraphQL type NoticeConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection struct {
+	// Pagination entries returned for the current page
+	Edges []StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
+}
+
+// GetEdges returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge {
+	return v.Edges
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge includes the requested fields of the GraphQL type NoticeEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge struct {
+	// Node instance
+	Node StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice `json:"node"`
+}
+
+// GetNode returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge.Node, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice {
+	return v.Node
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice includes the requested fields of the GraphQL type Notice.
+// The GraphQL type's documentation follows.
+//
+// Informational statement that can be validated in the base layer blockchain
+type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice struct {
+	// Notice index within the context of the input that produced it
+	Index int `json:"index"`
+	// Notice data as a payload in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+	// Proof object that allows this notice to be validated by the base layer blockchain
+	Proof *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof `json:"proof"`
+}
+
+// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Index, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetIndex() int {
+	return v.Index
+}
+
+// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Payload, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetPayload() string {
+	return v.Payload
+}
+
+// GetProof returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice.Proof, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNotice) GetProof() *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof {
+	return v.Proof
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof includes the requested fields of the GraphQL type Proof.
+// The GraphQL type's documentation follows.
+//
+// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
+type StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof struct {
+	OutputIndex          string    `json:"outputIndex"`
+	OutputHashesSiblings []*string `json:"outputHashesSiblings"`
+}
+
+// GetOutputIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof.OutputIndex, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof) GetOutputIndex() string {
+	return v.OutputIndex
+}
+
+// GetOutputHashesSiblings returns StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof.OutputHashesSiblings, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProof) GetOutputHashesSiblings() []*string {
+	return v.OutputHashesSiblings
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection includes the requested fields of the GraphQL type ReportConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection struct {
+	// Pagination entries returned for the current page
+	Edges []StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge `json:"edges"`
+}
+
+// GetEdges returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection.Edges, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge {
+	return v.Edges
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge includes the requested fields of the GraphQL type ReportEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge struct {
+	// Node instance
+	Node StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport `json:"node"`
+}
+
+// GetNode returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge.Node, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport {
+	return v.Node
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport includes the requested fields of the GraphQL type Report.
+// The GraphQL type's documentation follows.
+//
+// Application log or diagnostic information
+type StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport struct {
+	// Report index within the context of the input that produced it
+	Index int `json:"index"`
+	// Report data as a payload in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+}
+
+// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport.Index, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport) GetIndex() int {
+	return v.Index
+}
+
+// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport.Payload, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdgeNodeReport) GetPayload() string {
+	return v.Payload
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection includes the requested fields of the GraphQL type VoucherConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection struct {
+	// Pagination entries returned for the current page
+	Edges []StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
+}
+
+// GetEdges returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection) GetEdges() []StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge {
+	return v.Edges
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge includes the requested fields of the GraphQL type VoucherEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge struct {
+	// Node instance
+	Node StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher `json:"node"`
+}
+
+// GetNode returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge.Node, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher {
+	return v.Node
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher includes the requested fields of the GraphQL type Voucher.
+// The GraphQL type's documentation follows.
+//
+// Representation of a transaction that can be carried out on the base layer blockchain, such as a transfer of assets
+type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher struct {
+	// Voucher index within the context of the input that produced it
+	Index int `json:"index"`
+	// Transaction payload in Ethereum hex binary format, starting with '0x'
+	Payload string `json:"payload"`
+	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with '0x'
+	Destination string `json:"destination"`
+	// Proof object that allows this voucher to be validated and executed on the base layer blockchain
+	Proof *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof `json:"proof"`
+}
+
+// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Index, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetIndex() int {
+	return v.Index
+}
+
+// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Payload, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetPayload() string {
+	return v.Payload
+}
+
+// GetDestination returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Destination, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetDestination() string {
+	return v.Destination
+}
+
+// GetProof returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Proof, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetProof() *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof {
+	return v.Proof
+}
+
+// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof includes the requested fields of the GraphQL type Proof.
+// The GraphQL type's documentation follows.
+//
+// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
+type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof struct {
+	OutputIndex          string    `json:"outputIndex"`
+	OutputHashesSiblings []*string `json:"outputHashesSiblings"`
+}
+
+// GetOutputIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof.OutputIndex, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof) GetOutputIndex() string {
+	return v.OutputIndex
+}
+
+// GetOutputHashesSiblings returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof.OutputHashesSiblings, and is useful for accessing the field via an interface.
+func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof) GetOutputHashesSiblings() []*string {
+	return v.OutputHashesSiblings
+}
+
+// StateResponse is returned by State on success.
+type StateResponse struct {
+	// Get inputs with support for pagination
+	Inputs StateInputsInputConnection `json:"inputs"`
+}
+
+// GetInputs returns StateResponse.Inputs, and is useful for accessing the field via an interface.
+func (v *StateResponse) GetInputs() StateInputsInputConnection { return v.Inputs }
+
+// TransactionRequest includes the GraphQL fields of TransactionRequest requested by the fragment TransactionRequest.
+// The GraphQL type's documentation follows.
+//
+// Unsigned base layer transaction, ready to be signed by a wallet
+type TransactionRequest struct {
+	// Address of the application in Ethereum hex binary format (20 bytes), starting with '0x'
+	To string `json:"to"`
+	// Call data in Ethereum hex binary format, starting with '0x'
+	Data string `json:"data"`
+	// Amount of wei sent along with the transaction
+	Value string `json:"value"`
+}
+
+// GetTo returns TransactionRequest.To, and is useful for accessing the field via an interface.
+func (v *TransactionRequest) GetTo() string { return v.To }
+
+// GetData returns TransactionRequest.Data, and is useful for accessing the field via an interface.
+func (v *TransactionRequest) GetData() string { return v.Data }
+
+// GetValue returns TransactionRequest.Value, and is useful for accessing the field via an interface.
+func (v *TransactionRequest) GetValue() string { return v.Value }
+
+// Comparison of the value at a path of the JSON payload, the values are compared as text
+type UserDataFilter struct {
+	// Path of the value, like $.type or $.items[0].id
+	Path string  `json:"path"`
+	Eq   *string `json:"eq"`
+	Ne   *string `json:"ne"`
+	// Greater than, comparing numbers
+	Gt *string `json:"gt"`
+	// Greater than or equal, comparing numbers
+	Gte *string `json:"gte"`
+	// Lower than, comparing numbers
+	Lt *string `json:"lt"`
+	// Lower than or equal, comparing numbers
+	Lte *string  `json:"lte"`
+	In  []string `json:"in"`
+	Nin []string `json:"nin"`
+}
+
+// GetPath returns UserDataFilter.Path, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetPath() string { return v.Path }
+
+// GetEq returns UserDataFilter.Eq, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetEq() *string { return v.Eq }
+
+// GetNe returns UserDataFilter.Ne, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetNe() *string { return v.Ne }
+
+// GetGt returns UserDataFilter.Gt, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetGt() *string { return v.Gt }
+
+// GetGte returns UserDataFilter.Gte, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetGte() *string { return v.Gte }
+
+// GetLt returns UserDataFilter.Lt, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetLt() *string { return v.Lt }
+
+// GetLte returns UserDataFilter.Lte, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetLte() *string { return v.Lte }
+
+// GetIn returns UserDataFilter.In, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetIn() []string { return v.In }
+
+// GetNin returns UserDataFilter.Nin, and is useful for accessing the field via an interface.
+func (v *UserDataFilter) GetNin() []string { return v.Nin }
+
+// Voucher includes the GraphQL fields of Voucher requested by the fragment Voucher.
+// The GraphQL type's documentation follows.
+//
+// Representation of a transaction that can be carried out on the base layer blockchain, such as a transfer of assets
+type Voucher struct {
+	// Voucher index within the context of the input that produced it
+	Index int `json:"index"`
+	// Input whose processing produced the voucher
+	Input VoucherInput `json:"input"`
+	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with '0x'
+	Destination string `json:"destination"`
+	// Transaction payload in Ethereum hex binary format, starting with '0x'
+	Payload string  `json:"payload"`
+	Value   *string `json:"value"`
+	// Indicates whether the voucher has been executed on the base layer blockchain
+	Executed *bool `json:"executed"`
+	// The hash of executed transaction
+	TransactionHash *string `json:"transactionHash"`
+}
+
+// GetIndex returns Voucher.Index, and is useful for accessing the field via an interface.
+func (v *Voucher) GetIndex() int { return v.Index }
+
+// GetInput returns Voucher.Input, and is useful for accessing the field via an interface.
+func (v *Voucher) GetInput() VoucherInput { return v.Input }
+
+// GetDestination returns Voucher.Destination, and is useful for accessing the field via an interface.
+func (v *Voucher) GetDestination() string { return v.Destination }
+
+// GetPayload returns Voucher.Payload, and is useful for accessing the field via an interface.
+func (v *Voucher) GetPayload() string { return v.Payload }
+
+// GetValue returns Voucher.Value, and is useful for accessing the field via an interface.
+func (v *Voucher) GetValue() *string { return v.Value }
+
+// GetExecuted returns Voucher.Executed, and is useful for accessing the field via an interface.
+func (v *Voucher) GetExecuted() *bool { return v.Executed }
+
+// GetTransactionHash returns Voucher.TransactionHash, and is useful for accessing the field via an interface.
+func (v *Voucher) GetTransactionHash() *string { return v.TransactionHash }
+
+// VoucherInput includes the requested fields of the GraphQL type Input.
+// The GraphQL type's documentation follows.
+//
+// Request submitted to the application to advance its state
+type VoucherInput struct {
+	// Input index starting from genesis
+	Index int `json:"index"`
+}
+
+// GetIndex returns VoucherInput.Index, and is useful for accessing the field via an interface.
+func (v *VoucherInput) GetIndex() int { return v.Index }
+
+// VoucherProofResponse is returned by VoucherProof on success.
+type VoucherProofResponse struct {
+	// Get a voucher based on its index
+	Voucher VoucherProofVoucher `json:"voucher"`
+}
+
+// GetVoucher returns VoucherProofResponse.Voucher, and is useful for accessing the field via an interface.
+func (v *VoucherProofResponse) GetVoucher() VoucherProofVoucher { return v.Voucher }
+
+// VoucherProofVoucher includes the requested fields of the GraphQL type Voucher.
+// The GraphQL type's documentation follows.
+//
+// Representation of a transaction that can be carried out on the base layer blockchain, such as a transfer of assets
+type VoucherProofVoucher struct {
+	Voucher `json:"-"`
+	// Proof object that allows this voucher to be validated and executed on the base layer blockchain
+	Proof *VoucherProofVoucherProof `json:"proof"`
+	// Accepted claim of the epoch of the voucher. While it is null, the proof can not be executed on the base layer blockchain
+	Claim *VoucherProofVoucherClaim `json:"claim"`
+	// Application.executeOutput transaction that executes the voucher, null while the proof is not available
+	ExecutionCalldata *VoucherProofVoucherExecutionCalldataTransactionRequest `json:"executionCalldata"`
+}
+
+// GetProof returns VoucherProofVoucher.Proof, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetProof() *VoucherProofVoucherProof { return v.Proof }
+
+// GetClaim returns VoucherProofVoucher.Claim, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetClaim() *VoucherProofVoucherClaim { return v.Claim }
+
+// GetExecutionCalldata returns VoucherProofVoucher.ExecutionCalldata, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetExecutionCalldata() *VoucherProofVoucherExecutionCalldataTransactionRequest {
+	return v.ExecutionCalldata
+}
+
+// GetIndex returns VoucherProofVoucher.Index, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetIndex() int { return v.Voucher.Index }
+
+// GetInput returns VoucherProofVoucher.Input, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetInput() VoucherInput { return v.Voucher.Input }
+
+// GetDestination returns VoucherProofVoucher.Destination, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetDestination() string { return v.Voucher.Destination }
+
+// GetPayload returns VoucherProofVoucher.Payload, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetPayload() string { return v.Voucher.Payload }
+
+// GetValue returns VoucherProofVoucher.Value, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetValue() *string { return v.Voucher.Value }
+
+// GetExecuted returns VoucherProofVoucher.Executed, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetExecuted() *bool { return v.Voucher.Executed }
+
+// GetTransactionHash returns VoucherProofVoucher.TransactionHash, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucher) GetTransactionHash() *string { return v.Voucher.TransactionHash }
+
+func (v *VoucherProofVoucher) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*VoucherProofVoucher
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.VoucherProofVoucher = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Voucher)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalVoucherProofVoucher struct {
+	Proof *VoucherProofVoucherProof `json:"proof"`
+
+	Claim *VoucherProofVoucherClaim `json:"claim"`
+
+	ExecutionCalldata *VoucherProofVoucherExecutionCalldataTransactionRequest `json:"executionCalldata"`
+
+	Index int `json:"index"`
+
+	Input VoucherInput `json:"input"`
+
+	Destination string `json:"destination"`
+
+	Payload string `json:"payload"`
+
+	Value *string `json:"value"`
+
+	Executed *bool `json:"executed"`
+
+	TransactionHash *string `json:"transactionHash"`
+}
+
+func (v *VoucherProofVoucher) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *VoucherProofVoucher) __premarshalJSON() (*__premarshalVoucherProofVoucher, error) {
+	var retval __premarshalVoucherProofVoucher
+
+	retval.Proof = v.Proof
+	retval.Claim = v.Claim
+	retval.ExecutionCalldata = v.ExecutionCalldata
+	retval.Index = v.Voucher.Index
+	retval.Input = v.Voucher.Input
+	retval.Destination = v.Voucher.Destination
+	retval.Payload = v.Voucher.Payload
+	retval.Value = v.Voucher.Value
+	retval.Executed = v.Voucher.Executed
+	retval.TransactionHash = v.Voucher.TransactionHash
+	return &retval, nil
+}
+
+// VoucherProofVoucherClaim includes the requested fields of the GraphQL type Claim.
+// The GraphQL type's documentation follows.
+//
+// Claim of the outputs of an epoch submitted to the consensus
+type VoucherProofVoucherClaim struct {
+	// Epoch index, starting from genesis
+	EpochIndex int `json:"epochIndex"`
+	// Indicates whether the consensus accepted the claim, making the proofs of the epoch executable
+	Accepted bool `json:"accepted"`
+}
+
+// GetEpochIndex returns VoucherProofVoucherClaim.EpochIndex, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherClaim) GetEpochIndex() int { return v.EpochIndex }
+
+// GetAccepted returns VoucherProofVoucherClaim.Accepted, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherClaim) GetAccepted() bool { return v.Accepted }
+
+// VoucherProofVoucherExecutionCalldataTransactionRequest includes the requested fields of the GraphQL type TransactionRequest.
+// The GraphQL type's documentation follows.
+//
+// Unsigned base layer transaction, ready to be signed by a wallet
+type VoucherProofVoucherExecutionCalldataTransactionRequest struct {
+	TransactionRequest `json:"-"`
+}
+
+// GetTo returns VoucherProofVoucherExecutionCalldataTransactionRequest.To, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherExecutionCalldataTransactionRequest) GetTo() string {
+	return v.TransactionRequest.To
+}
+
+// GetData returns VoucherProofVoucherExecutionCalldataTransactionRequest.Data, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherExecutionCalldataTransactionRequest) GetData() string {
+	return v.TransactionRequest.Data
+}
+
+// GetValue returns VoucherProofVoucherExecutionCalldataTransactionRequest.Value, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherExecutionCalldataTransactionRequest) GetValue() string {
+	return v.TransactionRequest.Value
+}
+
+func (v *VoucherProofVoucherExecutionCalldataTransactionRequest) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*VoucherProofVoucherExecutionCalldataTransactionRequest
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.VoucherProofVoucherExecutionCalldataTransactionRequest = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.TransactionRequest)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalVoucherProofVoucherExecutionCalldataTransactionRequest struct {
+	To string `json:"to"`
+
+	Data string `json:"data"`
+
+	Value string `json:"value"`
+}
+
+func (v *VoucherProofVoucherExecutionCalldataTransactionRequest) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *VoucherProofVoucherExecutionCalldataTransactionRequest) __premarshalJSON() (*__premarshalVoucherProofVoucherExecutionCalldataTransactionRequest, error) {
+	var retval __premarshalVoucherProofVoucherExecutionCalldataTransactionRequest
+
+	retval.To = v.TransactionRequest.To
+	retval.Data = v.TransactionRequest.Data
+	retval.Value = v.TransactionRequest.Value
+	return &retval, nil
+}
+
+// VoucherProofVoucherProof includes the requested fields of the GraphQL type Proof.
+// The GraphQL type's documentation follows.
+//
+// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
+type VoucherProofVoucherProof struct {
+	Proof `json:"-"`
+}
+
+// GetOutputIndex returns VoucherProofVoucherProof.OutputIndex, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherProof) GetOutputIndex() string { return v.Proof.OutputIndex }
+
+// GetOutputHashesSiblings returns VoucherProofVoucherProof.OutputHashesSiblings, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherProof) GetOutputHashesSiblings() []*string {
+	return v.Proof.OutputHashesSiblings
+}
+
+// GetComputedRoot returns VoucherProofVoucherProof.ComputedRoot, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherProof) GetComputedRoot() *string { return v.Proof.ComputedRoot }
+
+// GetIsConsistent returns VoucherProofVoucherProof.IsConsistent, and is useful for accessing the field via an interface.
+func (v *VoucherProofVoucherProof) GetIsConsistent() *bool { return v.Proof.IsConsistent }
+
+func (v *VoucherProofVoucherProof) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*VoucherProofVoucherProof
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.VoucherProofVoucherProof = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Proof)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalVoucherProofVoucherProof struct {
+	OutputIndex string `json:"outputIndex"`
+
+	OutputHashesSiblings []*string `json:"outputHashesSiblings"`
+
+	ComputedRoot *string `json:"computedRoot"`
+
+	IsConsistent *bool `json:"isConsistent"`
+}
+
+func (v *VoucherProofVoucherProof) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *VoucherProofVoucherProof) __premarshalJSON() (*__premarshalVoucherProofVoucherProof, error) {
+	var retval __premarshalVoucherProofVoucherProof
+
+	retval.OutputIndex = v.Proof.OutputIndex
+	retval.OutputHashesSiblings = v.Proof.OutputHashesSiblings
+	retval.ComputedRoot = v.Proof.ComputedRoot
+	retval.IsConsistent = v.Proof.IsConsistent
+	return &retval, nil
+}
+
+// VouchersResponse is returned by Vouchers on success.
+type VouchersResponse struct {
+	// Get vouchers with support for pagination
+	Vouchers VouchersVouchersVoucherConnection `json:"vouchers"`
+}
+
+// GetVouchers returns VouchersResponse.Vouchers, and is useful for accessing the field via an interface.
+func (v *VouchersResponse) GetVouchers() VouchersVouchersVoucherConnection { return v.Vouchers }
+
+// VouchersVouchersVoucherConnection includes the requested fields of the GraphQL type VoucherConnection.
+// The GraphQL type's documentation follows.
+//
+// Pagination result
+type VouchersVouchersVoucherConnection struct {
+	// Total number of entries that match the query
+	TotalCount int `json:"totalCount"`
+	// Pagination metadata
+	PageInfo VouchersVouchersVoucherConnectionPageInfo `json:"pageInfo"`
+	// Pagination entries returned for the current page
+	Edges []VouchersVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
+}
+
+// GetTotalCount returns VouchersVouchersVoucherConnection.TotalCount, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnection) GetTotalCount() int { return v.TotalCount }
+
+// GetPageInfo returns VouchersVouchersVoucherConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnection) GetPageInfo() VouchersVouchersVoucherConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetEdges returns VouchersVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnection) GetEdges() []VouchersVouchersVoucherConnectionEdgesVoucherEdge {
+	return v.Edges
+}
+
+// VouchersVouchersVoucherConnectionEdgesVoucherEdge includes the requested fields of the GraphQL type VoucherEdge.
+// The GraphQL type's documentation follows.
+//
+// Pagination entry
+type VouchersVouchersVoucherConnectionEdgesVoucherEdge struct {
+	// Node instance
+	Node VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher `json:"node"`
+}
+
+// GetNode returns VouchersVouchersVoucherConnectionEdgesVoucherEdge.Node, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdge) GetNode() VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher {
+	return v.Node
+}
+
+// VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher includes the requested fields of the GraphQL type Voucher.
+// The GraphQL type's documentation follows.
+//
+// Representation of a transaction that can be carried out on the base layer blockchain, such as a transfer of assets
+type VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher struct {
+	Voucher `json:"-"`
+}
+
+// GetIndex returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Index, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetIndex() int {
+	return v.Voucher.Index
+}
+
+// GetInput returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Input, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetInput() VoucherInput {
+	return v.Voucher.Input
+}
+
+// GetDestination returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Destination, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetDestination() string {
+	return v.Voucher.Destination
+}
+
+// GetPayload returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Payload, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetPayload() string {
+	return v.Voucher.Payload
+}
+
+// GetValue returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Value, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetValue() *string {
+	return v.Voucher.Value
+}
+
+// GetExecuted returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Executed, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetExecuted() *bool {
+	return v.Voucher.Executed
+}
+
+// GetTransactionHash returns VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.TransactionHash, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetTransactionHash() *string {
+	return v.Voucher.TransactionHash
+}
+
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Voucher)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalVouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher struct {
+	Index int `json:"index"`
+
+	Input VoucherInput `json:"input"`
+
+	Destination string `json:"destination"`
+
+	Payload string `json:"payload"`
+
+	Value *string `json:"value"`
+
+	Executed *bool `json:"executed"`
+
+	TransactionHash *string `json:"transactionHash"`
+}
+
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
 }
 
-// GetNode returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge.Node, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge) GetNode() StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher {
-	return v.Node
+func (v *VouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) __premarshalJSON() (*__premarshalVouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher, error) {
+	var retval __premarshalVouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher
+
+	retval.Index = v.Voucher.Index
+	retval.Input = v.Voucher.Input
+	retval.Destination = v.Voucher.Destination
+	retval.Payload = v.Voucher.Payload
+	retval.Value = v.Voucher.Value
+	retval.Executed = v.Voucher.Executed
+	retval.TransactionHash = v.Voucher.TransactionHash
+	return &retval, nil
 }
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher includes the requested fields of the GraphQL type Voucher.
+// VouchersVouchersVoucherConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
 // The GraphQL type's documentation follows.
 //
-// Representation of a transaction that can be carried out on the base layer blockchain, such as a transfer of assets
-type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher struct {
-	// Voucher index within the context of the input that produced it
-	Index int `json:"index"`
-	// Transaction payload in Ethereum hex binary format, starting with '0x'
-	Payload string `json:"payload"`
-	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with '0x'
-	Destination string `json:"destination"`
-	// Proof object that allows this voucher to be validated and executed on the base layer blockchain
-	Proof StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof `json:"proof"`
+// Page metadata for the cursor-based Connection pagination pattern
+type VouchersVouchersVoucherConnectionPageInfo struct {
+	PageInfo `json:"-"`
 }
 
-// GetIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Index, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetIndex() int {
-	return v.Index
+// GetEndCursor returns VouchersVouchersVoucherConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionPageInfo) GetEndCursor() *string {
+	return v.PageInfo.EndCursor
 }
 
-// GetPayload returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Payload, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetPayload() string {
-	return v.Payload
+// GetHasNextPage returns VouchersVouchersVoucherConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *VouchersVouchersVoucherConnectionPageInfo) GetHasNextPage() bool {
+	return v.PageInfo.HasNextPage
 }
 
-// GetDestination returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Destination, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetDestination() string {
-	return v.Destination
-}
+func (v *VouchersVouchersVoucherConnectionPageInfo) UnmarshalJSON(b []byte) error {
 
-// GetProof returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher.Proof, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucher) GetProof() StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof {
-	return v.Proof
-}
+	if string(b) == "null" {
+		return nil
+	}
 
-// StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof includes the requested fields of the GraphQL type Proof.
-// The GraphQL type's documentation follows.
-//
-// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
-type StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof struct {
-	OutputIndex          string   `json:"outputIndex"`
-	OutputHashesSiblings []string `json:"outputHashesSiblings"`
-}
+	var firstPass struct {
+		*VouchersVouchersVoucherConnectionPageInfo
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.VouchersVouchersVoucherConnectionPageInfo = v
 
-// GetOutputIndex returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof.OutputIndex, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof) GetOutputIndex() string {
-	return v.OutputIndex
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PageInfo)
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
-// GetOutputHashesSiblings returns StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof.OutputHashesSiblings, and is useful for accessing the field via an interface.
-func (v *StateInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProof) GetOutputHashesSiblings() []string {
-	return v.OutputHashesSiblings
+type __premarshalVouchersVouchersVoucherConnectionPageInfo struct {
+	EndCursor *string `json:"endCursor"`
+
+	HasNextPage bool `json:"hasNextPage"`
 }
 
-// StateResponse is returned by State on success.
-type StateResponse struct {
-	// Get inputs with support for pagination
-	Inputs StateInputsInputConnection `json:"inputs"`
+func (v *VouchersVouchersVoucherConnectionPageInfo) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
 }
 
-// GetInputs returns StateResponse.Inputs, and is useful for accessing the field via an interface.
-func (v *StateResponse) GetInputs() StateInputsInputConnection { return v.Inputs }
+func (v *VouchersVouchersVoucherConnectionPageInfo) __premarshalJSON() (*__premarshalVouchersVouchersVoucherConnectionPageInfo, error) {
+	var retval __premarshalVouchersVouchersVoucherConnectionPageInfo
+
+	retval.EndCursor = v.PageInfo.EndCursor
+	retval.HasNextPage = v.PageInfo.HasNextPage
+	return &retval, nil
+}
 
 // __InputStatusInput is used internally by genqlient
 type __InputStatusInput struct {
@@ -355,6 +2170,86 @@ type __InputStatusInput struct {
 // GetId returns __InputStatusInput.Id, and is useful for accessing the field via an interface.
 func (v *__InputStatusInput) GetId() string { return v.Id }
 
+// __InputsInput is used internally by genqlient
+type __InputsInput struct {
+	First *int         `json:"first"`
+	After *string      `json:"after"`
+	Where *InputFilter `json:"where"`
+}
+
+// GetFirst returns __InputsInput.First, and is useful for accessing the field via an interface.
+func (v *__InputsInput) GetFirst() *int { return v.First }
+
+// GetAfter returns __InputsInput.After, and is useful for accessing the field via an interface.
+func (v *__InputsInput) GetAfter() *string { return v.After }
+
+// GetWhere returns __InputsInput.Where, and is useful for accessing the field via an interface.
+func (v *__InputsInput) GetWhere() *InputFilter { return v.Where }
+
+// __NoticeProofInput is used internally by genqlient
+type __NoticeProofInput struct {
+	OutputIndex int `json:"outputIndex"`
+}
+
+// GetOutputIndex returns __NoticeProofInput.OutputIndex, and is useful for accessing the field via an interface.
+func (v *__NoticeProofInput) GetOutputIndex() int { return v.OutputIndex }
+
+// __NoticesInput is used internally by genqlient
+type __NoticesInput struct {
+	First *int           `json:"first"`
+	After *string        `json:"after"`
+	Where *PayloadFilter `json:"where"`
+}
+
+// GetFirst returns __NoticesInput.First, and is useful for accessing the field via an interface.
+func (v *__NoticesInput) GetFirst() *int { return v.First }
+
+// GetAfter returns __NoticesInput.After, and is useful for accessing the field via an interface.
+func (v *__NoticesInput) GetAfter() *string { return v.After }
+
+// GetWhere returns __NoticesInput.Where, and is useful for accessing the field via an interface.
+func (v *__NoticesInput) GetWhere() *PayloadFilter { return v.Where }
+
+// __ReportsInput is used internally by genqlient
+type __ReportsInput struct {
+	First *int           `json:"first"`
+	After *string        `json:"after"`
+	Where *PayloadFilter `json:"where"`
+}
+
+// GetFirst returns __ReportsInput.First, and is useful for accessing the field via an interface.
+func (v *__ReportsInput) GetFirst() *int { return v.First }
+
+// GetAfter returns __ReportsInput.After, and is useful for accessing the field via an interface.
+func (v *__ReportsInput) GetAfter() *string { return v.After }
+
+// GetWhere returns __ReportsInput.Where, and is useful for accessing the field via an interface.
+func (v *__ReportsInput) GetWhere() *PayloadFilter { return v.Where }
+
+// __VoucherProofInput is used internally by genqlient
+type __VoucherProofInput struct {
+	OutputIndex int `json:"outputIndex"`
+}
+
+// GetOutputIndex returns __VoucherProofInput.OutputIndex, and is useful for accessing the field via an interface.
+func (v *__VoucherProofInput) GetOutputIndex() int { return v.OutputIndex }
+
+// __VouchersInput is used internally by genqlient
+type __VouchersInput struct {
+	First  *int                `json:"first"`
+	After  *string             `json:"after"`
+	Filter []*ConvenientFilter `json:"filter"`
+}
+
+// GetFirst returns __VouchersInput.First, and is useful for accessing the field via an interface.
+func (v *__VouchersInput) GetFirst() *int { return v.First }
+
+// GetAfter returns __VouchersInput.After, and is useful for accessing the field via an interface.
+func (v *__VouchersInput) GetAfter() *string { return v.After }
+
+// GetFilter returns __VouchersInput.Filter, and is useful for accessing the field via an interface.
+func (v *__VouchersInput) GetFilter() []*ConvenientFilter { return v.Filter }
+
 // The query or mutation executed by InputStatus.
 const InputStatus_Operation = `
 query InputStatus ($id: String!) {
@@ -391,6 +2286,253 @@ func InputStatus(
 	return &data, err
 }
 
+// The query or mutation executed by Inputs.
+const Inputs_Operation = `
+query Inputs ($first: Int, $after: String, $where: InputFilter) {
+	inputs(first: $first, after: $after, where: $where) {
+		totalCount
+		pageInfo {
+			... PageInfo
+		}
+		edges {
+			node {
+				... Input
+			}
+		}
+	}
+}
+fragment PageInfo on PageInfo {
+	endCursor
+	hasNextPage
+}
+fragment Input on Input {
+	id
+	index
+	status
+	msgSender
+	blockNumber
+	blockTimestamp
+	payload
+	inputBoxIndex
+	transactionHash
+}
+`
+
+// Get a page of inputs.
+func Inputs(
+	ctx context.Context,
+	client graphql.Client,
+	first *int,
+	after *string,
+	where *InputFilter,
+) (*InputsResponse, error) {
+	req := &graphql.Request{
+		OpName: "Inputs",
+		Query:  Inputs_Operation,
+		Variables: &__InputsInput{
+			First: first,
+			After: after,
+			Where: where,
+		},
+	}
+	var err error
+
+	var data InputsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by NoticeProof.
+const NoticeProof_Operation = `
+query NoticeProof ($outputIndex: Int!) {
+	notice(outputIndex: $outputIndex) {
+		... Notice
+		proof {
+			... Proof
+		}
+		validationCalldata {
+			... TransactionRequest
+		}
+	}
+}
+fragment Notice on Notice {
+	index
+	input {
+		index
+	}
+	payload
+	payloadText
+	payloadJson
+}
+fragment Proof on Proof {
+	outputIndex
+	outputHashesSiblings
+	computedRoot
+	isConsistent
+}
+fragment TransactionRequest on TransactionRequest {
+	to
+	data
+	value
+}
+`
+
+// Get the proof of a notice and the call that validates it.
+func NoticeProof(
+	ctx context.Context,
+	client graphql.Client,
+	outputIndex int,
+) (*NoticeProofResponse, error) {
+	req := &graphql.Request{
+		OpName: "NoticeProof",
+		Query:  NoticeProof_Operation,
+		Variables: &__NoticeProofInput{
+			OutputIndex: outputIndex,
+		},
+	}
+	var err error
+
+	var data NoticeProofResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by Notices.
+const Notices_Operation = `
+query Notices ($first: Int, $after: String, $where: PayloadFilter) {
+	notices(first: $first, after: $after, where: $where) {
+		totalCount
+		pageInfo {
+			... PageInfo
+		}
+		edges {
+			node {
+				... Notice
+			}
+		}
+	}
+}
+fragment PageInfo on PageInfo {
+	endCursor
+	hasNextPage
+}
+fragment Notice on Notice {
+	index
+	input {
+		index
+	}
+	payload
+	payloadText
+	payloadJson
+}
+`
+
+// Get a page of notices.
+func Notices(
+	ctx context.Context,
+	client graphql.Client,
+	first *int,
+	after *string,
+	where *PayloadFilter,
+) (*NoticesResponse, error) {
+	req := &graphql.Request{
+		OpName: "Notices",
+		Query:  Notices_Operation,
+		Variables: &__NoticesInput{
+			First: first,
+			After: after,
+			Where: where,
+		},
+	}
+	var err error
+
+	var data NoticesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by Reports.
+const Reports_Operation = `
+query Reports ($first: Int, $after: String, $where: PayloadFilter) {
+	reports(first: $first, after: $after, where: $where) {
+		totalCount
+		pageInfo {
+			... PageInfo
+		}
+		edges {
+			node {
+				... Report
+			}
+		}
+	}
+}
+fragment PageInfo on PageInfo {
+	endCursor
+	hasNextPage
+}
+fragment Report on Report {
+	index
+	input {
+		index
+	}
+	payload
+	payloadText
+	payloadJson
+}
+`
+
+// Get a page of reports.
+func Reports(
+	ctx context.Context,
+	client graphql.Client,
+	first *int,
+	after *string,
+	where *PayloadFilter,
+) (*ReportsResponse, error) {
+	req := &graphql.Request{
+		OpName: "Reports",
+		Query:  Reports_Operation,
+		Variables: &__ReportsInput{
+			First: first,
+			After: after,
+			Where: where,
+		},
+	}
+	var err error
+
+	var data ReportsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by State.
 const State_Operation = `
 query State {
@@ -464,3 +2606,134 @@ func State(
 
 	return &data, err
 }
+
+// The query or mutation executed by VoucherProof.
+const VoucherProof_Operation = `
+query VoucherProof ($outputIndex: Int!) {
+	voucher(outputIndex: $outputIndex) {
+		... Voucher
+		proof {
+			... Proof
+		}
+		claim {
+			epochIndex
+			accepted
+		}
+		executionCalldata {
+			... TransactionRequest
+		}
+	}
+}
+fragment Voucher on Voucher {
+	index
+	input {
+		index
+	}
+	destination
+	payload
+	value
+	executed
+	transactionHash
+}
+fragment Proof on Proof {
+	outputIndex
+	outputHashesSiblings
+	computedRoot
+	isConsistent
+}
+fragment TransactionRequest on TransactionRequest {
+	to
+	data
+	value
+}
+`
+
+// Get the proof of a voucher and the transaction that executes it.
+func VoucherProof(
+	ctx context.Context,
+	client graphql.Client,
+	outputIndex int,
+) (*VoucherProofResponse, error) {
+	req := &graphql.Request{
+		OpName: "VoucherProof",
+		Query:  VoucherProof_Operation,
+		Variables: &__VoucherProofInput{
+			OutputIndex: outputIndex,
+		},
+	}
+	var err error
+
+	var data VoucherProofResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by Vouchers.
+const Vouchers_Operation = `
+query Vouchers ($first: Int, $after: String, $filter: [ConvenientFilter]) {
+	vouchers(first: $first, after: $after, filter: $filter) {
+		totalCount
+		pageInfo {
+			... PageInfo
+		}
+		edges {
+			node {
+				... Voucher
+			}
+		}
+	}
+}
+fragment PageInfo on PageInfo {
+	endCursor
+	hasNextPage
+}
+fragment Voucher on Voucher {
+	index
+	input {
+		index
+	}
+	destination
+	payload
+	value
+	executed
+	transactionHash
+}
+`
+
+// Get a page of vouchers.
+func Vouchers(
+	ctx context.Context,
+	client graphql.Client,
+	first *int,
+	after *string,
+	filter []*ConvenientFilter,
+) (*VouchersResponse, error) {
+	req := &graphql.Request{
+		OpName: "Vouchers",
+		Query:  Vouchers_Operation,
+		Variables: &__VouchersInput{
+			First:  first,
+			After:  after,
+			Filter: filter,
+		},
+	}
+	var err error
+
+	var data VouchersResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
@@ -0,0 +1,94 @@
+package readerclient
+
+import (
+	"context"
+)
+
+// page of a connection
+type page[T any] struct {
+	items       []T
+	totalCount  int
+	endCursor   *string
+	hasNextPage bool
+}
+
+type fetchPage[T any] func(ctx context.Context, first int, after *string) (*page[T], error)
+
+// Iterator walks a connection forward, fetching the next page when
+// the current one is consumed. It is used like bufio.Scanner:
+//
+//	it := client.Inputs(nil)
+//	for it.Next(ctx) {
+//		input := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch      fetchPage[T]
+	pageSize   int
+	items      []T
+	position   int
+	current    T
+	after      *string
+	totalCount int
+	done       bool
+	err        error
+}
+
+func newIterator[T any](pageSize int, fetch fetchPage[T]) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Iterator[T]{fetch: fetch, pageSize: pageSize}
+}
+
+// Next advances to the next entry, returning false at the end or on error
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	for it.position >= len(it.items) {
+		if it.done {
+			return false
+		}
+		page, err := it.fetch(ctx, it.pageSize, it.after)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.items
+		it.position = 0
+		it.totalCount = page.totalCount
+		it.after = page.endCursor
+		it.done = !page.hasNextPage || page.endCursor == nil
+	}
+	it.current = it.items[it.position]
+	it.position++
+	return true
+}
+
+// Value is the current entry
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err is the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// TotalCount is the number of entries that match the query,
+// as of the last page fetched
+func (it *Iterator[T]) TotalCount() int {
+	return it.totalCount
+}
+
+// All fetches the remaining entries
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	all := []T{}
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
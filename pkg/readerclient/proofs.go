@@ -0,0 +1,119 @@
+package readerclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// ErrProofNotAvailable is returned while the epoch of the output is open
+	ErrProofNotAvailable = errors.New("proof not available")
+	// ErrInconsistentProof is returned when the root computed from the proof
+	// does not match the accepted claim of the epoch
+	ErrInconsistentProof = errors.New("proof does not match the accepted claim")
+)
+
+// VoucherProof gets the voucher with its proof, claim and execution call
+func (c *Client) VoucherProof(ctx context.Context, outputIndex int) (*VoucherProofVoucher, error) {
+	res, err := VoucherProof(ctx, c.graphql, outputIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Voucher, nil
+}
+
+// NoticeProof gets the notice with its proof and validation call
+func (c *Client) NoticeProof(ctx context.Context, outputIndex int) (*NoticeProofNotice, error) {
+	res, err := NoticeProof(ctx, c.graphql, outputIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Notice, nil
+}
+
+// VoucherExecution returns the Application.executeOutput transaction of the voucher
+func (c *Client) VoucherExecution(ctx context.Context, outputIndex int) (*TransactionRequest, error) {
+	voucher, err := c.VoucherProof(ctx, outputIndex)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.ExecutionCalldata == nil || voucher.Proof == nil {
+		return nil, ErrProofNotAvailable
+	}
+	if err := checkConsistency(&voucher.Proof.Proof); err != nil {
+		return nil, err
+	}
+	return &voucher.ExecutionCalldata.TransactionRequest, nil
+}
+
+// NoticeValidation returns the Application.validateOutput call of the notice
+func (c *Client) NoticeValidation(ctx context.Context, outputIndex int) (*TransactionRequest, error) {
+	notice, err := c.NoticeProof(ctx, outputIndex)
+	if err != nil {
+		return nil, err
+	}
+	if notice.ValidationCalldata == nil || notice.Proof == nil {
+		return nil, ErrProofNotAvailable
+	}
+	if err := checkConsistency(&notice.Proof.Proof); err != nil {
+		return nil, err
+	}
+	return &notice.ValidationCalldata.TransactionRequest, nil
+}
+
+// WaitVoucherExecution polls the voucher until its proof is available
+// and returns the transaction that executes it
+func (c *Client) WaitVoucherExecution(
+	ctx context.Context, outputIndex int, interval time.Duration,
+) (*TransactionRequest, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		tx, err := c.VoucherExecution(ctx, outputIndex)
+		if !errors.Is(err, ErrProofNotAvailable) {
+			return tx, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// the consistency is unknown until the claim of the epoch is accepted
+func checkConsistency(proof *Proof) error {
+	if proof.IsConsistent != nil && !*proof.IsConsistent {
+		return ErrInconsistentProof
+	}
+	return nil
+}
+
+// CallMsg converts the transaction to estimate its gas or send it with go-ethereum
+func (t *TransactionRequest) CallMsg(from common.Address) (ethereum.CallMsg, error) {
+	if !common.IsHexAddress(t.To) {
+		return ethereum.CallMsg{}, fmt.Errorf("invalid transaction address: %s", t.To)
+	}
+	data, err := hexutil.Decode(t.Data)
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("invalid transaction data: %w", err)
+	}
+	value, ok := new(big.Int).SetString(t.Value, 10) // nolint
+	if !ok {
+		return ethereum.CallMsg{}, fmt.Errorf("invalid transaction value: %s", t.Value)
+	}
+	to := common.HexToAddress(t.To)
+	return ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package readerclient
+
+import (
+	"context"
+)
+
+// Inputs iterates over the inputs in index order.
+// The filter is optional, see InputsWhere.
+func (c *Client) Inputs(where *InputFilter) *Iterator[Input] {
+	return newIterator(c.PageSize, func(ctx context.Context, first int, after *string) (*page[Input], error) {
+		res, err := Inputs(ctx, c.graphql, &first, after, where)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]Input, len(res.Inputs.Edges))
+		for i, edge := range res.Inputs.Edges {
+			items[i] = edge.Node.Input
+		}
+		return &page[Input]{
+			items:       items,
+			totalCount:  res.Inputs.TotalCount,
+			endCursor:   res.Inputs.PageInfo.EndCursor,
+			hasNextPage: res.Inputs.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// Vouchers iterates over the vouchers in output index order.
+// The filter is optional, see VouchersWhere.
+func (c *Client) Vouchers(filter []*ConvenientFilter) *Iterator[Voucher] {
+	return newIterator(c.PageSize, func(ctx context.Context, first int, after *string) (*page[Voucher], error) {
+		res, err := Vouchers(ctx, c.graphql, &first, after, filter)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]Voucher, len(res.Vouchers.Edges))
+		for i, edge := range res.Vouchers.Edges {
+			items[i] = edge.Node.Voucher
+		}
+		return &page[Voucher]{
+			items:       items,
+			totalCount:  res.Vouchers.TotalCount,
+			endCursor:   res.Vouchers.PageInfo.EndCursor,
+			hasNextPage: res.Vouchers.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// Notices iterates over the notices in output index order.
+// The filter is optional, see PayloadWhere.
+func (c *Client) Notices(where *PayloadFilter) *Iterator[Notice] {
+	return newIterator(c.PageSize, func(ctx context.Context, first int, after *string) (*page[Notice], error) {
+		res, err := Notices(ctx, c.graphql, &first, after, where)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]Notice, len(res.Notices.Edges))
+		for i, edge := range res.Notices.Edges {
+			items[i] = edge.Node.Notice
+		}
+		return &page[Notice]{
+			items:       items,
+			totalCount:  res.Notices.TotalCount,
+			endCursor:   res.Notices.PageInfo.EndCursor,
+			hasNextPage: res.Notices.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// Reports iterates over the reports in index order.
+// The filter is optional, see PayloadWhere.
+func (c *Client) Reports(where *PayloadFilter) *Iterator[Report] {
+	return newIterator(c.PageSize, func(ctx context.Context, first int, after *string) (*page[Report], error) {
+		res, err := Reports(ctx, c.graphql, &first, after, where)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]Report, len(res.Reports.Edges))
+		for i, edge := range res.Reports.Edges {
+			items[i] = edge.Node.Report
+		}
+		return &page[Report]{
+			items:       items,
+			totalCount:  res.Reports.TotalCount,
+			endCursor:   res.Reports.PageInfo.EndCursor,
+			hasNextPage: res.Reports.PageInfo.HasNextPage,
+		}, nil
+	})
+}
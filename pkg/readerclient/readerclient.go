@@ -1,7 +1,66 @@
 // Copyright (c) Gabriel de Quadros Ligneul
 // SPDX-License-Identifier: Apache-2.0 (see LICENSE)
 
-// This package contains a client for the GraphQL reader API for testing purposes.
+// This package contains a typed client for the GraphQL reader API.
+// The queries are generated by genqlient from the .graphql files.
 package readerclient
 
 //go:generate go run github.com/Khan/genqlient
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultPageSize is the number of entries the iterators fetch per request
+const DefaultPageSize = 100
+
+// Client of the GraphQL reader API
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+	graphql    graphql.Client
+	// PageSize is the number of entries the iterators fetch per request
+	PageSize int
+}
+
+// NewClient creates a client of the GraphQL endpoint, like
+// http://localhost:8080/graphql. The http client is optional,
+// without it http.DefaultClient is used.
+func NewClient(endpoint string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	return &Client{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+		graphql:    graphql.NewClient(endpoint, httpClient),
+		PageSize:   DefaultPageSize,
+	}
+}
+
+// ForApp returns a client of the endpoint of the application,
+// whose queries only return its inputs and outputs
+func (c *Client) ForApp(appContract common.Address) *Client {
+	endpoint := c.endpoint + "/" + appContract.Hex()
+	return &Client{
+		endpoint:   endpoint,
+		httpClient: c.httpClient,
+		graphql:    graphql.NewClient(endpoint, c.httpClient),
+		PageSize:   c.PageSize,
+	}
+}
+
+// Endpoint is the URL the queries are sent to
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// GraphQL returns the underlying client, to call the generated queries
+func (c *Client) GraphQL() graphql.Client {
+	return c.graphql
+}
@@ -0,0 +1,256 @@
+package readerclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience"
+	cModel "github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/reader"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	clientApp      = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+	otherApp       = common.HexToAddress("0x75135d8ADb7180640d29d822D9AD59E83E8695b2")
+	clientSender   = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	otherSender    = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	clientToken    = common.HexToAddress("0xc6e7DF5E7b4f2A278906862b61205850344D4e7d")
+	clientSiblings = []string{
+		common.HexToHash("0x01").Hex(),
+		common.HexToHash("0x02").Hex(),
+		common.HexToHash("0x03").Hex(),
+	}
+)
+
+const clientInputs = 7
+
+type ReaderClientSuite struct {
+	suite.Suite
+	dbFactory *commons.DbFactory
+	container *convenience.Container
+	server    *httptest.Server
+	client    *Client
+}
+
+func TestReaderClientSuite(t *testing.T) {
+	suite.Run(t, new(ReaderClientSuite))
+}
+
+func (s *ReaderClientSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("readerclient.sqlite3")
+	s.container = convenience.NewContainer(*db, false)
+	convenienceService := s.container.GetConvenienceService()
+	e := echo.New()
+	reader.Register(e, convenienceService, reader.NewAdapterV1(db, convenienceService), reader.Options{})
+	s.server = httptest.NewServer(e)
+	s.client = NewClient(s.server.URL+"/graphql", s.server.Client())
+	s.client.PageSize = 3
+	s.seed()
+}
+
+func (s *ReaderClientSuite) TearDownTest() {
+	s.server.Close()
+	s.dbFactory.Cleanup()
+}
+
+func (s *ReaderClientSuite) seed() {
+	ctx := context.Background()
+	for i := 0; i < clientInputs; i++ {
+		sender := clientSender
+		if i%2 == 1 {
+			sender = otherSender
+		}
+		_, err := s.container.GetInputRepository().Create(ctx, cModel.AdvanceInput{
+			ID:             strconv.Itoa(i),
+			Index:          i,
+			Status:         cModel.CompletionStatusAccepted,
+			MsgSender:      sender,
+			Payload:        "0x00",
+			BlockNumber:    uint64(100 + i),
+			BlockTimestamp: time.Unix(int64(1700000000+i), 0),
+			AppContract:    clientApp,
+		})
+		s.Require().NoError(err)
+	}
+	_, err := s.container.GetInputRepository().Create(ctx, cModel.AdvanceInput{
+		ID:          "other-0",
+		Index:       0,
+		Status:      cModel.CompletionStatusAccepted,
+		MsgSender:   clientSender,
+		Payload:     "0x00",
+		AppContract: otherApp,
+	})
+	s.Require().NoError(err)
+
+	siblings, err := json.Marshal(clientSiblings)
+	s.Require().NoError(err)
+	for i := 0; i < 4; i++ {
+		voucher := &cModel.ConvenienceVoucher{
+			Destination: clientToken,
+			Payload:     "0xcafe",
+			InputIndex:  uint64(i),
+			OutputIndex: uint64(i),
+			Executed:    i == 0,
+			Value:       "0",
+			AppContract: clientApp,
+		}
+		if i == 3 {
+			voucher.Destination = clientSender
+		}
+		_, err := s.container.GetVoucherRepository().CreateVoucher(ctx, voucher)
+		s.Require().NoError(err)
+	}
+	err = s.container.GetVoucherRepository().SetProof(ctx, &cModel.ConvenienceVoucher{
+		AppContract:          clientApp,
+		OutputIndex:          1,
+		ProofOutputIndex:     1,
+		OutputHashesSiblings: string(siblings),
+	})
+	s.Require().NoError(err)
+
+	_, err = s.container.GetNoticeRepository().Create(ctx, &cModel.ConvenienceNotice{
+		AppContract:          clientApp.Hex(),
+		Payload:              "0xdeadbeef",
+		InputIndex:           1,
+		OutputIndex:          4,
+		ProofOutputIndex:     4,
+		OutputHashesSiblings: string(siblings),
+	})
+	s.Require().NoError(err)
+
+	for i, payload := range []string{
+		`{"type":"trade","amount":150}`,
+		`{"type":"trade","amount":50}`,
+		`{"type":"deposit","amount":300}`,
+	} {
+		_, err := s.container.GetReportRepository().CreateReport(ctx, cModel.Report{
+			Index:       i,
+			InputIndex:  i,
+			Payload:     hexutil.Encode([]byte(payload)),
+			AppContract: clientApp,
+		})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *ReaderClientSuite) TestInputsArePaginated() {
+	ctx := context.Background()
+	it := s.client.ForApp(clientApp).Inputs(nil)
+	indexes := []int{}
+	for it.Next(ctx) {
+		indexes = append(indexes, it.Value().Index)
+	}
+	s.Require().NoError(it.Err())
+	s.Equal([]int{0, 1, 2, 3, 4, 5, 6}, indexes)
+	s.Equal(clientInputs, it.TotalCount())
+
+	all, err := s.client.Inputs(nil).All(ctx)
+	s.Require().NoError(err)
+	s.Len(all, clientInputs+1)
+}
+
+func (s *ReaderClientSuite) TestInputFilter() {
+	where := InputsWhere().MsgSender(clientSender).IndexGreaterThan(1).IndexLowerThan(6).Build()
+	inputs, err := s.client.ForApp(clientApp).Inputs(where).All(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(inputs, 2)
+	s.Equal(2, inputs[0].Index)
+	s.Equal(4, inputs[1].Index)
+	s.Equal(clientSender.Hex(), inputs[0].MsgSender)
+	s.Equal(CompletionStatusAccepted, inputs[0].Status)
+}
+
+func (s *ReaderClientSuite) TestVoucherFilter() {
+	ctx := context.Background()
+	app := s.client.ForApp(clientApp)
+	vouchers, err := app.Vouchers(nil).All(ctx)
+	s.Require().NoError(err)
+	s.Len(vouchers, 4)
+
+	where := VouchersWhere().Destination(clientToken).Executed(false).Build()
+	vouchers, err = app.Vouchers(where).All(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(vouchers, 2)
+	s.Equal(1, vouchers[0].Index)
+	s.Equal(2, vouchers[1].Index)
+	s.Equal(clientToken.Hex(), vouchers[0].Destination)
+}
+
+func (s *ReaderClientSuite) TestPayloadFilter() {
+	ctx := context.Background()
+	app := s.client.ForApp(clientApp)
+	reports, err := app.Reports(PayloadWhere().Eq("$.type", "trade").Gt("$.amount", "100").Build()).All(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(reports, 1)
+	s.Equal(0, reports[0].Index)
+	s.Require().NotNil(reports[0].PayloadJson)
+	s.JSONEq(`{"type":"trade","amount":150}`, *reports[0].PayloadJson)
+
+	reports, err = app.Reports(PayloadWhere().In("$.type", "trade", "deposit").Build()).All(ctx)
+	s.Require().NoError(err)
+	s.Len(reports, 3)
+
+	notices, err := app.Notices(nil).All(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(notices, 1)
+	s.Equal(1, notices[0].Input.Index)
+}
+
+func (s *ReaderClientSuite) TestVoucherExecution() {
+	ctx := context.Background()
+	app := s.client.ForApp(clientApp)
+
+	_, err := app.VoucherExecution(ctx, 2)
+	s.ErrorIs(err, ErrProofNotAvailable)
+
+	tx, err := app.VoucherExecution(ctx, 1)
+	s.Require().NoError(err)
+	s.Equal(clientApp.Hex(), tx.To)
+	msg, err := tx.CallMsg(clientSender)
+	s.Require().NoError(err)
+	s.Equal(clientApp, *msg.To)
+	s.Equal(clientSender, msg.From)
+	s.NotEmpty(msg.Data)
+
+	voucher, err := app.VoucherProof(ctx, 1)
+	s.Require().NoError(err)
+	s.Require().NotNil(voucher.Proof)
+	s.Equal("1", voucher.Proof.OutputIndex)
+	s.Len(voucher.Proof.OutputHashesSiblings, len(clientSiblings))
+
+	tx, err = app.WaitVoucherExecution(ctx, 1, time.Millisecond)
+	s.Require().NoError(err)
+	s.Equal(clientApp.Hex(), tx.To)
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = app.WaitVoucherExecution(timeout, 2, time.Millisecond)
+	s.True(errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+}
+
+func (s *ReaderClientSuite) TestNoticeValidation() {
+	tx, err := s.client.ForApp(clientApp).NoticeValidation(context.Background(), 4)
+	s.Require().NoError(err)
+	s.Equal(clientApp.Hex(), tx.To)
+	s.NotEmpty(tx.Data)
+}
+
+func (s *ReaderClientSuite) TestQueryError() {
+	client := NewClient(s.server.URL+"/graphql/not-an-address", s.server.Client())
+	it := client.Inputs(nil)
+	s.False(it.Next(context.Background()))
+	s.Error(it.Err())
+}
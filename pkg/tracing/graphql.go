@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// GraphQLTracer is a gqlgen extension with a span per operation and
+// per resolver field. The fields read straight from the parent
+// object have no span, they would only add noise.
+type GraphQLTracer struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = GraphQLTracer{}
+
+func (GraphQLTracer) ExtensionName() string {
+	return "OpenTelemetry"
+}
+
+func (GraphQLTracer) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (GraphQLTracer) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+	oc := graphql.GetOperationContext(ctx)
+	operationType := "query"
+	if oc.Operation != nil {
+		operationType = string(oc.Operation.Operation)
+	}
+	name := oc.OperationName
+	if name == "" {
+		name = operationType
+	}
+	ctx, span := Start(ctx, "graphql "+name,
+		attribute.String("graphql.operation.name", oc.OperationName),
+		attribute.String("graphql.operation.type", operationType),
+	)
+	defer span.End()
+	res := next(ctx)
+	if res != nil && len(res.Errors) > 0 {
+		span.SetStatus(codes.Error, res.Errors.Error())
+	}
+	return res
+}
+
+func (GraphQLTracer) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+	ctx, span := Start(ctx, fc.Object+"."+fc.Field.Name,
+		attribute.String("graphql.field.path", fc.Path().String()),
+	)
+	res, err := next(ctx)
+	End(span, err)
+	return res, err
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"github.com/XSAM/otelsql"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// MustConnect is sqlx.MustConnect with a span per query. The
+// sqlx driver name is kept, so the dialect checks still work.
+func MustConnect(driverName string, dsn string) *sqlx.DB {
+	db, err := otelsql.Open(driverName, dsn,
+		otelsql.WithAttributes(dbSystem(driverName)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitConnectorConnect: true,
+			OmitRows:             true,
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	sqlxDb := sqlx.NewDb(db, driverName)
+	err = sqlxDb.Ping()
+	if err != nil {
+		panic(err)
+	}
+	return sqlxDb
+}
+
+func dbSystem(driverName string) attribute.KeyValue {
+	if driverName == "postgres" {
+		return semconv.DBSystemPostgreSQL
+	}
+	return semconv.DBSystemSqlite
+}
@@ -0,0 +1,107 @@
+// Package tracing exports OpenTelemetry spans of the HTTP requests,
+// GraphQL operations, SQL queries and synchronizer steps over OTLP.
+// Without an endpoint the spans are no-ops.
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	DefaultServiceName  = "cartesi-rollups-hl-graphql"
+	instrumentationName = "github.com/calindra/cartesi-rollups-hl-graphql"
+	shutdownTimeout     = 5 * time.Second
+)
+
+type Config struct {
+	// Endpoint is the OTLP/HTTP collector URL, like http://localhost:4318.
+	// Tracing is off when empty.
+	Endpoint    string
+	ServiceName string
+}
+
+// Provider flushes the spans on shutdown
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// Setup installs the global tracer provider. It returns nil when
+// tracing is off, so the global provider stays a no-op.
+func Setup(ctx context.Context, config Config) (*Provider, error) {
+	if config.Endpoint == "" {
+		return nil, nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(config.Endpoint))
+	if err != nil {
+		return nil, err
+	}
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	slog.Info("Tracing enabled", "endpoint", config.Endpoint, "service", serviceName)
+	return &Provider{provider}, nil
+}
+
+func (p *Provider) String() string {
+	return "tracing"
+}
+
+// Start implements supervisor.Worker.
+// It waits for the shutdown to flush the pending spans.
+func (p *Provider) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := p.provider.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Failed to flush the spans", "error", err)
+	}
+	return ctx.Err()
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start begins a span that is a child of the span in the context, if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	previous trace.TracerProvider
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
+
+func (s *TracingSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	s.previous = otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *TracingSuite) TearDownTest() {
+	otel.SetTracerProvider(s.previous)
+}
+
+func (s *TracingSuite) TestSetupWithoutEndpoint() {
+	provider, err := Setup(context.Background(), Config{})
+	s.NoError(err)
+	s.Nil(provider)
+}
+
+func (s *TracingSuite) TestQuerySpanIsChildOfStep() {
+	db := MustConnect("sqlite3", filepath.Join(s.T().TempDir(), "tracing.sqlite3"))
+	defer db.Close()
+
+	ctx, span := Start(context.Background(), "sync inputs")
+	var one int
+	err := db.GetContext(ctx, &one, "SELECT 1")
+	End(span, err)
+	s.Require().NoError(err)
+
+	var query sdktrace.ReadOnlySpan
+	for _, ended := range s.recorder.Ended() {
+		for _, attr := range ended.Attributes() {
+			if attr.Key == semconv.DBStatementKey && attr.Value.AsString() == "SELECT 1" {
+				query = ended
+			}
+		}
+	}
+	s.Require().NotNil(query, "missing the span of the query")
+	s.Equal(span.SpanContext().SpanID(), query.Parent().SpanID())
+	s.Contains(query.Attributes(), semconv.DBSystemSqlite)
+}
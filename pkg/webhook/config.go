@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EventTypes are the events an endpoint can subscribe to
+var EventTypes = []string{
+	model.WEBHOOK_VOUCHER_PROOF,
+	model.WEBHOOK_VOUCHER_EXECUTED,
+	model.WEBHOOK_INPUT_EXCEPTION,
+}
+
+// Endpoint receives the events of one app, or of all of them when
+// AppContract is empty. An empty Events list subscribes to every event.
+type Endpoint struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	AppContract string   `json:"appContract,omitempty"`
+	Events      []string `json:"events,omitempty"`
+}
+
+type Config struct {
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// LoadConfig reads and validates the JSON file with the endpoints
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhooks config %s: %w", path, err)
+	}
+	for i, endpoint := range config.Endpoints {
+		err = endpoint.validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook endpoint %d: %w", i, err)
+		}
+	}
+	return &config, nil
+}
+
+func (e Endpoint) validate() error {
+	parsed, err := url.Parse(e.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid url %q", e.URL)
+	}
+	if e.Secret == "" {
+		return fmt.Errorf("missing secret for %s", e.URL)
+	}
+	if e.AppContract != "" && !common.IsHexAddress(e.AppContract) {
+		return fmt.Errorf("invalid app contract %q", e.AppContract)
+	}
+	for _, event := range e.Events {
+		if !slices.Contains(EventTypes, event) {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+	return nil
+}
+
+// Matches tells if the endpoint subscribes to the event of the app
+func (e Endpoint) Matches(eventType string, appContract string) bool {
+	if e.AppContract != "" &&
+		common.HexToAddress(e.AppContract) != common.HexToAddress(appContract) {
+		return false
+	}
+	return len(e.Events) == 0 || slices.Contains(e.Events, eventType)
+}
@@ -0,0 +1,301 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+)
+
+const (
+	DefaultInterval    = 2 * time.Second
+	DefaultBatchSize   = 50
+	DefaultMaxAttempts = 8
+	DefaultMinBackoff  = 10 * time.Second
+	DefaultMaxBackoff  = time.Hour
+	DefaultTimeout     = 10 * time.Second
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	IdHeader        = "X-Webhook-Id"
+)
+
+// Payload is the JSON body posted to the endpoints
+type Payload struct {
+	Id          int64           `json:"id"`
+	Type        string          `json:"type"`
+	AppContract string          `json:"appContract"`
+	CreatedAt   int64           `json:"createdAt"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Dispatcher fans the outbox events out to the subscribed endpoints
+// and posts them, retrying the failures with exponential backoff.
+// A delivery fails for good after MaxAttempts.
+// The endpoints are posted to concurrently, and an endpoint that cannot be
+// reached has its other deliveries postponed, so it does not stall the rest.
+type Dispatcher struct {
+	Repository  *repository.WebhookRepository
+	Endpoints   []Endpoint
+	Client      *http.Client
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	now         func() time.Time
+}
+
+func NewDispatcher(webhookRepository *repository.WebhookRepository, endpoints []Endpoint) *Dispatcher {
+	return &Dispatcher{
+		Repository:  webhookRepository,
+		Endpoints:   endpoints,
+		Client:      &http.Client{Timeout: DefaultTimeout},
+		Interval:    DefaultInterval,
+		BatchSize:   DefaultBatchSize,
+		MaxAttempts: DefaultMaxAttempts,
+		MinBackoff:  DefaultMinBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		now:         time.Now,
+	}
+}
+
+func (d *Dispatcher) String() string {
+	return "webhooks"
+}
+
+// Start implements supervisor.Worker.
+func (d *Dispatcher) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	slog.Info("Webhook dispatcher started", "endpoints", len(d.Endpoints), "interval", d.Interval)
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// the events and deliveries stay stored, the next tick retries them
+			if err := d.Flush(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Webhook flush failed", "err", err)
+			}
+		}
+	}
+}
+
+// Flush creates the deliveries of the new events and
+// makes the attempts that are due
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	err := d.fanOut(ctx)
+	if err != nil {
+		return err
+	}
+	return d.deliver(ctx)
+}
+
+func (d *Dispatcher) fanOut(ctx context.Context) error {
+	events, err := d.Repository.FindPendingEvents(ctx, d.BatchSize)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	txCtx, tx, err := repository.StartTransactionContext(ctx, d.Repository.Db)
+	if err != nil {
+		return err
+	}
+	now := d.now().Unix()
+	for _, event := range events {
+		err = d.Repository.Dispatch(txCtx, event, d.subscribers(event), now)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				slog.Error("transaction rollback error", "err", rollbackErr)
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// subscribers returns the urls of the endpoints that want the event
+func (d *Dispatcher) subscribers(event model.WebhookEvent) []string {
+	urls := []string{}
+	for _, endpoint := range d.Endpoints {
+		if endpoint.Matches(event.EventType, event.AppContract) {
+			urls = append(urls, endpoint.URL)
+		}
+	}
+	return urls
+}
+
+func (d *Dispatcher) deliver(ctx context.Context) error {
+	deliveries, err := d.Repository.FindDueDeliveries(ctx, d.now().Unix(), d.BatchSize)
+	if err != nil {
+		return err
+	}
+	byEndpoint := map[string][]model.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		byEndpoint[delivery.Endpoint] = append(byEndpoint[delivery.Endpoint], delivery)
+	}
+	var wg sync.WaitGroup
+	results := make(chan []model.WebhookDelivery, len(byEndpoint))
+	for _, endpointDeliveries := range byEndpoint {
+		wg.Add(1)
+		go func(deliveries []model.WebhookDelivery) {
+			defer wg.Done()
+			results <- d.attempt(ctx, deliveries)
+		}(endpointDeliveries)
+	}
+	wg.Wait()
+	close(results)
+	// the updates are not concurrent, as SQLite has a single writer
+	for attempted := range results {
+		for _, delivery := range attempted {
+			err = d.Repository.UpdateDelivery(ctx, delivery)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// attempt posts the deliveries of an endpoint in order. When the endpoint
+// cannot be reached, the remaining deliveries wait for the next attempt of
+// the failed one, so a dead endpoint costs a single timeout per flush.
+func (d *Dispatcher) attempt(ctx context.Context, deliveries []model.WebhookDelivery) []model.WebhookDelivery {
+	var postponedTo int64
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		if postponedTo != 0 {
+			delivery.NextAttemptAt = postponedTo
+			continue
+		}
+		event, err := d.Repository.FindEventById(ctx, delivery.EventId)
+		if err != nil {
+			// the delivery is left as it is, the next flush retries it
+			slog.Error("Webhook event lookup failed", "eventId", delivery.EventId, "err", err)
+			continue
+		}
+		statusCode, err := d.post(ctx, *delivery, *event)
+		d.record(delivery, statusCode, err)
+		if err != nil && statusCode == 0 && delivery.Status == model.DELIVERY_PENDING {
+			postponedTo = delivery.NextAttemptAt
+		}
+	}
+	return deliveries
+}
+
+func (d *Dispatcher) post(
+	ctx context.Context,
+	delivery model.WebhookDelivery,
+	event model.WebhookEvent,
+) (int, error) {
+	endpoint := d.endpoint(delivery.Endpoint, event)
+	if endpoint == nil {
+		return 0, fmt.Errorf("endpoint is no longer configured")
+	}
+	body, err := json.Marshal(Payload{
+		Id:          event.Id,
+		Type:        event.EventType,
+		AppContract: event.AppContract,
+		CreatedAt:   event.CreatedAt,
+		Data:        json.RawMessage(event.Data),
+	})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.EventType)
+	req.Header.Set(IdHeader, strconv.FormatInt(event.Id, 10))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// endpoint finds the configuration of the delivery url, it is
+// gone when the config changed after the event was fanned out
+func (d *Dispatcher) endpoint(url string, event model.WebhookEvent) *Endpoint {
+	for i, endpoint := range d.Endpoints {
+		if endpoint.URL == url && endpoint.Matches(event.EventType, event.AppContract) {
+			return &d.Endpoints[i]
+		}
+	}
+	return nil
+}
+
+// record updates the delivery with the attempt result
+func (d *Dispatcher) record(delivery *model.WebhookDelivery, statusCode int, err error) {
+	now := d.now()
+	delivery.Attempts++
+	delivery.ResponseStatus = statusCode
+	delivery.UpdatedAt = now.Unix()
+	if err == nil {
+		delivery.Status = model.DELIVERY_DELIVERED
+		delivery.LastError = ""
+		return
+	}
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.MaxAttempts {
+		delivery.Status = model.DELIVERY_FAILED
+		slog.Warn("Webhook delivery failed",
+			"endpoint", delivery.Endpoint,
+			"eventId", delivery.EventId,
+			"attempts", delivery.Attempts,
+			"error", err,
+		)
+		return
+	}
+	delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts)).Unix()
+	slog.Debug("Webhook delivery will be retried",
+		"endpoint", delivery.Endpoint,
+		"eventId", delivery.EventId,
+		"attempts", delivery.Attempts,
+		"error", err,
+	)
+}
+
+// backoff doubles the wait after each failed attempt, up to MaxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.MinBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// Sign is the value of the X-Webhook-Signature header: the hex
+// HMAC-SHA256 of the body with the endpoint secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/commons"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/model"
+	"github.com/calindra/cartesi-rollups-hl-graphql/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+var app = common.HexToAddress("0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb")
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+type DispatcherSuite struct {
+	suite.Suite
+	dbFactory  *commons.DbFactory
+	repository *repository.WebhookRepository
+	server     *httptest.Server
+	mutex      sync.Mutex
+	requests   []received
+	statusCode int
+	now        time.Time
+}
+
+func TestDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherSuite))
+}
+
+func (s *DispatcherSuite) SetupTest() {
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory = commons.NewDbFactory()
+	db := s.dbFactory.CreateDb("webhook.sqlite3")
+	s.repository = &repository.WebhookRepository{Db: db}
+	s.Require().NoError(s.repository.CreateTables())
+	s.requests = nil
+	s.statusCode = http.StatusOK
+	s.now = time.Unix(1000, 0)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.requests = append(s.requests, received{r.Header.Clone(), body})
+		w.WriteHeader(s.statusCode)
+	}))
+}
+
+func (s *DispatcherSuite) TearDownTest() {
+	s.server.Close()
+	s.dbFactory.Cleanup()
+}
+
+func (s *DispatcherSuite) newDispatcher(endpoints ...Endpoint) *Dispatcher {
+	dispatcher := NewDispatcher(s.repository, endpoints)
+	dispatcher.MaxAttempts = 3
+	dispatcher.now = func() time.Time { return s.now }
+	return dispatcher
+}
+
+func (s *DispatcherSuite) deliveries() []model.WebhookDelivery {
+	res, err := s.repository.FindAllDeliveries(context.Background(), nil, nil, nil, nil,
+		repository.WebhookDeliveryFilter{})
+	s.Require().NoError(err)
+	return res.Rows
+}
+
+func (s *DispatcherSuite) TestSignedDelivery() {
+	ctx := context.Background()
+	dispatcher := s.newDispatcher(
+		Endpoint{URL: s.server.URL, Secret: "s3cr3t", Events: []string{model.WEBHOOK_VOUCHER_EXECUTED}},
+		Endpoint{URL: s.server.URL + "/other", Secret: "x", AppContract: common.Address{}.Hex()},
+	)
+	inputIndex := uint64(1)
+	err := s.repository.AddEvent(ctx, model.WEBHOOK_VOUCHER_EXECUTED, app,
+		model.VoucherExecutedEvent{InputIndex: &inputIndex, OutputIndex: 2, TransactionHash: "0x01"})
+	s.Require().NoError(err)
+	err = s.repository.AddEvent(ctx, model.WEBHOOK_INPUT_EXCEPTION, app,
+		model.InputExceptionEvent{InputIndex: 3})
+	s.Require().NoError(err)
+
+	s.Require().NoError(dispatcher.Flush(ctx))
+
+	// only the first endpoint wants the execution and none the exception
+	s.Require().Len(s.requests, 1)
+	req := s.requests[0]
+	s.Equal(Sign("s3cr3t", req.body), req.header.Get(SignatureHeader))
+	s.Equal(model.WEBHOOK_VOUCHER_EXECUTED, req.header.Get(EventHeader))
+	var payload Payload
+	s.Require().NoError(json.Unmarshal(req.body, &payload))
+	s.Equal(app.Hex(), payload.AppContract)
+	s.JSONEq(`{"inputIndex":1,"outputIndex":2,"transactionHash":"0x01"}`, string(payload.Data))
+
+	deliveries := s.deliveries()
+	s.Require().Len(deliveries, 1)
+	s.Equal(model.DELIVERY_DELIVERED, deliveries[0].Status)
+	s.Equal(1, deliveries[0].Attempts)
+	s.Equal(http.StatusOK, deliveries[0].ResponseStatus)
+}
+
+func (s *DispatcherSuite) TestRetryWithBackoff() {
+	ctx := context.Background()
+	dispatcher := s.newDispatcher(Endpoint{URL: s.server.URL, Secret: "s3cr3t"})
+	s.statusCode = http.StatusInternalServerError
+	err := s.repository.AddEvent(ctx, model.WEBHOOK_INPUT_EXCEPTION, app,
+		model.InputExceptionEvent{InputIndex: 3})
+	s.Require().NoError(err)
+
+	s.Require().NoError(dispatcher.Flush(ctx))
+	delivery := s.deliveries()[0]
+	s.Equal(model.DELIVERY_PENDING, delivery.Status)
+	s.Equal(http.StatusInternalServerError, delivery.ResponseStatus)
+	s.Equal(s.now.Add(DefaultMinBackoff).Unix(), delivery.NextAttemptAt)
+
+	// not due yet
+	s.Require().NoError(dispatcher.Flush(ctx))
+	s.Len(s.requests, 1)
+
+	s.now = s.now.Add(DefaultMinBackoff)
+	s.Require().NoError(dispatcher.Flush(ctx))
+	delivery = s.deliveries()[0]
+	s.Equal(2, delivery.Attempts)
+	s.Equal(s.now.Add(2*DefaultMinBackoff).Unix(), delivery.NextAttemptAt)
+
+	s.now = s.now.Add(2 * DefaultMinBackoff)
+	s.Require().NoError(dispatcher.Flush(ctx))
+	delivery = s.deliveries()[0]
+	s.Equal(model.DELIVERY_FAILED, delivery.Status)
+	s.Equal(3, delivery.Attempts)
+	s.Contains(delivery.LastError, "500")
+	s.Len(s.requests, 3)
+}
+
+func (s *DispatcherSuite) TestDeadEndpoint() {
+	ctx := context.Background()
+	release := make(chan struct{})
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer dead.Close()
+	defer close(release)
+	dispatcher := s.newDispatcher(
+		Endpoint{URL: dead.URL, Secret: "x"},
+		Endpoint{URL: s.server.URL, Secret: "s3cr3t"},
+	)
+	dispatcher.Client.Timeout = 100 * time.Millisecond
+	for i := 0; i < 3; i++ {
+		err := s.repository.AddEvent(ctx, model.WEBHOOK_INPUT_EXCEPTION, app,
+			model.InputExceptionEvent{InputIndex: uint64(i)})
+		s.Require().NoError(err)
+	}
+
+	start := time.Now()
+	s.Require().NoError(dispatcher.Flush(ctx))
+	// a single timeout, the dead endpoint does not hold the other one
+	s.Less(time.Since(start), time.Second)
+	s.Len(s.requests, 3)
+
+	attempts := 0
+	for _, delivery := range s.deliveries() {
+		if delivery.Endpoint == s.server.URL {
+			s.Equal(model.DELIVERY_DELIVERED, delivery.Status)
+			continue
+		}
+		s.Equal(model.DELIVERY_PENDING, delivery.Status)
+		s.Equal(s.now.Add(DefaultMinBackoff).Unix(), delivery.NextAttemptAt)
+		attempts += delivery.Attempts
+	}
+	// only the first delivery was attempted, the others were postponed
+	s.Equal(1, attempts)
+}
+
+func (s *DispatcherSuite) TestLoadConfig() {
+	path := filepath.Join(s.T().TempDir(), "webhooks.json")
+	err := os.WriteFile(path, []byte(`{"endpoints": [
+		{"url": "https://example.com/hook", "secret": "abc", "events": ["voucher.proof"]}
+	]}`), 0600)
+	s.Require().NoError(err)
+	config, err := LoadConfig(path)
+	s.Require().NoError(err)
+	s.Require().Len(config.Endpoints, 1)
+	s.True(config.Endpoints[0].Matches(model.WEBHOOK_VOUCHER_PROOF, app.Hex()))
+	s.False(config.Endpoints[0].Matches(model.WEBHOOK_INPUT_EXCEPTION, app.Hex()))
+
+	err = os.WriteFile(path, []byte(`{"endpoints": [
+		{"url": "https://example.com/hook", "secret": "abc", "events": ["voucher.created"]}
+	]}`), 0600)
+	s.Require().NoError(err)
+	_, err = LoadConfig(path)
+	s.ErrorContains(err, "unknown event")
+}